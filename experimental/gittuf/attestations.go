@@ -266,6 +266,50 @@ func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer ss
 	return allAttestations.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
 }
 
+// AddPushCertificate records a Git push certificate, such as one created using
+// `git push --signed`, in the attestations. A push certificate signed by an
+// authorized principal is accepted as evidence for the RSL entries that record
+// the reference updates in the certificate, even if the entries are created by
+// someone else. The certificate's signature is checked during verification.
+func (r *Repository) AddPushCertificate(certificate []byte, signCommit bool, opts ...attestopts.Option) error {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	options := &attestopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	pushCertificate, err := gitinterface.ParsePushCertificate(certificate)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current set of attestations...")
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetPushCertificate(r.r, certificate); err != nil {
+		return err
+	}
+
+	refNames := []string{}
+	for _, update := range pushCertificate.Updates {
+		refNames = append(refNames, fmt.Sprintf("'%s'", update.RefName))
+	}
+	commitMessage := fmt.Sprintf("Add push certificate for %s", strings.Join(refNames, ", "))
+
+	slog.Debug("Committing attestations...")
+	return allAttestations.Commit(r.r, commitMessage, options.CreateRSLEntry, signCommit)
+}
+
 // AddGitHubPullRequestAttestationForCommit identifies the pull request for a
 // specified commit ID and triggers AddGitHubPullRequestAttestationForNumber for
 // that pull request. The source of the authentication token for the GitHub API
@@ -696,7 +740,11 @@ func (r *Repository) getGitHubPullRequestReviewDetails(ctx context.Context, curr
 		toID = commit.GetTree().GetSHA()
 	} else {
 		// Check the RSL instead for from ID.
-		entry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(baseRef), rsl.IsUnskipped())
+		skipAuthorizer, err := r.currentSkipAuthorizer(ctx)
+		if err != nil {
+			return "", "", "", err
+		}
+		entry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(baseRef), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(skipAuthorizer))
 		if err != nil {
 			return "", "", "", err
 		}
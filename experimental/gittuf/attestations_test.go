@@ -22,6 +22,7 @@ import (
 	"github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestApplyAttestations(t *testing.T) {
@@ -284,6 +285,39 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 	})
 }
 
+func TestAddPushCertificate(t *testing.T) {
+	testDir := t.TempDir()
+	r := gitinterface.CreateTestGitRepository(t, testDir, false)
+	repo := &Repository{r: r}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, refName, 1, gpgKeyBytes)
+
+	certificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[0]}}, gpgKeyBytes)
+
+	err := repo.AddPushCertificate(certificate, false, attestopts.WithRSLEntry())
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(r)
+	require.Nil(t, err)
+	assert.Equal(t, attestations.Ref, latestEntry.(*rsl.ReferenceEntry).RefName)
+
+	attestationsCommitID, err := r.GetReference(attestations.Ref)
+	require.Nil(t, err)
+	commitMessage, err := r.GetCommitMessage(attestationsCommitID)
+	require.Nil(t, err)
+	assert.Equal(t, "Add push certificate for 'refs/heads/main'", commitMessage)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	require.Nil(t, err)
+	pushCertificate, err := allAttestations.GetPushCertificateFor(r, refName, gitinterface.ZeroHash.String(), commitIDs[0].String())
+	assert.Nil(t, err)
+	assert.True(t, pushCertificate.HasUpdate(refName, gitinterface.ZeroHash, commitIDs[0]))
+
+	err = repo.AddPushCertificate([]byte("not a push certificate"), false)
+	assert.ErrorIs(t, err, gitinterface.ErrInvalidPushCertificate)
+}
+
 func TestGetGitHubPullRequestApprovalPredicateFromEnvelope(t *testing.T) {
 	tests := map[string]struct {
 		envelope          *dsse.Envelope
@@ -15,8 +15,8 @@ const (
 )
 
 // PopulateCache scans the repository's RSL and generates a persistent
-// local-only cache of policy and attestation entries. This makes subsequent
-// verifications faster.
+// local-only cache of policy and attestation entries as well as an index of the
+// RSL. This makes subsequent verifications and RSL lookups faster.
 func (r *Repository) PopulateCache() error {
 	return cache.PopulatePersistentCache(r.r)
 }
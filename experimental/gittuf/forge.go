@@ -0,0 +1,409 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const (
+	// PushReceiptsRef is the local-only reference used to store the receipts
+	// returned by the forge for pushes.
+	PushReceiptsRef = "refs/local/gittuf/push-receipts"
+
+	// PushReceiptPrefix precedes an encoded push receipt in the messages the
+	// forge sends to the pusher.
+	PushReceiptPrefix = "gittuf-receipt: "
+
+	// forgeRecordAttempts is the number of times the forge tries to record an
+	// entry when the RSL is concurrently updated by other pushes.
+	forgeRecordAttempts = 5
+)
+
+var (
+	// ErrInvalidPushReceipt is returned when a push receipt is not an RSL
+	// entry created by the forge for references delegated to it.
+	ErrInvalidPushReceipt = errors.New("push receipt is not an RSL entry created by the trusted forge")
+
+	// ErrPushNotInRSL is returned for a push receipt whose RSL entry is not
+	// in the RSL, i.e., the forge acknowledged the push but dropped it.
+	ErrPushNotInRSL = errors.New("RSL entry for push receipt not found in RSL")
+
+	// ErrDroppedPushes is returned when at least one of the stored push
+	// receipts is for an entry that is not in the RSL.
+	ErrDroppedPushes = errors.New("one or more pushes acknowledged by the forge are not recorded in the RSL")
+)
+
+// PushReceiptResult records the outcome of checking a single push receipt
+// against the RSL.
+type PushReceiptResult struct {
+	// EntryID is the ID of the RSL entry the forge created for the push.
+	EntryID gitinterface.Hash
+
+	// RefNames are the references whose updates are recorded in the entry.
+	RefNames []string
+
+	// Err is ErrPushNotInRSL if the entry is not in the RSL, nil otherwise.
+	Err error
+}
+
+// EncodePushReceipt returns the message the forge sends to the pusher for a
+// push receipt.
+func EncodePushReceipt(receipt []byte) string {
+	return PushReceiptPrefix + base64.StdEncoding.EncodeToString(receipt)
+}
+
+// DecodePushReceipt returns the push receipt in a message created by
+// EncodePushReceipt. The prefix of the message is optional.
+func DecodePushReceipt(message string) ([]byte, error) {
+	message = strings.TrimPrefix(strings.TrimSpace(message), PushReceiptPrefix)
+	receipt, err := base64.StdEncoding.DecodeString(strings.TrimSpace(message))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPushReceipt, err)
+	}
+
+	return receipt, nil
+}
+
+// RecordPushedReferenceUpdates records the reference updates received in a
+// push in the RSL as the forge trusted in the root of trust, for example, in
+// Git's post-receive hook once the updates are applied. Only updates to
+// references delegated to the forge are recorded, atomically in a single
+// entry, and updates whose latest entries already record the new tips are
+// omitted. The raw contents of the entry's commit are returned as the receipt
+// for the push, which the pusher can store using StorePushReceipt. If no
+// updates must be recorded, such as when no forge is trusted, a nil receipt is
+// returned.
+func (r *Repository) RecordPushedReferenceUpdates(ctx context.Context, updates []*ReferenceUpdate, signCommit bool) ([]byte, error) {
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	forge, err := r.loadForge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if forge == nil {
+		slog.Debug("Policy does not trust a forge, skipping...")
+		return nil, nil
+	}
+
+	skipAuthorizer, err := r.currentSkipAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDs := map[string]gitinterface.Hash{}
+	for _, update := range updates {
+		if strings.HasPrefix(update.RefName, "refs/gittuf/") || !forge.Matches(update.RefName) {
+			slog.Debug(fmt.Sprintf("Reference '%s' is not delegated to the forge, skipping...", update.RefName))
+			continue
+		}
+
+		isDuplicate, err := r.isDuplicateEntry(update.RefName, update.NewTip, skipAuthorizer)
+		if err != nil {
+			return nil, err
+		}
+		if isDuplicate {
+			slog.Debug(fmt.Sprintf("The latest entry for '%s' has the same target, skipping...", update.RefName))
+			continue
+		}
+
+		targetIDs[update.RefName] = update.NewTip
+	}
+
+	if len(targetIDs) == 0 {
+		slog.Debug("No references need to be recorded, skipping creation of new entry...")
+		return nil, nil
+	}
+
+	var entry interface {
+		rsl.Entry
+		Commit(*gitinterface.Repository, bool) error
+	}
+	for attempt := 1; ; attempt++ {
+		if len(targetIDs) == 1 {
+			slog.Debug("Creating RSL reference entry...")
+			for refName, targetID := range targetIDs {
+				entry = rsl.NewReferenceEntry(refName, targetID)
+			}
+		} else {
+			slog.Debug("Creating RSL multi-reference entry...")
+			entry = rsl.NewMultiReferenceEntry(targetIDs)
+		}
+
+		// The RSL may be updated by concurrent pushes between the entry being
+		// numbered and committed
+		err := entry.Commit(r.r, signCommit)
+		if err == nil {
+			break
+		}
+		if attempt == forgeRecordAttempts {
+			return nil, err
+		}
+		slog.Debug(fmt.Sprintf("Unable to record entry, retrying: %s", err.Error()))
+	}
+
+	entryID, err := r.r.GetReference(rsl.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Recorded push in RSL entry '%s'", entryID.String()))
+	return r.r.ReadCommitObject(entryID)
+}
+
+// StorePushReceipt validates and stores a receipt returned by the forge for a
+// push, so that CheckPushReceipts can later detect if the push was dropped.
+// The receipt must be an RSL entry signed by the forge trusted in the current
+// policy for references delegated to it. The ID of the entry is returned.
+func (r *Repository) StorePushReceipt(ctx context.Context, receipt []byte) (gitinterface.Hash, error) {
+	entryID, err := r.r.WriteCommitObject(receipt)
+	if err != nil {
+		return gitinterface.ZeroHash, fmt.Errorf("%w: %w", ErrInvalidPushReceipt, err)
+	}
+
+	referenceEntries, err := r.getPushReceiptEntries(entryID)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	for _, referenceEntry := range referenceEntries {
+		createdByForge, err := state.EntryCreatedByForge(ctx, referenceEntry)
+		if err != nil {
+			return gitinterface.ZeroHash, err
+		}
+		if !createdByForge {
+			return gitinterface.ZeroHash, fmt.Errorf("%w: entry is not signed by the forge for '%s'", ErrInvalidPushReceipt, referenceEntry.RefName)
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Storing receipt for RSL entry '%s'...", entryID.String()))
+	receiptBlobID, err := r.r.WriteBlob(receipt)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	receipts, err := r.loadPushReceipts()
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+	receipts[entryID.String()] = receiptBlobID
+
+	if err := r.writePushReceipts(receipts, fmt.Sprintf("Add push receipt for '%s'\n", entryID.String())); err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	return entryID, nil
+}
+
+// CheckPushReceipts checks that the RSL entry of each stored push receipt is
+// in the local RSL, which must be fetched from the forge first. The result for
+// each receipt is returned, ordered by entry ID; ErrDroppedPushes is returned
+// if any entries are missing. Receipts whose entries are found are removed, as
+// the pushes are now recorded in the RSL, and any subsequent rewrite of the RSL
+// is detected by verifying it.
+func (r *Repository) CheckPushReceipts(_ context.Context) ([]PushReceiptResult, error) {
+	receipts, err := r.loadPushReceipts()
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		return nil, nil
+	}
+
+	receiptEntries := map[string]rsl.Entry{}
+	lowestNumber := uint64(0)
+	for entryIDString, receiptBlobID := range receipts {
+		// The entry's commit may have been removed by garbage collection if
+		// the entry isn't in the RSL
+		receipt, err := r.r.ReadBlob(receiptBlobID)
+		if err != nil {
+			return nil, err
+		}
+		entryID, err := r.r.WriteCommitObject(receipt)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := rsl.GetEntry(r.r, entryID)
+		if err != nil {
+			return nil, err
+		}
+		receiptEntries[entryIDString] = entry
+
+		if lowestNumber == 0 || entry.GetNumber() < lowestNumber {
+			lowestNumber = entry.GetNumber()
+		}
+	}
+
+	slog.Debug("Searching RSL for entries of push receipts...")
+	foundEntryIDs, err := r.findRSLEntriesFromNumber(lowestNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PushReceiptResult, 0, len(receipts))
+	dropped := false
+	for _, entryIDString := range slices.Sorted(maps.Keys(receiptEntries)) {
+		entry := receiptEntries[entryIDString]
+
+		result := PushReceiptResult{EntryID: entry.GetID()}
+		for _, referenceEntry := range rsl.GetReferenceUpdaterEntries(entry) {
+			result.RefNames = append(result.RefNames, referenceEntry.GetRefName())
+		}
+
+		if foundEntryIDs[entryIDString] {
+			delete(receipts, entryIDString)
+		} else {
+			slog.Debug(fmt.Sprintf("Entry '%s' not found in RSL", entryIDString))
+			result.Err = ErrPushNotInRSL
+			dropped = true
+		}
+		results = append(results, result)
+	}
+
+	if err := r.writePushReceipts(receipts, "Remove push receipts recorded in RSL\n"); err != nil {
+		return nil, err
+	}
+
+	if dropped {
+		return results, ErrDroppedPushes
+	}
+	return results, nil
+}
+
+// loadForge returns the forge trusted in the current policy, nil if there is
+// no policy or the policy does not trust a forge.
+func (r *Repository) loadForge(ctx context.Context) (tuf.Forge, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return rootMetadata.GetForge(), nil
+}
+
+// getPushReceiptEntries returns the updates recorded in the RSL entry of a
+// push receipt.
+func (r *Repository) getPushReceiptEntries(entryID gitinterface.Hash) ([]*rsl.ReferenceEntry, error) {
+	entry, err := rsl.GetEntry(r.r, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPushReceipt, err)
+	}
+
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		return []*rsl.ReferenceEntry{entry}, nil
+	case *rsl.MultiReferenceEntry:
+		return entry.GetReferenceEntries(), nil
+	default:
+		return nil, fmt.Errorf("%w: entry does not record reference updates", ErrInvalidPushReceipt)
+	}
+}
+
+// loadPushReceipts returns the stored push receipts, mapping the IDs of their
+// RSL entries to the blobs containing the receipts.
+func (r *Repository) loadPushReceipts() (map[string]gitinterface.Hash, error) {
+	commitID, err := r.r.GetReference(PushReceiptsRef)
+	if err != nil {
+		if errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return map[string]gitinterface.Hash{}, nil
+		}
+		return nil, err
+	}
+
+	treeID, err := r.r.GetCommitTreeID(commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.r.GetTreeItems(treeID)
+}
+
+// writePushReceipts records the push receipts in PushReceiptsRef. The
+// reference is not updated if the receipts are unchanged.
+func (r *Repository) writePushReceipts(receipts map[string]gitinterface.Hash, message string) error {
+	entries := make([]gitinterface.TreeEntry, 0, len(receipts))
+	for entryID, receiptBlobID := range receipts {
+		entries = append(entries, gitinterface.NewEntryBlob(entryID, receiptBlobID))
+	}
+
+	treeID, err := gitinterface.NewTreeBuilder(r.r).WriteTreeFromEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	currentCommitID, err := r.r.GetReference(PushReceiptsRef)
+	if err == nil {
+		currentTreeID, err := r.r.GetCommitTreeID(currentCommitID)
+		if err == nil && treeID.Equal(currentTreeID) {
+			return nil
+		}
+	} else if len(receipts) == 0 {
+		return nil
+	}
+
+	_, err = r.r.Commit(treeID, PushReceiptsRef, message, false)
+	return err
+}
+
+// findRSLEntriesFromNumber returns the IDs of the entries in the RSL, walking
+// back from the latest entry until an entry numbered lower than number is
+// found.
+func (r *Repository) findRSLEntriesFromNumber(number uint64) (map[string]bool, error) {
+	entryIDs := map[string]bool{}
+
+	entry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return entryIDs, nil
+		}
+		return nil, err
+	}
+
+	for entry.GetNumber() >= number {
+		entryIDs[entry.GetID().String()] = true
+
+		entry, err = rsl.GetParentForEntry(r.r, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	return entryIDs, nil
+}
@@ -0,0 +1,136 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordPushedReferenceUpdates(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	t.Run("no forge", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		rslTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		updates := []*ReferenceUpdate{{RefName: mainRef, OldTip: gitinterface.ZeroHash, NewTip: commitIDs[0]}}
+
+		receipt, err := repo.RecordPushedReferenceUpdates(testCtx, updates, true)
+		assert.Nil(t, err)
+		assert.Nil(t, receipt)
+
+		currentRSLTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, rslTip, currentRSLTip)
+	})
+
+	t.Run("with forge", func(t *testing.T) {
+		repo := createTestRepositoryWithForge(t)
+
+		mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, featureRef, 1, gpgUnauthorizedKeyBytes)
+		updates := []*ReferenceUpdate{
+			{RefName: mainRef, OldTip: gitinterface.ZeroHash, NewTip: mainCommitIDs[0]},
+			{RefName: featureRef, OldTip: gitinterface.ZeroHash, NewTip: featureCommitIDs[0]},
+			{RefName: "refs/tags/v1", OldTip: gitinterface.ZeroHash, NewTip: mainCommitIDs[0]},
+		}
+
+		receipt, err := repo.RecordPushedReferenceUpdates(testCtx, updates, true)
+		require.Nil(t, err)
+		require.NotNil(t, receipt)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		multiReferenceEntry, isMultiReferenceEntry := latestEntry.(*rsl.MultiReferenceEntry)
+		require.True(t, isMultiReferenceEntry)
+		assert.Equal(t, []string{featureRef, mainRef}, multiReferenceEntry.GetRefNames())
+
+		expectedReceipt, err := repo.r.ReadCommitObject(latestEntry.GetID())
+		require.Nil(t, err)
+		assert.Equal(t, expectedReceipt, receipt)
+
+		// The entry created by the forge meets the policy for main
+		err = repo.VerifyRef(testCtx, mainRef, verifyopts.WithLatestOnly())
+		assert.Nil(t, err)
+
+		// Updates already recorded are not recorded again
+		receipt, err = repo.RecordPushedReferenceUpdates(testCtx, updates, true)
+		assert.Nil(t, err)
+		assert.Nil(t, receipt)
+	})
+}
+
+func TestPushReceipts(t *testing.T) {
+	mainRef := "refs/heads/main"
+
+	t.Run("store and check receipt", func(t *testing.T) {
+		repo := createTestRepositoryWithForge(t)
+		rslTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		results, err := repo.CheckPushReceipts(testCtx)
+		assert.Nil(t, err)
+		assert.Empty(t, results)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		updates := []*ReferenceUpdate{{RefName: mainRef, OldTip: gitinterface.ZeroHash, NewTip: commitIDs[0]}}
+		receipt, err := repo.RecordPushedReferenceUpdates(testCtx, updates, true)
+		require.Nil(t, err)
+
+		decodedReceipt, err := DecodePushReceipt(EncodePushReceipt(receipt))
+		require.Nil(t, err)
+		assert.Equal(t, receipt, decodedReceipt)
+
+		entryID, err := repo.StorePushReceipt(testCtx, decodedReceipt)
+		require.Nil(t, err)
+
+		// The forge drops the push
+		require.Nil(t, repo.r.SetReference(rsl.Ref, rslTip))
+
+		results, err = repo.CheckPushReceipts(testCtx)
+		assert.ErrorIs(t, err, ErrDroppedPushes)
+		assert.Equal(t, []PushReceiptResult{{EntryID: entryID, RefNames: []string{mainRef}, Err: ErrPushNotInRSL}}, results)
+
+		// The receipt is retained until the push is in the RSL
+		require.Nil(t, repo.r.SetReference(rsl.Ref, entryID))
+
+		results, err = repo.CheckPushReceipts(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []PushReceiptResult{{EntryID: entryID, RefNames: []string{mainRef}}}, results)
+
+		results, err = repo.CheckPushReceipts(testCtx)
+		assert.Nil(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("receipt not created by forge", func(t *testing.T) {
+		repo := createTestRepositoryWithForge(t)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(mainRef, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		receipt, err := repo.r.ReadCommitObject(entryID)
+		require.Nil(t, err)
+
+		_, err = repo.StorePushReceipt(testCtx, receipt)
+		assert.ErrorIs(t, err, ErrInvalidPushReceipt)
+
+		_, err = repo.StorePushReceipt(testCtx, []byte("invalid"))
+		assert.ErrorIs(t, err, ErrInvalidPushReceipt)
+
+		_, err = DecodePushReceipt(PushReceiptPrefix + "invalid!")
+		assert.ErrorIs(t, err, ErrInvalidPushReceipt)
+	})
+}
@@ -148,6 +148,28 @@ func createTestRepositoryWithPolicyAuthorizingGitSigningKey(t *testing.T, opts .
 	return r
 }
 
+// createTestRepositoryWithForge sets up a repository whose policy trusts the
+// SSH key configured as the repository's Git signing key (see
+// setupSigningKeys) as the forge for all branches.
+func createTestRepositoryWithForge(t *testing.T) *Repository {
+	t.Helper()
+
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	gitSigningKey := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+
+	if err := r.SetForge(testCtx, rootSigner, []tuf.Principal{gitSigningKey}, []string{"refs/heads/*"}, false, trustpolicyopts.WithRSLEntry()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Apply(testCtx, r.r, false); err != nil {
+		t.Fatalf("failed to apply policy staging changes into policy, err = %s", err)
+	}
+
+	return r
+}
+
 func createTestRepositoryWithPolicyWithFileRule(t *testing.T, location string) *Repository {
 	t.Helper()
 
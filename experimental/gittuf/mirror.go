@@ -0,0 +1,288 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var (
+	// ErrMirrorVerificationFailed is returned when at least one of the
+	// references changed on the source remote fails gittuf verification. In
+	// that case, no references are mirrored to the destination remote.
+	ErrMirrorVerificationFailed = errors.New("one or more references failed gittuf verification and were not mirrored")
+
+	// ErrSourceRSLNotFound is returned when the source remote of a mirror does
+	// not have an RSL, so its references cannot be verified.
+	ErrSourceRSLNotFound = errors.New("source remote does not have an RSL")
+)
+
+// mirroredRefPrefixes are the namespaces of the references propagated by
+// Mirror. Other references, such as GitHub's pull request references, are
+// typically read-only on the forge and are not mirrored.
+var mirroredRefPrefixes = []string{
+	gitinterface.BranchRefPrefix,
+	gitinterface.TagRefPrefix,
+	"refs/gittuf/",
+}
+
+// Mirror propagates the references of the source remote to the destination
+// remote after verifying them against the source's gittuf policy. The
+// branches, tags, and gittuf references of both remotes are compared, and each
+// reference that differs is verified using the source's RSL and policy, as the
+// destination would see them once mirrored. The source's RSL must extend the
+// destination's RSL, and every changed reference protected by the policy must
+// pass verification, otherwise nothing is mirrored: the source's RSL records
+// the updates of all references, so propagating it without them leaves the
+// destination in a state that fails verification. Unprotected references and
+// gittuf references other than the policy and attestations are mirrored as
+// is. References deleted on the source are deleted on the
+// destination, except for gittuf references. All updates are pushed to the
+// destination atomically, and the push is rejected if the destination changes
+// after it is inspected. The result for each changed reference is returned,
+// starting with the RSL; ErrMirrorVerificationFailed is returned if any of
+// them failed verification.
+func (r *Repository) Mirror(ctx context.Context, sourceRemoteName, destinationRemoteName string) ([]RefVerificationResult, error) {
+	sourceRemoteName, removeSourceRemote, err := r.remoteWithoutGittufTransport(sourceRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	defer removeSourceRemote()
+
+	destinationRemoteName, removeDestinationRemote, err := r.remoteWithoutGittufTransport(destinationRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	defer removeDestinationRemote()
+
+	slog.Debug(fmt.Sprintf("Identifying references on source remote '%s'...", sourceRemoteName))
+	sourceRefs, err := r.listMirroredReferences(sourceRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	sourceRSLTip, hasSourceRSL := sourceRefs[rsl.Ref]
+	if !hasSourceRSL {
+		return nil, ErrSourceRSLNotFound
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying references on destination remote '%s'...", destinationRemoteName))
+	destinationRefs, err := r.listMirroredReferences(destinationRemoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identify the references to update on the destination
+	changedRefNames := []string{}
+	for refName, sourceTip := range sourceRefs {
+		if destinationTip, has := destinationRefs[refName]; !has || !destinationTip.Equal(sourceTip) {
+			changedRefNames = append(changedRefNames, refName)
+		}
+	}
+	for refName := range destinationRefs {
+		if _, has := sourceRefs[refName]; !has && !strings.HasPrefix(refName, "refs/gittuf/") {
+			changedRefNames = append(changedRefNames, refName)
+		}
+	}
+	if len(changedRefNames) == 0 {
+		slog.Debug("Source and destination remotes have the same references, nothing to do")
+		return nil, nil
+	}
+	slices.Sort(changedRefNames)
+
+	slog.Debug("Fetching objects from source remote...")
+	if err := r.fetchMissingObjects(sourceRemoteName, sourceRefs); err != nil {
+		return nil, err
+	}
+
+	destinationRSLTip := gitinterface.ZeroHash
+	if tip, has := destinationRefs[rsl.Ref]; has {
+		destinationRSLTip = tip
+
+		// The destination's RSL is needed to check that the source's RSL
+		// extends it
+		slog.Debug("Fetching RSL from destination remote...")
+		if err := r.fetchMissingObjects(destinationRemoteName, map[string]gitinterface.Hash{rsl.Ref: tip}); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]RefVerificationResult, 0, len(changedRefNames))
+	if !sourceRSLTip.Equal(destinationRSLTip) {
+		result := RefVerificationResult{RefName: rsl.Ref}
+		if err := r.verifyPushedRSL(&ReferenceUpdate{RefName: rsl.Ref, OldTip: destinationRSLTip, NewTip: sourceRSLTip}); err != nil {
+			slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", rsl.Ref, err.Error()))
+			result.Err = err
+			// The other references cannot be verified using the source's RSL
+			return append(results, result), ErrMirrorVerificationFailed
+		}
+		results = append(results, result)
+	}
+
+	// The references are verified in an overlay with the source's references
+	// so that the local repository's references are not updated
+	overlayDir, err := os.MkdirTemp("", "gittuf-mirror-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(overlayDir) //nolint:errcheck
+
+	slog.Debug("Creating overlay repository with source references...")
+	overlayGitRepo, err := r.r.CreateOverlayRepository(overlayDir, sourceRefs)
+	if err != nil {
+		return nil, err
+	}
+	overlay := &Repository{r: overlayGitRepo}
+
+	slog.Debug("Loading source policy...")
+	state, err := policy.LoadCurrentState(ctx, overlayGitRepo, policy.PolicyRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		slog.Debug("Policy not found, references are not protected")
+	}
+
+	failed := false
+	for _, refName := range changedRefNames {
+		if refName == rsl.Ref {
+			continue
+		}
+
+		result := RefVerificationResult{RefName: refName}
+
+		verify := false
+		switch {
+		case refName == policy.PolicyRef || refName == attestations.Ref:
+			verify = true
+		case strings.HasPrefix(refName, "refs/gittuf/"):
+			verify = false
+		case state != nil:
+			verify, err = state.IsProtectedReference(refName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !verify {
+			slog.Debug(fmt.Sprintf("Reference '%s' is not protected by policy, skipping verification...", refName))
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", refName))
+		if err := overlay.VerifyRef(ctx, refName, verifyopts.WithWarnOnStaleHeartbeat()); err != nil {
+			slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", refName, err.Error()))
+			result.Err = err
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	if failed {
+		// The source's RSL cannot be mirrored without the references that
+		// failed verification, and vice versa
+		return results, ErrMirrorVerificationFailed
+	}
+
+	refSpecs := []string{}
+	expectedTips := map[string]gitinterface.Hash{}
+	for _, result := range results {
+		sourceTip, has := sourceRefs[result.RefName]
+		if has {
+			refSpecs = append(refSpecs, fmt.Sprintf("%s:%s", sourceTip.String(), result.RefName))
+		} else {
+			refSpecs = append(refSpecs, fmt.Sprintf(":%s", result.RefName))
+		}
+
+		expectedTips[result.RefName] = gitinterface.ZeroHash
+		if destinationTip, has := destinationRefs[result.RefName]; has {
+			expectedTips[result.RefName] = destinationTip
+		}
+	}
+
+	if len(refSpecs) != 0 {
+		slog.Debug(fmt.Sprintf("Pushing verified references to destination remote '%s'...", destinationRemoteName))
+		if err := r.r.PushRefSpec(destinationRemoteName, refSpecs, gitinterface.WithAtomicPush(), gitinterface.WithExpectedRemoteTips(expectedTips)); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Mirrored references successfully!")
+	return results, nil
+}
+
+// listMirroredReferences returns the references on the remote that are
+// propagated by Mirror.
+func (r *Repository) listMirroredReferences(remoteName string) (map[string]gitinterface.Hash, error) {
+	remoteRefs, err := r.r.ListRemoteReferences(remoteName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]gitinterface.Hash{}
+	for refName, tip := range remoteRefs {
+		for _, prefix := range mirroredRefPrefixes {
+			if strings.HasPrefix(refName, prefix) {
+				refs[refName] = tip
+				break
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// fetchMissingObjects fetches the tips of the references from the remote if
+// they are not present locally. Local references are not updated.
+func (r *Repository) fetchMissingObjects(remoteName string, refs map[string]gitinterface.Hash) error {
+	objectIDs := []string{}
+	for _, tip := range refs {
+		if r.r.HasObject(tip) || slices.Contains(objectIDs, tip.String()) {
+			continue
+		}
+		objectIDs = append(objectIDs, tip.String())
+	}
+	if len(objectIDs) == 0 {
+		return nil
+	}
+
+	return r.r.FetchRefSpec(remoteName, objectIDs)
+}
+
+// remoteWithoutGittufTransport returns the name of a remote that can be used
+// to interact with the specified remote without the gittuf transport. If the
+// remote uses the gittuf transport, a temporary remote is created; the returned
+// function removes it.
+func (r *Repository) remoteWithoutGittufTransport(remoteName string) (string, func(), error) {
+	remoteURL, err := r.r.GetRemoteURL(remoteName)
+	if err != nil {
+		return "", nil, err
+	}
+	if !strings.HasPrefix(remoteURL, gittufTransportPrefix) {
+		return remoteName, func() {}, nil
+	}
+
+	slog.Debug(fmt.Sprintf("Creating new remote for '%s' to avoid using gittuf transport...", remoteName))
+	newRemoteName := fmt.Sprintf("mirror-remote-%s", remoteName)
+	if err := r.r.AddRemote(newRemoteName, strings.TrimPrefix(remoteURL, gittufTransportPrefix)); err != nil {
+		return "", nil, err
+	}
+
+	return newRemoteName, func() {
+		r.r.RemoveRemote(newRemoteName) //nolint:errcheck
+	}, nil
+}
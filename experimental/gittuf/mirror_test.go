@@ -0,0 +1,164 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	// setup creates a source repository with a policy protecting main and a
+	// destination repository, and returns a local repository with both as
+	// remotes
+	setup := func(t *testing.T) (*Repository, *Repository, *gitinterface.Repository) {
+		t.Helper()
+
+		sourceDir := t.TempDir()
+		source := createTestRepositoryWithPolicy(t, sourceDir)
+
+		destinationDir := t.TempDir()
+		destination := gitinterface.CreateTestGitRepository(t, destinationDir, true)
+
+		local := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+		require.Nil(t, local.AddRemote("source", sourceDir))
+		require.Nil(t, local.AddRemote("destination", destinationDir))
+
+		return &Repository{r: local}, source, destination
+	}
+
+	assertRefsMatch := func(t *testing.T, source *Repository, destination *gitinterface.Repository, refNames ...string) {
+		t.Helper()
+
+		for _, refName := range refNames {
+			sourceTip, err := source.r.GetReference(refName)
+			require.Nil(t, err)
+			destinationTip, err := destination.GetReference(refName)
+			require.Nil(t, err)
+			assert.Equal(t, sourceTip, destinationTip, refName)
+		}
+	}
+
+	t.Run("verified references are mirrored", func(t *testing.T) {
+		repo, source, destination := setup(t)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, source.r, mainRef, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, source.r, rsl.NewReferenceEntry(mainRef, commitIDs[0]), gpgKeyBytes)
+		common.AddNTestCommitsToSpecifiedRef(t, source.r, featureRef, 1, gpgUnauthorizedKeyBytes)
+
+		results, err := repo.Mirror(testCtx, "source", "destination")
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{
+			{RefName: rsl.Ref},
+			{RefName: policy.PolicyRef},
+			{RefName: policy.PolicyStagingRef, Skipped: true},
+			{RefName: featureRef, Skipped: true},
+			{RefName: mainRef},
+		}, results)
+		assertRefsMatch(t, source, destination, rsl.Ref, policy.PolicyRef, policy.PolicyStagingRef, mainRef, featureRef)
+
+		// The local repository's references are not updated
+		_, err = repo.r.GetReference(mainRef)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+		_, err = repo.r.GetReference(rsl.Ref)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+
+		// Nothing to do as the remotes have the same references
+		results, err = repo.Mirror(testCtx, "source", "destination")
+		assert.Nil(t, err)
+		assert.Empty(t, results)
+
+		// Deleted references are deleted on the destination
+		require.Nil(t, source.r.DeleteReference(featureRef))
+
+		results, err = repo.Mirror(testCtx, "source", "destination")
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: featureRef, Skipped: true}}, results)
+		_, err = destination.GetReference(featureRef)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+	})
+
+	t.Run("nothing is mirrored if a reference fails verification", func(t *testing.T) {
+		repo, source, destination := setup(t)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, source.r, mainRef, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, source.r, rsl.NewReferenceEntry(mainRef, commitIDs[0]), gpgKeyBytes)
+
+		_, err := repo.Mirror(testCtx, "source", "destination")
+		require.Nil(t, err)
+		priorDestinationTip, err := destination.GetReference(mainRef)
+		require.Nil(t, err)
+		priorDestinationRSLTip, err := destination.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		// Update main using an unauthorized key
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, source.r, mainRef, 1, gpgUnauthorizedKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, source.r, rsl.NewReferenceEntry(mainRef, commitIDs[0]), gpgUnauthorizedKeyBytes)
+		common.AddNTestCommitsToSpecifiedRef(t, source.r, featureRef, 1, gpgUnauthorizedKeyBytes)
+
+		results, err := repo.Mirror(testCtx, "source", "destination")
+		assert.ErrorIs(t, err, ErrMirrorVerificationFailed)
+		require.Len(t, results, 3)
+		assert.Equal(t, RefVerificationResult{RefName: rsl.Ref}, results[0])
+		assert.Equal(t, RefVerificationResult{RefName: featureRef, Skipped: true}, results[1])
+		assert.Equal(t, mainRef, results[2].RefName)
+		assert.ErrorIs(t, results[2].Err, policy.ErrVerificationFailed)
+
+		// Nothing is mirrored, the destination's RSL would otherwise record
+		// an update of main that it does not have
+		destinationTip, err := destination.GetReference(mainRef)
+		require.Nil(t, err)
+		assert.Equal(t, priorDestinationTip, destinationTip)
+		destinationRSLTip, err := destination.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, priorDestinationRSLTip, destinationRSLTip)
+		_, err = destination.GetReference(featureRef)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+	})
+
+	t.Run("source RSL does not extend destination RSL", func(t *testing.T) {
+		repo, source, destination := setup(t)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, source.r, mainRef, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, source.r, rsl.NewReferenceEntry(mainRef, commitIDs[0]), gpgKeyBytes)
+
+		// The destination has an unrelated RSL
+		otherRepo := createTestRepositoryWithPolicy(t, "")
+		require.Nil(t, otherRepo.r.AddRemote("destination", destination.GetGitDir()))
+		require.Nil(t, otherRepo.r.Push("destination", []string{rsl.Ref}))
+
+		results, err := repo.Mirror(testCtx, "source", "destination")
+		assert.ErrorIs(t, err, ErrMirrorVerificationFailed)
+		require.Len(t, results, 1)
+		assert.Equal(t, rsl.Ref, results[0].RefName)
+		assert.ErrorIs(t, results[0].Err, ErrPushedRSLDoesNotExtendRSL)
+
+		// Nothing is mirrored
+		_, err = destination.GetReference(mainRef)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+	})
+
+	t.Run("source without RSL", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		source := gitinterface.CreateTestGitRepository(t, sourceDir, false)
+		common.AddNTestCommitsToSpecifiedRef(t, source, mainRef, 1, gpgKeyBytes)
+
+		local := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+		require.Nil(t, local.AddRemote("source", sourceDir))
+		require.Nil(t, local.AddRemote("destination", t.TempDir()))
+		repo := &Repository{r: local}
+
+		_, err := repo.Mirror(testCtx, "source", "destination")
+		assert.ErrorIs(t, err, ErrSourceRSLNotFound)
+	})
+}
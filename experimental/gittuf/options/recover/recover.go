@@ -0,0 +1,45 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recover
+
+type Options struct {
+	Revert             bool
+	SkipInvalidEntries bool
+	RemoteName         string
+	LocalOnly          bool
+}
+
+type Option func(o *Options)
+
+// WithRevert restores the reference by creating a new commit on top of its
+// current tip that matches the last good state, rather than resetting the
+// reference to the last good commit.
+func WithRevert() Option {
+	return func(o *Options) {
+		o.Revert = true
+	}
+}
+
+// WithSkipInvalidEntries creates an RSL annotation that skips the invalid
+// entries for the reference.
+func WithSkipInvalidEntries() Option {
+	return func(o *Options) {
+		o.SkipInvalidEntries = true
+	}
+}
+
+// WithRemote sets the remote the new RSL entries are pushed to.
+func WithRemote(remoteName string) Option {
+	return func(o *Options) {
+		o.RemoteName = remoteName
+	}
+}
+
+// WithLocalOnly records the new RSL entries locally without pushing them to a
+// remote.
+func WithLocalOnly() Option {
+	return func(o *Options) {
+		o.LocalOnly = true
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRevert(t *testing.T) {
+	options := &Options{}
+
+	option := WithRevert()
+
+	option(options)
+
+	assert.True(t, options.Revert)
+}
+
+func TestWithSkipInvalidEntries(t *testing.T) {
+	options := &Options{}
+
+	option := WithSkipInvalidEntries()
+
+	option(options)
+
+	assert.True(t, options.SkipInvalidEntries)
+}
+
+func TestWithRemote(t *testing.T) {
+	options := &Options{}
+
+	option := WithRemote("origin")
+
+	option(options)
+
+	assert.Equal(t, "origin", options.RemoteName)
+}
+
+func TestWithLocalOnly(t *testing.T) {
+	options := &Options{}
+
+	option := WithLocalOnly()
+
+	option(options)
+
+	assert.True(t, options.LocalOnly)
+}
@@ -5,10 +5,12 @@ package rsl
 
 type RecordOptions struct {
 	RefNameOverride       string
+	RefNameOverrides      []string
 	RemoteName            string
 	LocalOnly             bool
 	SkipCheckForDuplicate bool
 	SigningKeyBytes       []byte
+	Deletion              bool
 }
 
 type RecordOption func(o *RecordOptions)
@@ -19,6 +21,15 @@ func WithOverrideRefName(refNameOverride string) RecordOption {
 	}
 }
 
+// WithOverrideRefNames sets the names recorded in the RSL for the references
+// passed to RecordRSLEntryForReferences, in the same order. An empty name
+// records the corresponding reference under its own name.
+func WithOverrideRefNames(refNameOverrides ...string) RecordOption {
+	return func(o *RecordOptions) {
+		o.RefNameOverrides = refNameOverrides
+	}
+}
+
 // WithSkipCheckForDuplicateEntry indicates that the RSL entry creation must not
 // check if the latest entry for the reference has the same target ID.
 func WithSkipCheckForDuplicateEntry() RecordOption {
@@ -27,6 +38,14 @@ func WithSkipCheckForDuplicateEntry() RecordOption {
 	}
 }
 
+// WithRecordDeletion indicates that the references must be recorded as deleted
+// rather than at their current tips. The references need not exist locally.
+func WithRecordDeletion() RecordOption {
+	return func(o *RecordOptions) {
+		o.Deletion = true
+	}
+}
+
 func WithRecordRemote(remoteName string) RecordOption {
 	return func(o *RecordOptions) {
 		o.RemoteName = remoteName
@@ -55,6 +74,7 @@ type AnnotateOptions struct {
 	RemoteName      string
 	LocalOnly       bool
 	SigningKeyBytes []byte
+	Data            map[string]any
 }
 
 type AnnotateOption func(o *AnnotateOptions)
@@ -78,3 +98,86 @@ func WithAnnotateSigningKeyBytes(pem []byte) AnnotateOption {
 		o.SigningKeyBytes = pem
 	}
 }
+
+// WithAnnotateData records the specified structured data in the annotation.
+// Each value is encoded as JSON.
+func WithAnnotateData(data map[string]any) AnnotateOption {
+	return func(o *AnnotateOptions) {
+		o.Data = data
+	}
+}
+
+type HeartbeatOptions struct {
+	RemoteName      string
+	LocalOnly       bool
+	SigningKeyBytes []byte
+}
+
+type HeartbeatOption func(o *HeartbeatOptions)
+
+func WithHeartbeatRemote(remoteName string) HeartbeatOption {
+	return func(o *HeartbeatOptions) {
+		o.RemoteName = remoteName
+	}
+}
+
+func WithHeartbeatLocalOnly() HeartbeatOption {
+	return func(o *HeartbeatOptions) {
+		o.LocalOnly = true
+	}
+}
+
+// WithHeartbeatSigningKeyBytes provides a PEM-encoded private key to sign the
+// heartbeat commit with directly. See WithRecordSigningKeyBytes.
+func WithHeartbeatSigningKeyBytes(pem []byte) HeartbeatOption {
+	return func(o *HeartbeatOptions) {
+		o.SigningKeyBytes = pem
+	}
+}
+
+type CheckpointOptions struct {
+	RemoteName      string
+	LocalOnly       bool
+	SigningKeyBytes []byte
+}
+
+type CheckpointOption func(o *CheckpointOptions)
+
+func WithCheckpointRemote(remoteName string) CheckpointOption {
+	return func(o *CheckpointOptions) {
+		o.RemoteName = remoteName
+	}
+}
+
+func WithCheckpointLocalOnly() CheckpointOption {
+	return func(o *CheckpointOptions) {
+		o.LocalOnly = true
+	}
+}
+
+// WithCheckpointSigningKeyBytes provides a PEM-encoded private key to sign the
+// checkpoint commit with directly. See WithRecordSigningKeyBytes.
+func WithCheckpointSigningKeyBytes(pem []byte) CheckpointOption {
+	return func(o *CheckpointOptions) {
+		o.SigningKeyBytes = pem
+	}
+}
+
+type BootstrapOptions struct {
+	RemoteName string
+	LocalOnly  bool
+}
+
+type BootstrapOption func(o *BootstrapOptions)
+
+func WithBootstrapRemote(remoteName string) BootstrapOption {
+	return func(o *BootstrapOptions) {
+		o.RemoteName = remoteName
+	}
+}
+
+func WithBootstrapLocalOnly() BootstrapOption {
+	return func(o *BootstrapOptions) {
+		o.LocalOnly = true
+	}
+}
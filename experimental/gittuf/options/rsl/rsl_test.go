@@ -19,6 +19,16 @@ func TestWithOverrideName(t *testing.T) {
 	assert.Equal(t, "refs/gittuf/override", options.RefNameOverride)
 }
 
+func TestWithOverrideRefNames(t *testing.T) {
+	options := &RecordOptions{}
+
+	option := WithOverrideRefNames("refs/heads/main", "")
+
+	option(options)
+
+	assert.Equal(t, []string{"refs/heads/main", ""}, options.RefNameOverrides)
+}
+
 func TestWithSkipCheckForDuplicateEntry(t *testing.T) {
 	options := &RecordOptions{}
 
@@ -29,6 +39,16 @@ func TestWithSkipCheckForDuplicateEntry(t *testing.T) {
 	assert.True(t, options.SkipCheckForDuplicate)
 }
 
+func TestWithRecordDeletion(t *testing.T) {
+	options := &RecordOptions{}
+
+	option := WithRecordDeletion()
+
+	option(options)
+
+	assert.True(t, options.Deletion)
+}
+
 func TestWithRecordRemote(t *testing.T) {
 	options := &RecordOptions{}
 
@@ -68,3 +88,63 @@ func TestWithAnnotateLocalOnly(t *testing.T) {
 
 	assert.True(t, options.LocalOnly)
 }
+
+func TestWithHeartbeatRemote(t *testing.T) {
+	options := &HeartbeatOptions{}
+
+	option := WithHeartbeatRemote("origin")
+
+	option(options)
+
+	assert.Equal(t, "origin", options.RemoteName)
+}
+
+func TestWithHeartbeatLocalOnly(t *testing.T) {
+	options := &HeartbeatOptions{}
+
+	option := WithHeartbeatLocalOnly()
+
+	option(options)
+
+	assert.True(t, options.LocalOnly)
+}
+
+func TestWithCheckpointRemote(t *testing.T) {
+	options := &CheckpointOptions{}
+
+	option := WithCheckpointRemote("origin")
+
+	option(options)
+
+	assert.Equal(t, "origin", options.RemoteName)
+}
+
+func TestWithCheckpointLocalOnly(t *testing.T) {
+	options := &CheckpointOptions{}
+
+	option := WithCheckpointLocalOnly()
+
+	option(options)
+
+	assert.True(t, options.LocalOnly)
+}
+
+func TestWithBootstrapRemote(t *testing.T) {
+	options := &BootstrapOptions{}
+
+	option := WithBootstrapRemote("origin")
+
+	option(options)
+
+	assert.Equal(t, "origin", options.RemoteName)
+}
+
+func TestWithBootstrapLocalOnly(t *testing.T) {
+	options := &BootstrapOptions{}
+
+	option := WithBootstrapLocalOnly()
+
+	option(options)
+
+	assert.True(t, options.LocalOnly)
+}
@@ -4,8 +4,10 @@
 package verify
 
 type Options struct {
-	RefNameOverride string
-	LatestOnly      bool
+	RefNameOverride      string
+	LatestOnly           bool
+	WarnOnStaleHeartbeat bool
+	Full                 bool
 }
 
 type Option func(o *Options)
@@ -21,3 +23,20 @@ func WithLatestOnly() Option {
 		o.LatestOnly = true
 	}
 }
+
+// WithWarnOnStaleHeartbeat indicates that a missing or stale RSL heartbeat must
+// be reported as a warning rather than failing verification.
+func WithWarnOnStaleHeartbeat() Option {
+	return func(o *Options) {
+		o.WarnOnStaleHeartbeat = true
+	}
+}
+
+// WithFull indicates that the RSL must be verified from its first entry rather
+// than from the latest trusted checkpoint or the last verified entry recorded
+// in the persistent cache.
+func WithFull() Option {
+	return func(o *Options) {
+		o.Full = true
+	}
+}
@@ -28,3 +28,23 @@ func TestWithLatestOnly(t *testing.T) {
 
 	assert.True(t, options.LatestOnly)
 }
+
+func TestWithWarnOnStaleHeartbeat(t *testing.T) {
+	options := &Options{}
+
+	option := WithWarnOnStaleHeartbeat()
+
+	option(options)
+
+	assert.True(t, options.WarnOnStaleHeartbeat)
+}
+
+func TestWithFull(t *testing.T) {
+	options := &Options{}
+
+	option := WithFull()
+
+	option(options)
+
+	assert.True(t, options.Full)
+}
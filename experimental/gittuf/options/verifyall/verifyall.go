@@ -0,0 +1,44 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyall
+
+type Options struct {
+	LatestOnly           bool
+	ProtectedOnly        bool
+	ChangedSinceEntry    string
+	WarnOnStaleHeartbeat bool
+}
+
+type Option func(o *Options)
+
+// WithLatestOnly verifies each reference against only its latest RSL entry.
+func WithLatestOnly() Option {
+	return func(o *Options) {
+		o.LatestOnly = true
+	}
+}
+
+// WithProtectedOnly selects references matched by a Git namespace rule in the
+// current policy rather than every reference with entries in the RSL.
+func WithProtectedOnly() Option {
+	return func(o *Options) {
+		o.ProtectedOnly = true
+	}
+}
+
+// WithChangedSinceEntry limits verification to references that have RSL
+// entries after the specified entry.
+func WithChangedSinceEntry(entryID string) Option {
+	return func(o *Options) {
+		o.ChangedSinceEntry = entryID
+	}
+}
+
+// WithWarnOnStaleHeartbeat indicates that a missing or stale RSL heartbeat must
+// be reported as a warning rather than failing verification.
+func WithWarnOnStaleHeartbeat() Option {
+	return func(o *Options) {
+		o.WarnOnStaleHeartbeat = true
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLatestOnly(t *testing.T) {
+	options := &Options{}
+
+	option := WithLatestOnly()
+
+	option(options)
+
+	assert.True(t, options.LatestOnly)
+}
+
+func TestWithProtectedOnly(t *testing.T) {
+	options := &Options{}
+
+	option := WithProtectedOnly()
+
+	option(options)
+
+	assert.True(t, options.ProtectedOnly)
+}
+
+func TestWithChangedSinceEntry(t *testing.T) {
+	options := &Options{}
+
+	option := WithChangedSinceEntry("abcdef")
+
+	option(options)
+
+	assert.Equal(t, "abcdef", options.ChangedSinceEntry)
+}
+
+func TestWithWarnOnStaleHeartbeat(t *testing.T) {
+	options := &Options{}
+
+	option := WithWarnOnStaleHeartbeat()
+
+	option(options)
+
+	assert.True(t, options.WarnOnStaleHeartbeat)
+}
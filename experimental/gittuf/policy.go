@@ -47,9 +47,22 @@ func (r *Repository) PullPolicy(remoteName string) error {
 	return nil
 }
 
-// HasPolicy indicates if the repository has a gittuf policy applied.
+// HasPolicy indicates if the repository has a gittuf policy applied. See
+// HasPolicyWithContext.
 func (r *Repository) HasPolicy() (bool, error) {
-	_, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef), rsl.IsUnskipped())
+	return r.HasPolicyWithContext(context.Background())
+}
+
+// HasPolicyWithContext indicates if the repository has a gittuf policy
+// applied. Skip annotations for the policy's RSL entries are only honored if
+// they are permitted by the current policy, which is loaded using ctx.
+func (r *Repository) HasPolicyWithContext(ctx context.Context) (bool, error) {
+	skipAuthorizer, err := r.currentSkipAuthorizer(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, _, err = rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(policy.PolicyRef), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(skipAuthorizer))
 	if err != nil {
 		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
 			return false, nil
@@ -61,6 +74,21 @@ func (r *Repository) HasPolicy() (bool, error) {
 	return true, nil
 }
 
+// currentSkipAuthorizer returns the authorizer for skip annotations in the
+// RSL using the current policy. If the repository does not have a policy, nil
+// is returned so that all skip annotations are honored.
+func (r *Repository) currentSkipAuthorizer(ctx context.Context) (rsl.SkipAuthorizer, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return state.SkipAuthorizer(ctx), nil
+}
+
 func (r *Repository) ApplyPolicy(ctx context.Context, remoteName string, localOnly, signRSLEntry bool) error {
 	if signRSLEntry {
 		slog.Debug("Checking if Git signing is configured...")
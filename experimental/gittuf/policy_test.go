@@ -127,6 +127,10 @@ func TestHasPolicy(t *testing.T) {
 		hasPolicy, err := repo.HasPolicy()
 		assert.Nil(t, err)
 		assert.True(t, hasPolicy)
+
+		hasPolicy, err = repo.HasPolicyWithContext(testCtx)
+		assert.Nil(t, err)
+		assert.True(t, hasPolicy)
 	})
 
 	t.Run("policy does not exist", func(t *testing.T) {
@@ -137,6 +141,10 @@ func TestHasPolicy(t *testing.T) {
 		hasPolicy, err := repo.HasPolicy()
 		assert.Nil(t, err)
 		assert.False(t, hasPolicy)
+
+		hasPolicy, err = repo.HasPolicyWithContext(testCtx)
+		assert.Nil(t, err)
+		assert.False(t, hasPolicy)
 	})
 }
 
@@ -0,0 +1,258 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var (
+	// ErrPushVerificationFailed is returned when at least one of the
+	// reference updates received in a push fails gittuf verification.
+	ErrPushVerificationFailed = errors.New("one or more pushed references failed gittuf verification")
+
+	// ErrPushedRSLDoesNotExtendRSL is returned when the RSL received in a push
+	// does not build on the repository's RSL, i.e., the push rewrites or
+	// deletes RSL entries.
+	ErrPushedRSLDoesNotExtendRSL = errors.New("pushed RSL does not extend the repository's RSL")
+
+	// ErrInvalidReferenceUpdate is returned when a reference update provided
+	// by Git to a receive hook cannot be parsed.
+	ErrInvalidReferenceUpdate = errors.New("invalid reference update, expected '<old> <new> <ref>'")
+)
+
+// ReferenceUpdate describes an update to a Git reference received in a push,
+// as reported to Git's pre-receive hook.
+type ReferenceUpdate struct {
+	// RefName is the absolute name of the updated reference.
+	RefName string
+
+	// OldTip is the tip of the reference prior to the push, the zero hash if
+	// the reference is created.
+	OldTip gitinterface.Hash
+
+	// NewTip is the tip of the reference after the push, the zero hash if the
+	// reference is deleted.
+	NewTip gitinterface.Hash
+}
+
+// ReadReferenceUpdates parses the reference updates Git provides to the
+// pre-receive and post-receive hooks on stdin, one per line in the form
+// `<old> <new> <ref>`.
+func ReadReferenceUpdates(reader io.Reader) ([]*ReferenceUpdate, error) {
+	updates := []*ReferenceUpdate{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidReferenceUpdate, line)
+		}
+
+		oldTip, err := gitinterface.NewHash(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %w", ErrInvalidReferenceUpdate, line, err)
+		}
+		newTip, err := gitinterface.NewHash(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %w", ErrInvalidReferenceUpdate, line, err)
+		}
+
+		updates = append(updates, &ReferenceUpdate{RefName: fields[2], OldTip: oldTip, NewTip: newTip})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// VerifyPushedReferenceUpdates verifies the reference updates received in a
+// push before they are applied to the repository, for example, in Git's
+// pre-receive hook. The updates are verified using the RSL and policy as they
+// would be once the push is applied. The pushed RSL must extend the
+// repository's RSL, and for each updated reference protected by the policy,
+// the latest RSL entry for the reference must match its new tip and meet the
+// policy. Updates to references delegated to the forge trusted in the policy
+// are not recorded in the pushed RSL; the forge records them using
+// RecordPushedReferenceUpdates once the push is applied. As the forge's RSL
+// entry meets the Git namespace policies, these updates are only checked
+// against the file namespace policies. Updates to unprotected
+// references and to gittuf references other than the RSL are skipped. The
+// result for each update is returned, starting with the RSL;
+// ErrPushVerificationFailed is returned if any of them failed verification. If
+// the pushed RSL is rejected, the other updates are not verified.
+func (r *Repository) VerifyPushedReferenceUpdates(ctx context.Context, updates []*ReferenceUpdate) ([]RefVerificationResult, error) {
+	slog.Debug("Identifying gittuf references after push...")
+	gittufRefNames, err := r.r.ListReferences("refs/gittuf/")
+	if err != nil {
+		return nil, err
+	}
+
+	overlayRefs := map[string]gitinterface.Hash{}
+	for _, refName := range gittufRefNames {
+		tip, err := r.r.GetReference(refName)
+		if err != nil {
+			return nil, err
+		}
+		overlayRefs[refName] = tip
+	}
+	for _, update := range updates {
+		if update.NewTip.IsZero() {
+			delete(overlayRefs, update.RefName)
+			continue
+		}
+		overlayRefs[update.RefName] = update.NewTip
+	}
+
+	// The pushed updates are applied to an overlay of the repository so that
+	// they can be verified without updating the repository's references
+	overlayDir, err := os.MkdirTemp("", "gittuf-push-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(overlayDir) //nolint:errcheck
+
+	slog.Debug("Creating overlay repository with pushed references...")
+	overlayGitRepo, err := r.r.CreateOverlayRepository(overlayDir, overlayRefs)
+	if err != nil {
+		return nil, err
+	}
+	overlay := &Repository{r: overlayGitRepo}
+
+	results := make([]RefVerificationResult, 0, len(updates))
+	failed := false
+	for _, update := range updates {
+		if update.RefName != rsl.Ref {
+			continue
+		}
+
+		result := RefVerificationResult{RefName: update.RefName}
+		if err := r.verifyPushedRSL(update); err != nil {
+			slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", update.RefName, err.Error()))
+			result.Err = err
+			failed = true
+		}
+		results = append(results, result)
+	}
+	if failed {
+		// The remaining updates cannot be verified using the pushed RSL
+		return results, ErrPushVerificationFailed
+	}
+
+	slog.Debug("Loading policy after push...")
+	var forge tuf.Forge
+	state, err := policy.LoadCurrentState(ctx, overlayGitRepo, policy.PolicyRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		slog.Debug("Policy not found, references are not protected")
+	} else {
+		rootMetadata, err := state.GetRootMetadata(false)
+		if err != nil {
+			return nil, err
+		}
+		forge = rootMetadata.GetForge()
+	}
+
+	for _, update := range updates {
+		if update.RefName == rsl.Ref {
+			continue
+		}
+
+		result := RefVerificationResult{RefName: update.RefName}
+
+		isProtected := false
+		if state != nil && !strings.HasPrefix(update.RefName, "refs/gittuf/") {
+			isProtected, err = state.IsProtectedReference(update.RefName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !isProtected {
+			slog.Debug(fmt.Sprintf("Reference '%s' is not protected by policy, skipping...", update.RefName))
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		if forge != nil && forge.Matches(update.RefName) {
+			result.DelegatedToForge = true
+
+			// Deletions and tags recorded by the forge are not verified
+			// against the policy, see policy.EntryCreatedByForge
+			if update.NewTip.IsZero() || strings.HasPrefix(update.RefName, gitinterface.TagRefPrefix) {
+				slog.Debug(fmt.Sprintf("Reference '%s' is delegated to the forge, skipping...", update.RefName))
+				results = append(results, result)
+				continue
+			}
+
+			slog.Debug(fmt.Sprintf("Reference '%s' is delegated to the forge, verifying that it is mergeable...", update.RefName))
+			if err := policy.NewPolicyVerifier(overlayGitRepo).VerifyMergeableForForge(ctx, update.RefName, update.NewTip); err != nil {
+				slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", update.RefName, err.Error()))
+				result.Err = err
+				failed = true
+			}
+			results = append(results, result)
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", update.RefName))
+		if err := overlay.VerifyRef(ctx, update.RefName, verifyopts.WithLatestOnly(), verifyopts.WithWarnOnStaleHeartbeat()); err != nil {
+			slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", update.RefName, err.Error()))
+			result.Err = err
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	if failed {
+		return results, ErrPushVerificationFailed
+	}
+
+	slog.Debug("Verification successful!")
+	return results, nil
+}
+
+// verifyPushedRSL checks that the update to the RSL received in a push only
+// adds entries to the repository's RSL.
+func (r *Repository) verifyPushedRSL(update *ReferenceUpdate) error {
+	if update.OldTip.IsZero() {
+		// The repository does not have an RSL yet
+		return nil
+	}
+	if update.NewTip.IsZero() {
+		return ErrPushedRSLDoesNotExtendRSL
+	}
+
+	extendsRSL, err := r.r.KnowsCommit(update.NewTip, update.OldTip)
+	if err != nil {
+		return err
+	}
+	if !extendsRSL {
+		return ErrPushedRSLDoesNotExtendRSL
+	}
+
+	return nil
+}
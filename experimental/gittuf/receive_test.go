@@ -0,0 +1,171 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReferenceUpdates(t *testing.T) {
+	oldTip := "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+	newTip := "5ab2f8a4323abafb10abb68657d9d39f1a775057"
+
+	updates, err := ReadReferenceUpdates(strings.NewReader(fmt.Sprintf("%s %s refs/heads/main\n\n%s %s refs/heads/feature\n", oldTip, newTip, gitinterface.ZeroHash.String(), newTip)))
+	require.Nil(t, err)
+	require.Len(t, updates, 2)
+	assert.Equal(t, "refs/heads/main", updates[0].RefName)
+	assert.Equal(t, oldTip, updates[0].OldTip.String())
+	assert.Equal(t, newTip, updates[0].NewTip.String())
+	assert.Equal(t, "refs/heads/feature", updates[1].RefName)
+	assert.True(t, updates[1].OldTip.IsZero())
+
+	_, err = ReadReferenceUpdates(strings.NewReader("refs/heads/main\n"))
+	assert.ErrorIs(t, err, ErrInvalidReferenceUpdate)
+
+	_, err = ReadReferenceUpdates(strings.NewReader("invalid invalid refs/heads/main\n"))
+	assert.ErrorIs(t, err, ErrInvalidReferenceUpdate)
+}
+
+func TestVerifyPushedReferenceUpdates(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	// pushCommit adds a commit to refName signed using keyBytes, optionally
+	// with an RSL entry, and then restores the refs to their prior tips so
+	// that the push can be verified before it's applied
+	pushCommit := func(t *testing.T, repo *Repository, refName string, keyBytes []byte, recordRSLEntry bool) []*ReferenceUpdate {
+		t.Helper()
+
+		refNames := []string{refName}
+		if recordRSLEntry {
+			refNames = append(refNames, rsl.Ref)
+		}
+
+		updates := []*ReferenceUpdate{}
+		for _, name := range refNames {
+			oldTip, err := repo.r.GetReference(name)
+			if err != nil {
+				oldTip = gitinterface.ZeroHash
+			}
+			updates = append(updates, &ReferenceUpdate{RefName: name, OldTip: oldTip})
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, keyBytes)
+		if recordRSLEntry {
+			entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+			common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, keyBytes)
+		}
+
+		for _, update := range updates {
+			newTip, err := repo.r.GetReference(update.RefName)
+			require.Nil(t, err)
+			update.NewTip = newTip
+
+			if update.OldTip.IsZero() {
+				require.Nil(t, repo.r.DeleteReference(update.RefName))
+			} else {
+				require.Nil(t, repo.r.SetReference(update.RefName, update.OldTip))
+			}
+		}
+
+		return updates
+	}
+
+	t.Run("authorized push", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		updates := pushCommit(t, repo, mainRef, gpgKeyBytes, true)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: rsl.Ref}, {RefName: mainRef}}, results)
+
+		// The repository's refs are not updated
+		_, err = repo.r.GetReference(mainRef)
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+		rslTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, updates[1].OldTip, rslTip)
+	})
+
+	t.Run("unauthorized push", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		updates := pushCommit(t, repo, mainRef, gpgUnauthorizedKeyBytes, true)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.ErrorIs(t, err, ErrPushVerificationFailed)
+		require.Len(t, results, 2)
+		assert.Nil(t, results[0].Err)
+		assert.Equal(t, mainRef, results[1].RefName)
+		assert.ErrorIs(t, results[1].Err, policy.ErrVerificationFailed)
+	})
+
+	t.Run("push without RSL entry", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		updates := pushCommit(t, repo, mainRef, gpgKeyBytes, false)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.ErrorIs(t, err, ErrPushVerificationFailed)
+		require.Len(t, results, 1)
+		assert.Error(t, results[0].Err)
+	})
+
+	t.Run("unprotected ref", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		updates := pushCommit(t, repo, featureRef, gpgUnauthorizedKeyBytes, false)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: featureRef, Skipped: true}}, results)
+	})
+
+	t.Run("reference delegated to forge", func(t *testing.T) {
+		repo := createTestRepositoryWithForge(t)
+		updates := pushCommit(t, repo, mainRef, gpgKeyBytes, false)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef, DelegatedToForge: true}}, results)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		repo := &Repository{r: gitinterface.CreateTestGitRepository(t, t.TempDir(), false)}
+		updates := pushCommit(t, repo, mainRef, gpgUnauthorizedKeyBytes, true)
+
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: rsl.Ref}, {RefName: mainRef, Skipped: true}}, results)
+	})
+
+	t.Run("RSL rewritten", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		rslTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		priorEntry, err := rsl.GetParentForEntry(repo.r, latestEntry)
+		require.Nil(t, err)
+
+		updates := []*ReferenceUpdate{{RefName: rsl.Ref, OldTip: rslTip, NewTip: priorEntry.GetID()}}
+		results, err := repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.ErrorIs(t, err, ErrPushVerificationFailed)
+		require.Len(t, results, 1)
+		assert.ErrorIs(t, results[0].Err, ErrPushedRSLDoesNotExtendRSL)
+
+		updates = []*ReferenceUpdate{{RefName: rsl.Ref, OldTip: rslTip, NewTip: gitinterface.ZeroHash}}
+		results, err = repo.VerifyPushedReferenceUpdates(testCtx, updates)
+		assert.ErrorIs(t, err, ErrPushVerificationFailed)
+		require.Len(t, results, 1)
+		assert.ErrorIs(t, results[0].Err, ErrPushedRSLDoesNotExtendRSL)
+	})
+}
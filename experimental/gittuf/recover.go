@@ -0,0 +1,234 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	recoveropts "github.com/gittuf/gittuf/experimental/gittuf/options/recover"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var (
+	ErrCannotRecoverCheckedOutReference = errors.New("cannot recover reference that is currently checked out, switch to a different branch first")
+	ErrReferenceChangedSincePlan        = errors.New("reference has changed since the recovery plan was created")
+)
+
+// PlanRecovery verifies the specified reference and, if verification fails,
+// returns a plan to restore it to its last valid state. The plan identifies the
+// last good RSL entry for the reference and the entries that must be skipped
+// for verification to pass again. policy.ErrNothingToRecover is returned if the
+// reference passes verification.
+func (r *Repository) PlanRecovery(ctx context.Context, refName string) (*policy.RecoveryPlan, error) {
+	slog.Debug("Identifying absolute reference path...")
+	refName, err := r.absoluteReference(refName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying recovery plan for '%s'...", refName))
+	verifier := policy.NewPolicyVerifier(r.r)
+	return verifier.PlanRecovery(ctx, refName)
+}
+
+// Recover restores the reference in the plan to its last valid state. By
+// default, the reference is reset to the commit recorded in the last good RSL
+// entry. With recoveropts.WithRevert, a new commit that matches the last good
+// state is created on top of the reference's current tip instead, preserving
+// its history. If the last good entry recorded the deletion of the reference,
+// the reference is deleted. A new RSL entry is recorded for the restored
+// reference, preceded by an annotation skipping the invalid entries if
+// recoveropts.WithSkipInvalidEntries is used. Worktrees are not updated, so the
+// reference must not be checked out.
+//
+// With a remote, the restored reference and the RSL are pushed together in a
+// single atomic push that is rejected if the remote's reference or RSL has
+// changed since the local RSL was synced with it. If the push fails, the local
+// reference and RSL are restored to their prior states.
+func (r *Repository) Recover(ctx context.Context, plan *policy.RecoveryPlan, signCommit bool, opts ...recoveropts.Option) error {
+	options := &recoveropts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if options.RemoteName == "" && !options.LocalOnly {
+		return ErrRemoteNotSpecified
+	} else if options.RemoteName != "" && options.LocalOnly {
+		return ErrCannotUseRemoteAndLocalOnly
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		if err := r.r.CanSign(); err != nil {
+			return err
+		}
+	}
+
+	refName := plan.RefName
+	if !r.r.IsBare() {
+		headRef, err := r.r.GetSymbolicReferenceTarget("HEAD")
+		if err == nil && headRef == refName {
+			return ErrCannotRecoverCheckedOutReference
+		}
+	}
+
+	if options.RemoteName != "" {
+		slog.Debug(fmt.Sprintf("Syncing RSL with '%s'...", options.RemoteName))
+		if _, err := r.Sync(ctx, options.RemoteName, false, signCommit); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Checking reference has not changed since recovery was planned...")
+	latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(refName))
+	if err != nil {
+		return err
+	}
+	if !latestEntry.GetID().Equal(plan.LatestEntry.GetID()) {
+		return ErrReferenceChangedSincePlan
+	}
+
+	// The RSL and the reference are updated locally and pushed together
+	// afterwards so that the remote's reference is updated atomically with its
+	// RSL
+	priorRSLTip, err := r.r.GetReference(rsl.Ref)
+	if err != nil {
+		return err
+	}
+	priorRefTip, err := r.r.GetReference(refName)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return err
+		}
+		priorRefTip = gitinterface.ZeroHash
+	}
+
+	if err := r.recoverLocally(ctx, plan, signCommit, options); err != nil {
+		return err
+	}
+
+	if options.RemoteName == "" {
+		return nil
+	}
+
+	slog.Debug(fmt.Sprintf("Pushing recovered state to '%s'...", options.RemoteName))
+	if err := r.pushRecovery(plan, options.RemoteName, priorRSLTip); err != nil {
+		slog.Debug("Unable to push recovered state, restoring local state...")
+		return errors.Join(err, r.restoreStateBeforeRecovery(refName, priorRefTip, priorRSLTip))
+	}
+
+	return nil
+}
+
+// recoverLocally restores the reference in the plan and records the restored
+// state in the local RSL.
+func (r *Repository) recoverLocally(ctx context.Context, plan *policy.RecoveryPlan, signCommit bool, options *recoveropts.Options) error {
+	refName := plan.RefName
+
+	if options.SkipInvalidEntries && len(plan.EntriesToSkip) != 0 {
+		entryIDs := make([]string, 0, len(plan.EntriesToSkip))
+		for _, entry := range plan.EntriesToSkip {
+			entryIDs = append(entryIDs, entry.GetID().String())
+		}
+
+		slog.Debug("Skipping invalid entries...")
+		message := fmt.Sprintf("Skip invalid entries for '%s' during recovery", refName)
+		if err := r.RecordRSLAnnotation(ctx, entryIDs, true, message, signCommit, rslopts.WithAnnotateLocalOnly()); err != nil {
+			return err
+		}
+	}
+
+	recordOpts := []rslopts.RecordOption{rslopts.WithRecordLocalOnly()}
+
+	lastGoodTargetID := plan.LastGoodEntry.GetTargetID()
+	switch {
+	case lastGoodTargetID.IsZero():
+		slog.Debug(fmt.Sprintf("Deleting '%s' as the last good entry recorded its deletion...", refName))
+		if err := r.r.DeleteReference(refName); err != nil {
+			return err
+		}
+		recordOpts = append(recordOpts, rslopts.WithRecordDeletion())
+
+	case options.Revert:
+		slog.Debug(fmt.Sprintf("Creating commit restoring '%s' to last good state...", refName))
+		treeID, err := r.r.GetCommitTreeID(lastGoodTargetID)
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("Restore '%s' to '%s'\n\nThis reverts the changes recorded in the RSL after entry '%s'.", refName, lastGoodTargetID.String(), plan.LastGoodEntry.GetID().String())
+		if _, err := r.r.Commit(treeID, refName, message, signCommit); err != nil {
+			return err
+		}
+
+	default:
+		slog.Debug(fmt.Sprintf("Resetting '%s' to last good state...", refName))
+		if err := r.r.SetReference(refName, lastGoodTargetID); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Recording restored state in RSL...")
+	// The restored state may match the latest entry, such as when the
+	// reference was reset but the invalid entries were skipped
+	recordOpts = append(recordOpts, rslopts.WithSkipCheckForDuplicateEntry())
+	return r.RecordRSLEntryForReference(ctx, refName, signCommit, recordOpts...)
+}
+
+// pushRecovery pushes the recovered reference and the RSL to the remote in a
+// single atomic push. Resetting the reference is not a fast-forward, so the
+// push is instead rejected if the remote's reference isn't at the tip recorded
+// in the plan's latest entry or the remote's RSL isn't at remoteRSLTip.
+func (r *Repository) pushRecovery(plan *policy.RecoveryPlan, remoteName string, remoteRSLTip gitinterface.Hash) error {
+	remoteName, removeRemote, err := r.remoteWithoutGittufTransport(remoteName)
+	if err != nil {
+		return err
+	}
+	defer removeRemote()
+
+	rslRefSpec, err := r.r.RefSpec(rsl.Ref, "", true)
+	if err != nil {
+		return err
+	}
+	refSpecs := []string{rslRefSpec}
+
+	if plan.LastGoodEntry.GetTargetID().IsZero() {
+		refSpecs = append(refSpecs, fmt.Sprintf(":%s", plan.RefName))
+	} else {
+		// The refspec must not force the update as that overrides the
+		// expected tip of the reference
+		refSpec, err := r.r.RefSpec(plan.RefName, "", true)
+		if err != nil {
+			return err
+		}
+		refSpecs = append(refSpecs, refSpec)
+	}
+
+	expectedTips := map[string]gitinterface.Hash{
+		plan.RefName: plan.LatestEntry.GetTargetID(),
+		rsl.Ref:      remoteRSLTip,
+	}
+
+	return r.r.PushRefSpec(remoteName, refSpecs, gitinterface.WithAtomicPush(), gitinterface.WithExpectedRemoteTips(expectedTips))
+}
+
+// restoreStateBeforeRecovery resets the reference and the RSL to their states
+// before recovery. A zero refTip indicates the reference did not exist.
+func (r *Repository) restoreStateBeforeRecovery(refName string, refTip, rslTip gitinterface.Hash) error {
+	if err := r.r.SetReference(rsl.Ref, rslTip); err != nil {
+		return err
+	}
+
+	if refTip.IsZero() {
+		return r.r.DeleteReference(refName)
+	}
+
+	return r.r.SetReference(refName, refTip)
+}
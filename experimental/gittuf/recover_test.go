@@ -0,0 +1,266 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"testing"
+
+	recoveropts "github.com/gittuf/gittuf/experimental/gittuf/options/recover"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// createInvalidState returns a repository where the latest entry for main
+	// is not authorized by the policy, along with the last valid commit
+	createInvalidState := func(t *testing.T) (*Repository, gitinterface.Hash, gitinterface.Hash) {
+		t.Helper()
+
+		repo := createTestRepositoryWithPolicyAuthorizingGitSigningKey(t)
+
+		// Recovery doesn't update worktrees, so main must not be checked out
+		require.Nil(t, repo.r.SetSymbolicReference("HEAD", "refs/heads/other"))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, rsaKeyBytes)
+		require.Nil(t, repo.RecordRSLEntryForReference(testCtx, refName, true, rslopts.WithRecordLocalOnly()))
+		validCommitID := commitIDs[0]
+
+		_, err := repo.PlanRecovery(testCtx, refName)
+		require.ErrorIs(t, err, policy.ErrNothingToRecover)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 2, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		invalidEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		require.ErrorIs(t, repo.VerifyRef(testCtx, refName), policy.ErrVerificationFailed)
+
+		return repo, validCommitID, invalidEntryID
+	}
+
+	t.Run("reset and skip", func(t *testing.T) {
+		repo, validCommitID, invalidEntryID := createInvalidState(t)
+
+		plan, err := repo.PlanRecovery(testCtx, "main")
+		require.Nil(t, err)
+		assert.Equal(t, refName, plan.RefName)
+		assert.Equal(t, invalidEntryID, plan.InvalidEntry.GetID())
+		assert.ErrorIs(t, plan.VerificationErr, policy.ErrVerificationFailed)
+		assert.Equal(t, validCommitID, plan.LastGoodEntry.GetTargetID())
+		require.Len(t, plan.EntriesToSkip, 1)
+		assert.Equal(t, invalidEntryID, plan.EntriesToSkip[0].GetID())
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithSkipInvalidEntries(), recoveropts.WithLocalOnly())
+		assert.Nil(t, err)
+
+		tip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, tip)
+
+		latestEntry, annotations, err := rsl.GetLatestReferenceUpdaterEntry(repo.r, rsl.ForReference(refName), rsl.BeforeEntryID(gitinterface.ZeroHash))
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, latestEntry.GetTargetID())
+		assert.Empty(t, annotations)
+
+		_, annotations, err = rsl.GetLatestReferenceUpdaterEntry(repo.r, rsl.ForReference(refName), rsl.BeforeEntryID(latestEntry.GetID()))
+		require.Nil(t, err)
+		require.Len(t, annotations, 1)
+		assert.True(t, annotations[0].Skip)
+
+		assert.Nil(t, repo.VerifyRef(testCtx, refName))
+
+		_, err = repo.PlanRecovery(testCtx, refName)
+		assert.ErrorIs(t, err, policy.ErrNothingToRecover)
+	})
+
+	t.Run("revert and skip", func(t *testing.T) {
+		repo, validCommitID, _ := createInvalidState(t)
+
+		invalidTip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithRevert(), recoveropts.WithSkipInvalidEntries(), recoveropts.WithLocalOnly())
+		assert.Nil(t, err)
+
+		tip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		parentIDs, err := repo.r.GetCommitParentIDs(tip)
+		require.Nil(t, err)
+		assert.Equal(t, []gitinterface.Hash{invalidTip}, parentIDs)
+
+		treeID, err := repo.r.GetCommitTreeID(tip)
+		require.Nil(t, err)
+		validTreeID, err := repo.r.GetCommitTreeID(validCommitID)
+		require.Nil(t, err)
+		assert.Equal(t, validTreeID, treeID)
+
+		assert.Nil(t, repo.VerifyRef(testCtx, refName))
+	})
+
+	t.Run("without skipping invalid entries", func(t *testing.T) {
+		repo, validCommitID, invalidEntryID := createInvalidState(t)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithLocalOnly())
+		assert.Nil(t, err)
+
+		tip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, tip)
+
+		// The invalid entry must still be skipped
+		err = repo.VerifyRef(testCtx, refName)
+		assert.ErrorIs(t, err, policy.ErrVerificationFailed)
+
+		plan, err = repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		assert.Equal(t, invalidEntryID, plan.InvalidEntry.GetID())
+		assert.Equal(t, validCommitID, plan.LastGoodEntry.GetTargetID())
+	})
+
+	t.Run("latest entry already skipped", func(t *testing.T) {
+		repo, validCommitID, invalidEntryID := createInvalidState(t)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		latestEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{latestEntryID}, true, "invalid entry")
+		common.CreateTestRSLAnnotationEntryCommit(t, repo.r, annotation, rsaKeyBytes)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		require.Len(t, plan.EntriesToSkip, 1)
+		assert.Equal(t, invalidEntryID, plan.EntriesToSkip[0].GetID())
+		assert.Equal(t, latestEntryID, plan.LatestEntry.GetID())
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithSkipInvalidEntries(), recoveropts.WithLocalOnly())
+		assert.Nil(t, err)
+
+		tip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, tip)
+
+		assert.Nil(t, repo.VerifyRef(testCtx, refName))
+	})
+
+	t.Run("reference is checked out", func(t *testing.T) {
+		repo, _, _ := createInvalidState(t)
+		require.Nil(t, repo.r.SetSymbolicReference("HEAD", refName))
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithLocalOnly())
+		assert.ErrorIs(t, err, ErrCannotRecoverCheckedOutReference)
+	})
+
+	t.Run("reference changed since plan", func(t *testing.T) {
+		repo, _, _ := createInvalidState(t)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithLocalOnly())
+		assert.ErrorIs(t, err, ErrReferenceChangedSincePlan)
+	})
+
+	// addRemote pushes the repository's references to a new remote
+	addRemote := func(t *testing.T, repo *Repository) *gitinterface.Repository {
+		t.Helper()
+
+		remoteDir := t.TempDir()
+		remote := gitinterface.CreateTestGitRepository(t, remoteDir, true)
+		require.Nil(t, repo.r.AddRemote("origin", remoteDir))
+		require.Nil(t, repo.r.PushRefSpec("origin", []string{"refs/*:refs/*"}))
+
+		return remote
+	}
+
+	t.Run("reset and skip with remote", func(t *testing.T) {
+		repo, validCommitID, _ := createInvalidState(t)
+		remote := addRemote(t, repo)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithSkipInvalidEntries(), recoveropts.WithRemote("origin"))
+		assert.Nil(t, err)
+
+		// The remote's reference is reset along with its RSL
+		remoteTip, err := remote.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, remoteTip)
+
+		localRSLTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		remoteRSLTip, err := remote.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, localRSLTip, remoteRSLTip)
+
+		remoteEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(remote, rsl.ForReference(refName))
+		require.Nil(t, err)
+		assert.Equal(t, validCommitID, remoteEntry.GetTargetID())
+
+		assert.Nil(t, repo.VerifyRef(testCtx, refName))
+	})
+
+	t.Run("remote reference changed since plan", func(t *testing.T) {
+		repo, _, _ := createInvalidState(t)
+		remote := addRemote(t, repo)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		invalidTip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		localRSLTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		// The remote's reference is updated without an RSL entry
+		parentIDs, err := repo.r.GetCommitParentIDs(invalidTip)
+		require.Nil(t, err)
+		require.Nil(t, remote.SetReference(refName, parentIDs[0]))
+
+		err = repo.Recover(testCtx, plan, true, recoveropts.WithSkipInvalidEntries(), recoveropts.WithRemote("origin"))
+		assert.ErrorContains(t, err, "unable to push")
+
+		// Neither the remote nor the local repository are changed
+		remoteRSLTip, err := remote.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, localRSLTip, remoteRSLTip)
+
+		currentRSLTip, err := repo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, localRSLTip, currentRSLTip)
+
+		currentTip, err := repo.r.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, invalidTip, currentTip)
+	})
+
+	t.Run("remote not specified", func(t *testing.T) {
+		repo, _, _ := createInvalidState(t)
+
+		plan, err := repo.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+
+		err = repo.Recover(testCtx, plan, true)
+		assert.ErrorIs(t, err, ErrRemoteNotSpecified)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"testing"
+	"time"
 
 	rootopts "github.com/gittuf/gittuf/experimental/gittuf/options/root"
 	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
@@ -220,6 +221,182 @@ func TestSetRepositoryLocation(t *testing.T) {
 	})
 }
 
+func TestSetHeartbeat(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	sv := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	err = r.SetHeartbeat(testCtx, sv, []tuf.Principal{gpgKey}, time.Hour, false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+
+	heartbeat := rootMetadata.GetHeartbeat()
+	require.NotNil(t, heartbeat)
+	assert.Equal(t, []string{gpgKey.KeyID}, heartbeat.GetPrincipalIDs())
+	assert.Equal(t, time.Hour, heartbeat.GetMaxStaleness())
+
+	err = r.SetHeartbeat(testCtx, sv, []tuf.Principal{gpgKey}, 0, false)
+	assert.ErrorIs(t, err, tuf.ErrInvalidHeartbeatMaxStaleness)
+
+	err = r.RemoveHeartbeat(testCtx, sv, false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.Nil(t, rootMetadata.GetHeartbeat())
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		unauthorizedSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.SetHeartbeat(testCtx, unauthorizedSigner, []tuf.Principal{gpgKey}, time.Hour, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		err = r.RemoveHeartbeat(testCtx, unauthorizedSigner, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
+func TestAddSkipAuthorization(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	sv := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	err = r.AddSkipAuthorization(testCtx, sv, "skip-main", []string{"refs/heads/main"}, []tuf.Principal{gpgKey}, 1, false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+
+	skipAuthorizations := rootMetadata.GetSkipAuthorizations()
+	require.Len(t, skipAuthorizations, 1)
+	assert.Equal(t, "skip-main", skipAuthorizations[0].GetName())
+	assert.Equal(t, []string{"refs/heads/main"}, skipAuthorizations[0].GetProtectedNamespaces())
+	assert.Equal(t, []string{gpgKey.KeyID}, skipAuthorizations[0].GetPrincipalIDs())
+	assert.Equal(t, 1, skipAuthorizations[0].GetThreshold())
+
+	err = r.AddSkipAuthorization(testCtx, sv, "skip-main", []string{"refs/heads/main"}, []tuf.Principal{gpgKey}, 1, false)
+	assert.ErrorIs(t, err, tuf.ErrSkipAuthorizationAlreadyExists)
+
+	err = r.RemoveSkipAuthorization(testCtx, sv, "skip-main", false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.Empty(t, rootMetadata.GetSkipAuthorizations())
+
+	err = r.RemoveSkipAuthorization(testCtx, sv, "skip-main", false)
+	assert.ErrorIs(t, err, tuf.ErrSkipAuthorizationNotFound)
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		unauthorizedSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.AddSkipAuthorization(testCtx, unauthorizedSigner, "skip-main", []string{"refs/heads/main"}, []tuf.Principal{gpgKey}, 1, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		err = r.RemoveSkipAuthorization(testCtx, unauthorizedSigner, "skip-main", false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
+func TestSetForge(t *testing.T) {
+	r := createTestRepositoryWithRoot(t, "")
+
+	sv := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	err = r.SetForge(testCtx, sv, []tuf.Principal{gpgKey}, []string{"refs/heads/*"}, false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	require.Nil(t, err)
+
+	forge := rootMetadata.GetForge()
+	require.NotNil(t, forge)
+	assert.Equal(t, []string{gpgKey.KeyID}, forge.GetPrincipalIDs())
+	assert.Equal(t, []string{"refs/heads/*"}, forge.GetProtectedNamespaces())
+
+	err = r.SetForge(testCtx, sv, []tuf.Principal{gpgKey}, nil, false)
+	assert.ErrorIs(t, err, tuf.ErrForgeReferencesNotSpecified)
+
+	err = r.RemoveForge(testCtx, sv, false)
+	assert.Nil(t, err)
+	err = r.StagePolicy(testCtx, "", true, false)
+	require.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r, policy.PolicyStagingRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = state.GetRootMetadata(false)
+	require.Nil(t, err)
+	assert.Nil(t, rootMetadata.GetForge())
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		unauthorizedSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+
+		err := r.SetForge(testCtx, unauthorizedSigner, []tuf.Principal{gpgKey}, []string{"refs/heads/*"}, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		err = r.RemoveForge(testCtx, unauthorizedSigner, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+}
+
 func TestAddRootKey(t *testing.T) {
 	r := createTestRepositoryWithRoot(t, "")
 
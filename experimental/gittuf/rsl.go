@@ -6,24 +6,38 @@ package gittuf
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/policy"
 	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
 
-const gittufTransportPrefix = "gittuf::"
+const (
+	gittufTransportPrefix = "gittuf::"
+
+	// compareRSLTrackerRef is a local-only ref that temporarily holds an
+	// RSL fetched for comparison
+	compareRSLTrackerRef = "refs/local/gittuf/compare/%d/reference-state-log"
+)
 
 var (
 	ErrCommitNotInRef              = errors.New("specified commit is not in ref")
@@ -32,16 +46,46 @@ var (
 	ErrDivergedRefs                = errors.New("references in local repository have diverged from upstream")
 	ErrRemoteNotSpecified          = errors.New("remote not specified")
 	ErrCannotUseRemoteAndLocalOnly = errors.New("cannot indicate local-only and push to specified remote")
+	ErrRSLEntryNotSignedByKnownKey = errors.New("RSL entry is not signed by any key that appears in a policy")
+	ErrUnableToLoadRecordedPolicy  = errors.New("unable to load policy recorded in RSL")
+	ErrInvalidRefNameOverrides     = errors.New("reference name overrides do not match the references being recorded")
+	ErrInvalidAnnotationDataKey    = errors.New("annotation data keys must be non-empty and must not contain whitespace")
+	ErrDuplicateReference          = errors.New("reference cannot be recorded more than once in an RSL entry")
+	ErrReferenceNotDeleted         = errors.New("reference still exists locally, delete it before recording its deletion")
 )
 
 // RecordRSLEntryForReference is the interface for the user to add an RSL entry
 // for the specified Git reference.
 func (r *Repository) RecordRSLEntryForReference(ctx context.Context, refName string, signCommit bool, opts ...rslopts.RecordOption) error {
+	return r.RecordRSLEntryForReferences(ctx, []string{refName}, signCommit, opts...)
+}
+
+// RecordRSLEntryForReferences is the interface for the user to add an RSL entry
+// that atomically records the states of the specified Git references. When
+// more than one reference must be recorded, a single multi-reference entry is
+// created so that other entries cannot be interleaved between the updates.
+// Unless the duplicate check is skipped, references whose latest entries
+// already have the same targets are not recorded again. A deleted reference is
+// recorded with the zero hash as its target and must no longer exist locally;
+// an empty reference name paired with an override records the deletion of the
+// override, mirroring Git's ':<dst>' refspec.
+func (r *Repository) RecordRSLEntryForReferences(ctx context.Context, refNames []string, signCommit bool, opts ...rslopts.RecordOption) error {
 	options := &rslopts.RecordOptions{}
 	for _, fn := range opts {
 		fn(options)
 	}
 
+	refNameOverrides := options.RefNameOverrides
+	if options.RefNameOverride != "" {
+		if len(refNames) != 1 || len(refNameOverrides) != 0 {
+			return ErrInvalidRefNameOverrides
+		}
+		refNameOverrides = []string{options.RefNameOverride}
+	}
+	if len(refNameOverrides) != 0 && len(refNameOverrides) != len(refNames) {
+		return ErrInvalidRefNameOverrides
+	}
+
 	if signCommit && options.SigningKeyBytes == nil {
 		slog.Debug("Checking if Git signing is configured...")
 		err := r.r.CanSign()
@@ -63,53 +107,119 @@ func (r *Repository) RecordRSLEntryForReference(ctx context.Context, refName str
 		}
 	}
 
-	slog.Debug("Identifying absolute reference path...")
-	refName, err := r.r.AbsoluteReference(refName)
-	if err != nil {
-		return err
+	var skipAuthorizer rsl.SkipAuthorizer
+	if !options.SkipCheckForDuplicate {
+		var err error
+		skipAuthorizer, err = r.currentSkipAuthorizer(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Track localRefName to check the expected tip as we may override refName
-	localRefName := refName
+	targetIDs := map[string]gitinterface.Hash{}
+	seenRefNames := set.NewSet[string]()
+	for index, refName := range refNames {
+		// An empty refName records the deletion of the overridden name
+		// Eg: git push <remote> :<dst>
+		isDeletion := options.Deletion || refName == ""
+
+		// Track localRefName to check the expected tip as we may override
+		// refName
+		localRefName := ""
+		if refName != "" {
+			slog.Debug("Identifying absolute reference path...")
+			var err error
+			localRefName, err = r.absoluteReference(refName, isDeletion)
+			if err != nil {
+				return err
+			}
+		}
+		refName = localRefName
 
-	if options.RefNameOverride != "" {
-		// dst differs from src
-		// Eg: git push <remote> <src>:<dst>
-		slog.Debug("Name of reference overridden to match remote reference name, identifying absolute reference path...")
-		refNameOverride, err := r.r.AbsoluteReference(options.RefNameOverride)
-		if err != nil {
-			return err
+		if len(refNameOverrides) != 0 && refNameOverrides[index] != "" {
+			// dst differs from src
+			// Eg: git push <remote> <src>:<dst>
+			slog.Debug("Name of reference overridden to match remote reference name, identifying absolute reference path...")
+			refNameOverride, err := r.absoluteReference(refNameOverrides[index], isDeletion)
+			if err != nil {
+				return err
+			}
+
+			refName = refNameOverride
 		}
 
-		refName = refNameOverride
-	}
+		if refName == "" {
+			return ErrInvalidRefNameOverrides
+		}
 
-	// The tip of the ref is always from the localRefName
-	slog.Debug(fmt.Sprintf("Loading current state of '%s'...", localRefName))
-	refTip, err := r.r.GetReference(localRefName)
-	if err != nil {
-		return err
-	}
+		if seenRefNames.Has(refName) {
+			return fmt.Errorf("%w: '%s'", ErrDuplicateReference, refName)
+		}
+		seenRefNames.Add(refName)
+
+		refTip := r.r.ZeroHash()
+		if isDeletion {
+			if localRefName == refName {
+				// The deleted reference is the local reference, so it
+				// must not exist anymore for its tip to match the entry
+				slog.Debug(fmt.Sprintf("Checking '%s' has been deleted...", localRefName))
+				if _, err := r.r.GetReference(localRefName); err == nil {
+					return fmt.Errorf("%w: '%s'", ErrReferenceNotDeleted, localRefName)
+				} else if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+					return err
+				}
+			}
 
-	if !options.SkipCheckForDuplicate {
-		slog.Debug("Checking if latest entry for reference has same target...")
-		isDuplicate, err := r.isDuplicateEntry(refName, refTip)
-		if err != nil {
-			return err
+			slog.Debug(fmt.Sprintf("Recording deletion of '%s'...", refName))
+		} else {
+			// The tip of the ref is always from the localRefName
+			slog.Debug(fmt.Sprintf("Loading current state of '%s'...", localRefName))
+			var err error
+			refTip, err = r.r.GetReference(localRefName)
+			if err != nil {
+				return err
+			}
 		}
-		if isDuplicate {
-			slog.Debug("The latest entry has the same target, skipping creation of new entry...")
-			return nil
+
+		if !options.SkipCheckForDuplicate {
+			slog.Debug("Checking if latest entry for reference has same target...")
+			isDuplicate, err := r.isDuplicateEntry(refName, refTip, skipAuthorizer)
+			if err != nil {
+				return err
+			}
+			if isDuplicate {
+				slog.Debug(fmt.Sprintf("The latest entry for '%s' has the same target, skipping...", refName))
+				continue
+			}
+		} else {
+			slog.Debug("Not checking if latest entry for reference has same target")
 		}
-	} else {
-		slog.Debug("Not checking if latest entry for reference has same target")
+
+		targetIDs[refName] = refTip
+	}
+
+	if len(targetIDs) == 0 {
+		slog.Debug("No references need to be recorded, skipping creation of new entry...")
+		return nil
 	}
 
 	// TODO: once policy verification is in place, the signing key used by
 	// signCommit must be verified for the refName in the delegation tree.
 
-	slog.Debug("Creating RSL reference entry...")
-	entry := rsl.NewReferenceEntry(refName, refTip)
+	var entry interface {
+		rsl.Entry
+		CommitUsingSpecificKey(*gitinterface.Repository, []byte) error
+	}
+	if len(targetIDs) == 1 {
+		slog.Debug("Creating RSL reference entry...")
+		for refName, refTip := range targetIDs {
+			entry = rsl.NewReferenceEntry(refName, refTip)
+		}
+	} else {
+		slog.Debug("Creating RSL multi-reference entry...")
+		entry = rsl.NewMultiReferenceEntry(targetIDs)
+	}
+
 	if signCommit && options.SigningKeyBytes != nil {
 		if err := entry.CommitUsingSpecificKey(r.r, options.SigningKeyBytes); err != nil {
 			return err
@@ -122,7 +232,7 @@ func (r *Repository) RecordRSLEntryForReference(ctx context.Context, refName str
 		return nil
 	}
 
-	_, err = r.Sync(ctx, options.RemoteName, false, signCommit)
+	_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
 	return err
 }
 
@@ -200,6 +310,22 @@ func (r *Repository) RecordRSLAnnotation(ctx context.Context, rslEntryIDs []stri
 		return ErrCannotUseRemoteAndLocalOnly
 	}
 
+	var data map[string]json.RawMessage
+	if len(options.Data) != 0 {
+		data = make(map[string]json.RawMessage, len(options.Data))
+		for key, value := range options.Data {
+			if key == "" || strings.ContainsFunc(key, unicode.IsSpace) {
+				return fmt.Errorf("%w: '%s'", ErrInvalidAnnotationDataKey, key)
+			}
+
+			encodedValue, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			data[key] = encodedValue
+		}
+	}
+
 	if !options.LocalOnly {
 		_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
 		if err != nil {
@@ -221,6 +347,7 @@ func (r *Repository) RecordRSLAnnotation(ctx context.Context, rslEntryIDs []stri
 
 	slog.Debug("Creating RSL annotation entry...")
 	annotation := rsl.NewAnnotationEntry(rslEntryHashes, skip, message)
+	annotation.Data = data
 	if signCommit && options.SigningKeyBytes != nil {
 		if err := annotation.CommitUsingSpecificKey(r.r, options.SigningKeyBytes); err != nil {
 			return err
@@ -237,6 +364,249 @@ func (r *Repository) RecordRSLAnnotation(ctx context.Context, rslEntryIDs []stri
 	return err
 }
 
+// RecordRSLHeartbeat creates a heartbeat entry in the RSL for the current time.
+// Heartbeats are created periodically by a principal trusted for heartbeats in
+// the root of trust so that verifiers can detect an outdated RSL.
+func (r *Repository) RecordRSLHeartbeat(ctx context.Context, signCommit bool, opts ...rslopts.HeartbeatOption) error {
+	options := &rslopts.HeartbeatOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit && options.SigningKeyBytes == nil {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.RemoteName == "" && !options.LocalOnly {
+		return ErrRemoteNotSpecified
+	} else if options.RemoteName != "" && options.LocalOnly {
+		return ErrCannotUseRemoteAndLocalOnly
+	}
+
+	if !options.LocalOnly {
+		_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Creating RSL heartbeat entry...")
+	heartbeat := rsl.NewHeartbeatEntry(time.Now())
+	if signCommit && options.SigningKeyBytes != nil {
+		if err := heartbeat.CommitUsingSpecificKey(r.r, options.SigningKeyBytes); err != nil {
+			return err
+		}
+	} else if err := heartbeat.Commit(r.r, signCommit); err != nil {
+		return err
+	}
+
+	if options.LocalOnly {
+		return nil
+	}
+
+	_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
+	return err
+}
+
+// RecordRSLCheckpoint verifies all references in the repository and records a
+// checkpoint entry in the RSL summarizing the verified state. Verification of
+// the RSL can then start from the checkpoint rather than from the first entry.
+// The checkpoint must be trusted by the threshold of root principals. The
+// signature on the checkpoint's commit counts towards the threshold, and each
+// of the specified signers also signs the checkpoint's summary.
+func (r *Repository) RecordRSLCheckpoint(ctx context.Context, signers []sslibdsse.SignerVerifier, signCommit bool, opts ...rslopts.CheckpointOption) error {
+	options := &rslopts.CheckpointOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit && options.SigningKeyBytes == nil {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.RemoteName == "" && !options.LocalOnly {
+		return ErrRemoteNotSpecified
+	} else if options.RemoteName != "" && options.LocalOnly {
+		return ErrCannotUseRemoteAndLocalOnly
+	}
+
+	if !options.LocalOnly {
+		_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("Verifying all references to create RSL checkpoint...")
+	verifier := policy.NewPolicyVerifier(r.r)
+	checkpoint, err := verifier.CreateCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(signers) != 0 {
+		env, err := dsse.CreateEnvelope(checkpoint.GetSummary())
+		if err != nil {
+			return err
+		}
+
+		for _, signer := range signers {
+			slog.Debug("Signing RSL checkpoint summary...")
+			env, err = dsse.SignEnvelope(ctx, env, signer)
+			if err != nil {
+				return err
+			}
+		}
+		checkpoint.Signatures = env
+	}
+
+	slog.Debug("Creating RSL checkpoint entry...")
+	if signCommit && options.SigningKeyBytes != nil {
+		if err := checkpoint.CommitUsingSpecificKey(r.r, options.SigningKeyBytes); err != nil {
+			return err
+		}
+	} else if err := checkpoint.Commit(r.r, signCommit); err != nil {
+		return err
+	}
+
+	if options.LocalOnly {
+		return nil
+	}
+
+	_, err = r.Sync(ctx, options.RemoteName, false, signCommit)
+	return err
+}
+
+// BootstrapRSL records a baseline for the references in a repository that is
+// adopting gittuf. A single RSL entry records the current tips of all branches
+// and tags that don't have entries in the RSL yet, and an annotation identifies
+// the entry as the baseline for these references. History prior to the
+// baseline cannot be verified, so verification instead treats the baseline as
+// the trusted starting point for each reference. The baseline is only trusted
+// if the annotation is signed by a root principal, and the policy must be
+// applied before the baseline is recorded. The names of the references
+// recorded in the baseline are returned.
+func (r *Repository) BootstrapRSL(ctx context.Context, signCommit bool, opts ...rslopts.BootstrapOption) ([]string, error) {
+	options := &rslopts.BootstrapOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if signCommit {
+		slog.Debug("Checking if Git signing is configured...")
+		err := r.r.CanSign()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.RemoteName == "" && !options.LocalOnly {
+		return nil, ErrRemoteNotSpecified
+	} else if options.RemoteName != "" && options.LocalOnly {
+		return nil, ErrCannotUseRemoteAndLocalOnly
+	}
+
+	if !options.LocalOnly {
+		_, err := r.Sync(ctx, options.RemoteName, false, signCommit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Checking policy has been applied...")
+	if _, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef); err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, policy.ErrPolicyNotFound
+		}
+		return nil, err
+	}
+
+	slog.Debug("Identifying references without RSL entries...")
+	refNames := []string{}
+	for _, prefix := range []string{gitinterface.BranchRefPrefix, gitinterface.TagRefPrefix} {
+		candidateRefNames, err := r.r.ListReferences(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, refName := range candidateRefNames {
+			_, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(refName))
+			if err == nil {
+				slog.Debug(fmt.Sprintf("'%s' is already recorded in the RSL, skipping...", refName))
+				continue
+			}
+			if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, err
+			}
+
+			refNames = append(refNames, refName)
+		}
+	}
+
+	if len(refNames) == 0 {
+		slog.Debug("All references are already recorded in the RSL, nothing to bootstrap")
+		return refNames, nil
+	}
+
+	// The entry and annotation are pushed together once both are recorded
+	slog.Debug("Recording baseline entry...")
+	if err := r.RecordRSLEntryForReferences(ctx, refNames, signCommit, rslopts.WithRecordLocalOnly()); err != nil {
+		return nil, err
+	}
+
+	baselineEntry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Recording baseline annotation...")
+	message := fmt.Sprintf("Bootstrap gittuf for %d existing references", len(refNames))
+	if err := r.RecordRSLAnnotation(ctx, []string{baselineEntry.GetID().String()}, false, message, signCommit, rslopts.WithAnnotateLocalOnly(), rslopts.WithAnnotateData(map[string]any{policy.BaselineAnnotationDataKey: true})); err != nil {
+		return nil, err
+	}
+
+	if options.LocalOnly {
+		return refNames, nil
+	}
+
+	_, err = r.Sync(ctx, options.RemoteName, false, signCommit)
+	return refNames, err
+}
+
+// EvaluateHistoryForReference reports whether each commit reachable from the
+// current tip of the specified reference is signed and whether it would
+// satisfy the latest policy. It is intended to help repositories adopting
+// gittuf understand how their existing history compares to their new policy.
+func (r *Repository) EvaluateHistoryForReference(ctx context.Context, refName string) ([]*policy.HistoricalCommit, error) {
+	slog.Debug("Identifying absolute reference path...")
+	refName, err := r.absoluteReference(refName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tipID, err := r.r.GetReference(refName)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Evaluating history of '%s'...", refName))
+	return state.EvaluateHistoryForRef(ctx, refName, tipID)
+}
+
 // ReconcileLocalRSLWithRemote checks the local RSL against the specified remote
 // and reconciles the local RSL if needed. If the local RSL doesn't exist or is
 // strictly behind the remote RSL, then the local RSL is updated to match the
@@ -361,16 +731,20 @@ func (r *Repository) ReconcileLocalRSLWithRemote(ctx context.Context, remoteName
 	localUpdatedRefs := set.NewSet[string]()
 	for _, entry := range localOnlyEntries {
 		slog.Debug(fmt.Sprintf("Identified local only entry that must be reapplied '%s'", entry.GetID().String()))
-		if entry, isRefEntry := entry.(*rsl.ReferenceEntry); isRefEntry {
-			localUpdatedRefs.Add(entry.RefName)
+		for _, entry := range rsl.GetReferenceUpdaterEntries(entry) {
+			if entry, isRefEntry := entry.(*rsl.ReferenceEntry); isRefEntry {
+				localUpdatedRefs.Add(entry.RefName)
+			}
 		}
 	}
 
 	remoteUpdatedRefs := set.NewSet[string]()
 	for _, entry := range remoteOnlyEntries {
 		slog.Debug(fmt.Sprintf("Identified remote only entry '%s'", entry.GetID().String()))
-		if entry, isRefEntry := entry.(*rsl.ReferenceEntry); isRefEntry {
-			remoteUpdatedRefs.Add(entry.RefName)
+		for _, entry := range rsl.GetReferenceUpdaterEntries(entry) {
+			if entry, isRefEntry := entry.(*rsl.ReferenceEntry); isRefEntry {
+				remoteUpdatedRefs.Add(entry.RefName)
+			}
 		}
 	}
 
@@ -388,6 +762,9 @@ func (r *Repository) ReconcileLocalRSLWithRemote(ctx context.Context, remoteName
 
 	// Apply local only entries on top of the new local RSL
 	// localOnlyEntries is in reverse order
+	// reappliedEntryIDs maps the IDs of reapplied entries to their new IDs so
+	// that annotations for them can be reapplied too
+	reappliedEntryIDs := map[string]gitinterface.Hash{}
 	for i := len(localOnlyEntries) - 1; i >= 0; i-- {
 		slog.Debug(fmt.Sprintf("Reapplying entry '%s'...", localOnlyEntries[i].GetID().String()))
 
@@ -400,19 +777,43 @@ func (r *Repository) ReconcileLocalRSLWithRemote(ctx context.Context, remoteName
 			if err := rsl.NewReferenceEntry(entry.RefName, entry.TargetID).Commit(r.r, sign); err != nil {
 				return fmt.Errorf("unable to reapply reference entry '%s': %w", entry.ID.String(), err)
 			}
+		case *rsl.MultiReferenceEntry:
+			if err := rsl.NewMultiReferenceEntry(entry.TargetIDs).Commit(r.r, sign); err != nil {
+				return fmt.Errorf("unable to reapply multi-reference entry '%s': %w", entry.ID.String(), err)
+			}
 		case *rsl.AnnotationEntry:
-			if err := rsl.NewAnnotationEntry(entry.RSLEntryIDs, entry.Skip, entry.Message).Commit(r.r, sign); err != nil {
+			entryIDs := make([]gitinterface.Hash, 0, len(entry.RSLEntryIDs))
+			for _, entryID := range entry.RSLEntryIDs {
+				if newEntryID, reapplied := reappliedEntryIDs[entryID.String()]; reapplied {
+					entryID = newEntryID
+				}
+				entryIDs = append(entryIDs, entryID)
+			}
+
+			annotation := rsl.NewAnnotationEntry(entryIDs, entry.Skip, entry.Message)
+			annotation.Data = entry.Data
+			if err := annotation.Commit(r.r, sign); err != nil {
 				return fmt.Errorf("unable to reapply annotation entry '%s': %w", entry.ID.String(), err)
 			}
+		case *rsl.HeartbeatEntry:
+			// The heartbeat retains its original timestamp as it only
+			// attests to the RSL as of that time
+			if err := rsl.NewHeartbeatEntry(entry.Timestamp).Commit(r.r, sign); err != nil {
+				return fmt.Errorf("unable to reapply heartbeat entry '%s': %w", entry.ID.String(), err)
+			}
+		case *rsl.CheckpointEntry:
+			// The checkpoint refers to entries that are being reapplied
+			// with new IDs, so it can't be trusted any longer
+			slog.Warn(fmt.Sprintf("Dropping checkpoint entry '%s' as it no longer matches the RSL, create a new checkpoint if needed", entry.ID.String()))
+			continue
 		}
 
-		if slog.Default().Enabled(ctx, slog.LevelDebug) {
-			currentTip, err := r.r.GetReference(rsl.Ref)
-			if err != nil {
-				return fmt.Errorf("unable to get current tip of the RSL: %w", err)
-			}
-			slog.Debug("New entry ID for '%s' is '%s'", localOnlyEntries[i].GetID().String(), currentTip.String())
+		currentTip, err := r.r.GetReference(rsl.Ref)
+		if err != nil {
+			return fmt.Errorf("unable to get current tip of the RSL: %w", err)
 		}
+		reappliedEntryIDs[localOnlyEntries[i].GetID().String()] = currentTip
+		slog.Debug(fmt.Sprintf("New entry ID for '%s' is '%s'", localOnlyEntries[i].GetID().String(), currentTip.String()))
 	}
 
 	slog.Debug("Updated local RSL!")
@@ -493,12 +894,37 @@ func (r *Repository) sync(remoteName string, overwriteLocalRefs bool) ([]string,
 
 		localUpdatedRefTips := getLatestRefTipsFromRSLEntries(localOnlyEntries)
 		pushRefs := []string{rsl.Ref}
-		for refName := range localUpdatedRefTips {
+		deletedRefs := []string{}
+		for refName, tip := range localUpdatedRefTips {
+			if tip.IsZero() {
+				deletedRefs = append(deletedRefs, refName)
+				continue
+			}
 			pushRefs = append(pushRefs, refName)
 		}
 
-		if err := r.r.Push(remoteName, pushRefs); err != nil {
-			return nil, err
+		if len(deletedRefs) == 0 {
+			if err := r.r.Push(remoteName, pushRefs); err != nil {
+				return nil, err
+			}
+		} else {
+			// Deleted references are pushed using ':<ref>' refspecs in the
+			// same push as the other references
+			refSpecs := make([]string, 0, len(pushRefs)+len(deletedRefs))
+			for _, refName := range pushRefs {
+				refSpec, err := r.r.RefSpec(refName, "", true)
+				if err != nil {
+					return nil, err
+				}
+				refSpecs = append(refSpecs, refSpec)
+			}
+			for _, refName := range deletedRefs {
+				refSpecs = append(refSpecs, fmt.Sprintf(":%s", refName))
+			}
+
+			if err := r.r.PushRefSpec(remoteName, refSpecs); err != nil {
+				return nil, err
+			}
 		}
 
 		slog.Debug("Pushed local changes to remote successfully!")
@@ -541,6 +967,13 @@ func (r *Repository) sync(remoteName string, overwriteLocalRefs bool) ([]string,
 				continue
 			}
 
+			if remoteTip.IsZero() {
+				// As with Git, deleting a reference upstream does not delete
+				// the local copy
+				slog.Debug(fmt.Sprintf("Reference '%s' was deleted upstream, not deleting local copy", refName))
+				continue
+			}
+
 			// Fetch remote objects for each ref
 			if !r.r.HasObject(remoteTip) {
 				if err := r.r.FetchObject(remoteName, remoteTip); err != nil {
@@ -637,6 +1070,13 @@ func (r *Repository) sync(remoteName string, overwriteLocalRefs bool) ([]string,
 			continue
 		}
 
+		if remoteTip.IsZero() {
+			// As with Git, deleting a reference upstream does not delete the
+			// local copy
+			slog.Debug(fmt.Sprintf("Reference '%s' was deleted upstream, not deleting local copy", refName))
+			continue
+		}
+
 		// Fetch remote objects for each ref
 		if !r.r.HasObject(remoteTip) {
 			if err := r.r.FetchObject(remoteName, remoteTip); err != nil {
@@ -730,6 +1170,17 @@ func getLatestRefTipsFromRSLEntries(entries []rsl.Entry) map[string]gitinterface
 			}
 
 			refTips[entry.GetRefName()] = entry.GetTargetID()
+		case *rsl.MultiReferenceEntry:
+			annotations := annotationsMap[entry.GetID().String()]
+			if entry.SkippedBy(annotations) {
+				continue
+			}
+
+			for refName, targetID := range entry.TargetIDs {
+				if _, has := refTips[refName]; !has {
+					refTips[refName] = targetID
+				}
+			}
 		case *rsl.PropagationEntry:
 			if _, has := refTips[entry.GetRefName()]; has {
 				continue
@@ -747,6 +1198,348 @@ func getLatestRefTipsFromRSLEntries(entries []rsl.Entry) map[string]gitinterface
 	return refTips
 }
 
+// CheckRSL validates the structure of the RSL independently of the policy it
+// records. In addition to the checks performed by rsl.CheckIntegrity, it checks
+// that every entry is signed by a key that appears in at least one policy
+// recorded in the RSL. The policies themselves are not verified. If the RSL
+// records no policies, the signature check is skipped. Signature verification
+// results are recorded in the persistent cache, if it exists.
+func (r *Repository) CheckRSL(ctx context.Context) (*rsl.IntegrityReport, error) {
+	slog.Debug("Checking structure of RSL...")
+	report, err := rsl.CheckIntegrity(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying keys from all recorded policies...")
+	knownKeys := []*signerverifier.SSLibKey{}
+	knownKeyIDs := set.NewSet[string]()
+	for _, entry := range report.Entries {
+		entry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || entry.RefName != policy.PolicyRef {
+			continue
+		}
+
+		state, err := policy.LoadStateFromCommit(r.r, entry.TargetID)
+		if err != nil {
+			report.Issues = append(report.Issues, &rsl.IntegrityIssue{EntryID: entry.ID, Err: fmt.Errorf("%w: %w", ErrUnableToLoadRecordedPolicy, err)})
+			continue
+		}
+
+		for _, principal := range state.GetAllPrincipals() {
+			for _, key := range principal.Keys() {
+				if knownKeyIDs.Has(key.KeyID) {
+					continue
+				}
+				knownKeyIDs.Add(key.KeyID)
+				knownKeys = append(knownKeys, key)
+			}
+		}
+	}
+
+	if len(knownKeys) == 0 {
+		slog.Debug("No keys found in recorded policies, skipping signature check...")
+		return report, nil
+	}
+
+	signerFinder := r.newRSLEntrySignerFinder(knownKeys)
+	defer signerFinder.commit()
+
+	slog.Debug("Checking RSL entries are signed by known keys...")
+	for _, entry := range report.Entries {
+		if signerFinder.findSigner(ctx, entry.GetID()) == nil {
+			report.Issues = append(report.Issues, &rsl.IntegrityIssue{EntryID: entry.GetID(), Err: ErrRSLEntryNotSignedByKnownKey})
+		}
+	}
+
+	return report, nil
+}
+
+// GetRSLEntrySigners identifies the principals who signed each entry in the
+// RSL. Principals are drawn from every policy recorded in the RSL, each of
+// which is verified using the policy before it; policies that fail
+// verification are not used. The returned map is keyed by entry ID, and
+// entries not signed by any known principal are omitted. Signature
+// verification results are recorded in the persistent cache, if it exists.
+func (r *Repository) GetRSLEntrySigners(ctx context.Context) (map[string][]string, error) {
+	slog.Debug("Loading RSL entries...")
+	entries := []rsl.Entry{}
+	entry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		entries = append(entries, entry)
+
+		entry, err = rsl.GetParentForEntry(r.r, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	slog.Debug("Identifying principals from all recorded policies...")
+	knownKeys := []*signerverifier.SSLibKey{}
+	keyPrincipalIDs := map[string]*set.Set[string]{}
+	var verifiedState *policy.State
+	// Entries are ordered from latest to first, policies must be verified
+	// starting with the first
+	for _, entry := range slices.Backward(entries) {
+		entry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || entry.RefName != policy.PolicyRef {
+			continue
+		}
+
+		var state *policy.State
+		if verifiedState == nil {
+			// The root of trust in the first policy is trusted as in
+			// regular verification
+			state, err = policy.LoadState(ctx, r.r, entry)
+		} else {
+			state, err = policy.LoadStateFromCommit(r.r, entry.TargetID)
+			if err == nil {
+				err = verifiedState.VerifyNewState(ctx, state)
+			}
+		}
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Unable to load verified policy recorded in entry '%s', skipping: %v", entry.ID.String(), err))
+			continue
+		}
+		verifiedState = state
+
+		for principalID, principal := range state.GetAllPrincipals() {
+			for _, key := range principal.Keys() {
+				if _, has := keyPrincipalIDs[key.KeyID]; !has {
+					keyPrincipalIDs[key.KeyID] = set.NewSet[string]()
+					knownKeys = append(knownKeys, key)
+				}
+				keyPrincipalIDs[key.KeyID].Add(principalID)
+			}
+		}
+	}
+
+	signerFinder := r.newRSLEntrySignerFinder(knownKeys)
+	defer signerFinder.commit()
+
+	slog.Debug("Identifying signers of RSL entries...")
+	entrySigners := map[string][]string{}
+	for _, entry := range entries {
+		key := signerFinder.findSigner(ctx, entry.GetID())
+		if key == nil {
+			continue
+		}
+
+		signers := keyPrincipalIDs[key.KeyID].Contents()
+		slices.Sort(signers)
+		entrySigners[entry.GetID().String()] = signers
+	}
+
+	return entrySigners, nil
+}
+
+// rslEntrySignerFinder identifies which of a set of known keys signed RSL
+// entries. If it has a signature cache, previously recorded results are used
+// when available, and new results are recorded.
+type rslEntrySignerFinder struct {
+	repository     *gitinterface.Repository
+	signatureCache *cache.Persistent
+	keys           []*signerverifier.SSLibKey
+}
+
+// newRSLEntrySignerFinder returns an rslEntrySignerFinder for the keys that
+// uses the persistent cache, if it exists.
+func (r *Repository) newRSLEntrySignerFinder(keys []*signerverifier.SSLibKey) *rslEntrySignerFinder {
+	finder := &rslEntrySignerFinder{repository: r.r, keys: keys}
+	if persistentCache, err := cache.LoadPersistentCache(r.r); err == nil {
+		slog.Debug("Using persistent cache for signature verification results...")
+		finder.signatureCache = persistentCache
+	}
+
+	return finder
+}
+
+// findSigner returns the key that signed the entry, or nil if the entry isn't
+// signed by any of the keys.
+func (f *rslEntrySignerFinder) findSigner(ctx context.Context, entryID gitinterface.Hash) *signerverifier.SSLibKey {
+	for index, key := range f.keys {
+		var err error
+		if f.signatureCache == nil {
+			err = f.repository.VerifySignature(ctx, entryID, key)
+		} else {
+			err = f.signatureCache.VerifyGitSignature(ctx, f.repository, entryID, key)
+		}
+		if err != nil {
+			continue
+		}
+
+		// Consecutive entries are usually signed by the same key, so try
+		// this key first for the next entry
+		f.keys[0], f.keys[index] = f.keys[index], f.keys[0]
+		return key
+	}
+
+	return nil
+}
+
+// commit records new signature verification results in the persistent cache,
+// if it is used.
+func (f *rslEntrySignerFinder) commit() {
+	if f.signatureCache == nil {
+		return
+	}
+
+	f.signatureCache.Commit(f.repository) //nolint:errcheck
+}
+
+// RemoteRSLState describes the RSL fetched from a remote or mirror.
+type RemoteRSLState struct {
+	// Remote is the name or URL of the remote as provided by the caller.
+	Remote string
+
+	// TipID is the ID of the latest entry in the remote's RSL.
+	TipID gitinterface.Hash
+
+	// TipNumber is the number of the latest entry in the remote's RSL.
+	TipNumber uint64
+
+	// TipTime is when the latest entry in the remote's RSL was created.
+	TipTime time.Time
+}
+
+// RSLComparison describes how the RSLs of two remotes relate to each other. An
+// RSL that is a strict prefix of the other may indicate that the remote serving
+// it is withholding entries (a freeze attack), while diverged RSLs indicate
+// that the remotes are presenting different histories (a fork attack).
+type RSLComparison struct {
+	A *RemoteRSLState
+	B *RemoteRSLState
+
+	// CommonEntryID is the latest entry present in both RSLs. It is the zero
+	// hash if the RSLs have no entries in common.
+	CommonEntryID gitinterface.Hash
+
+	// EntriesOnlyInA is the number of entries in A's RSL after
+	// CommonEntryID.
+	EntriesOnlyInA int
+
+	// EntriesOnlyInB is the number of entries in B's RSL after
+	// CommonEntryID.
+	EntriesOnlyInB int
+}
+
+// Identical returns true if both remotes have the same RSL.
+func (c *RSLComparison) Identical() bool {
+	return c.EntriesOnlyInA == 0 && c.EntriesOnlyInB == 0
+}
+
+// AIsPrefixOfB returns true if every entry in A's RSL is also in B's RSL.
+func (c *RSLComparison) AIsPrefixOfB() bool {
+	return c.EntriesOnlyInA == 0
+}
+
+// BIsPrefixOfA returns true if every entry in B's RSL is also in A's RSL.
+func (c *RSLComparison) BIsPrefixOfA() bool {
+	return c.EntriesOnlyInB == 0
+}
+
+// Diverged returns true if each RSL has entries that are not in the other.
+func (c *RSLComparison) Diverged() bool {
+	return c.EntriesOnlyInA != 0 && c.EntriesOnlyInB != 0
+}
+
+// CompareRemoteRSLs fetches the RSL from each of the specified remotes, which
+// may be names of configured remotes or URLs of mirrors, and compares every
+// pair of them. The local RSL is not modified.
+func (r *Repository) CompareRemoteRSLs(remotes ...string) ([]*RemoteRSLState, []*RSLComparison, error) {
+	states := make([]*RemoteRSLState, 0, len(remotes))
+	for index, remote := range remotes {
+		// Fetch using the remote's URL directly to avoid using the gittuf
+		// transport
+		remoteURL, err := r.r.GetRemoteURL(remote)
+		if err != nil {
+			slog.Debug(fmt.Sprintf("'%s' is not a configured remote, using it as a URL...", remote))
+			remoteURL = remote
+		}
+		remoteURL = strings.TrimPrefix(remoteURL, gittufTransportPrefix)
+
+		trackerRef := fmt.Sprintf(compareRSLTrackerRef, index)
+		slog.Debug(fmt.Sprintf("Fetching RSL from '%s'...", remote))
+		if err := r.r.FetchRefSpec(remoteURL, []string{fmt.Sprintf("+%s:%s", rsl.Ref, trackerRef)}); err != nil {
+			return nil, nil, fmt.Errorf("%w from '%s': %w", ErrPullingRSL, remote, err)
+		}
+		defer r.r.DeleteReference(trackerRef) //nolint:errcheck
+
+		tipID, err := r.r.GetReference(trackerRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		tipEntry, err := rsl.GetEntry(r.r, tipID)
+		if err != nil {
+			return nil, nil, err
+		}
+		tipTime, err := r.r.GetCommitTime(tipID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		states = append(states, &RemoteRSLState{
+			Remote:    remote,
+			TipID:     tipID,
+			TipNumber: tipEntry.GetNumber(),
+			TipTime:   tipTime,
+		})
+	}
+
+	comparisons := []*RSLComparison{}
+	for indexA := range states {
+		for indexB := indexA + 1; indexB < len(states); indexB++ {
+			comparison, err := r.compareRSLStates(states[indexA], states[indexB])
+			if err != nil {
+				return nil, nil, err
+			}
+			comparisons = append(comparisons, comparison)
+		}
+	}
+
+	return states, comparisons, nil
+}
+
+func (r *Repository) compareRSLStates(stateA, stateB *RemoteRSLState) (*RSLComparison, error) {
+	comparison := &RSLComparison{A: stateA, B: stateB, CommonEntryID: gitinterface.ZeroHash}
+
+	if stateA.TipID.Equal(stateB.TipID) {
+		comparison.CommonEntryID = stateA.TipID
+		return comparison, nil
+	}
+
+	commonEntryID, err := r.r.GetCommonAncestor(stateA.TipID, stateB.TipID)
+	switch {
+	case err == nil:
+		comparison.CommonEntryID = commonEntryID
+	case errors.Is(err, gitinterface.ErrNoCommonAncestor):
+		// The RSLs share no history
+		slog.Debug(fmt.Sprintf("No common entry found for RSLs from '%s' and '%s'", stateA.Remote, stateB.Remote))
+	default:
+		return nil, err
+	}
+
+	entriesOnlyInA, err := r.r.GetCommitsBetweenRange(stateA.TipID, comparison.CommonEntryID)
+	if err != nil {
+		return nil, err
+	}
+	comparison.EntriesOnlyInA = len(entriesOnlyInA)
+
+	entriesOnlyInB, err := r.r.GetCommitsBetweenRange(stateB.TipID, comparison.CommonEntryID)
+	if err != nil {
+		return nil, err
+	}
+	comparison.EntriesOnlyInB = len(entriesOnlyInB)
+
+	return comparison, nil
+}
+
 // PushRSL pushes the local RSL to the specified remote. As this push defaults
 // to fast-forward only, divergent RSL states are detected.
 func (r *Repository) PushRSL(remoteName string) error {
@@ -769,11 +1562,34 @@ func (r *Repository) PullRSL(remoteName string) error {
 	return nil
 }
 
+// absoluteReference identifies the absolute path of refName. If allowDeleted is
+// set, the reference may no longer exist locally. In that case, a short name is
+// resolved to a tag or branch that has entries in the RSL.
+func (r *Repository) absoluteReference(refName string, allowDeleted bool) (string, error) {
+	absRefName, err := r.r.AbsoluteReference(refName)
+	if err == nil || !allowDeleted || !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+		return absRefName, err
+	}
+
+	for _, candidate := range []string{gitinterface.TagReferenceName(refName), gitinterface.BranchReferenceName(refName)} {
+		_, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(candidate))
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return "", err
+		}
+	}
+
+	return "", gitinterface.ErrReferenceNotFound
+}
+
 // isDuplicateEntry checks if the latest unskipped entry for the ref has the
 // same target ID. Note that it's legal for the RSL to have target A, then B,
-// then A again, this is not considered a duplicate entry
-func (r *Repository) isDuplicateEntry(refName string, targetID gitinterface.Hash) (bool, error) {
-	latestUnskippedEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(refName), rsl.IsUnskipped())
+// then A again, this is not considered a duplicate entry. Skip annotations are
+// only honored if skipAuthorizer permits them, nil honors all of them.
+func (r *Repository) isDuplicateEntry(refName string, targetID gitinterface.Hash, skipAuthorizer rsl.SkipAuthorizer) (bool, error) {
+	latestUnskippedEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(refName), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(skipAuthorizer))
 	if err != nil {
 		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
 			return false, nil
@@ -903,7 +1719,12 @@ func (r *Repository) PropagateChangesFromUpstreamRepositories(ctx context.Contex
 			return fmt.Errorf("unable to fetch upstream repository '%s': %w", upstreamRepositoryURL, err)
 		}
 
-		if err := rsl.PropagateChangesFromUpstreamRepository(r.r, upstreamRepository, directives, sign); err != nil {
+		upstreamSkipAuthorizer, err := loadUpstreamSkipAuthorizer(ctx, upstreamRepository)
+		if err != nil {
+			return err
+		}
+
+		if err := rsl.PropagateChangesFromUpstreamRepository(r.r, upstreamRepository, directives, upstreamSkipAuthorizer, sign); err != nil {
 			// TODO: atomic? abort?
 			return err
 		}
@@ -911,3 +1732,32 @@ func (r *Repository) PropagateChangesFromUpstreamRepositories(ctx context.Contex
 
 	return nil
 }
+
+// loadUpstreamSkipAuthorizer returns the authorizer for skip annotations in
+// the RSL of the upstream repository, cloned from its default remote, using
+// the upstream repository's current policy. If the upstream repository does
+// not have a policy, nil is returned so that all skip annotations are honored.
+func loadUpstreamSkipAuthorizer(ctx context.Context, upstreamRepository *gitinterface.Repository) (rsl.SkipAuthorizer, error) {
+	remoteRefs, err := upstreamRepository.ListRemoteReferences(gitinterface.DefaultRemoteName, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+	if _, has := remoteRefs[policy.PolicyRef]; !has {
+		return nil, nil
+	}
+
+	slog.Debug("Fetching upstream policy to evaluate skip annotations...")
+	if err := upstreamRepository.Fetch(gitinterface.DefaultRemoteName, []string{policy.PolicyRef}, true); err != nil {
+		return nil, err
+	}
+
+	state, err := policy.LoadCurrentState(ctx, upstreamRepository, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return state.SkipAuthorizer(ctx), nil
+}
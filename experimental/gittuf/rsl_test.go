@@ -5,18 +5,25 @@ package gittuf
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	"github.com/gittuf/gittuf/internal/cache"
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
@@ -205,6 +212,129 @@ func TestRecordRSLEntryForReference(t *testing.T) {
 	})
 }
 
+func TestRecordRSLEntryForReferences(t *testing.T) {
+	tempDir := t.TempDir()
+	r := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	repo := &Repository{r: r}
+
+	emptyTreeHash, err := repo.r.EmptyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainCommitID, err := repo.r.Commit(emptyTreeHash, "refs/heads/main", "Initial commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	featureCommitID, err := repo.r.Commit(emptyTreeHash, "refs/heads/feature", "Feature commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForReferences(testCtx, []string{"main", "refs/heads/feature"}, false, rslopts.WithRecordLocalOnly())
+	require.Nil(t, err)
+
+	entryT, err := rsl.GetLatestEntry(repo.r)
+	require.Nil(t, err)
+	entry, isMultiReferenceEntry := entryT.(*rsl.MultiReferenceEntry)
+	require.True(t, isMultiReferenceEntry)
+	assert.Equal(t, map[string]gitinterface.Hash{"refs/heads/main": mainCommitID, "refs/heads/feature": featureCommitID}, entry.TargetIDs)
+
+	t.Run("duplicates are not recorded", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReferences(testCtx, []string{"main", "feature"}, false, rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		assert.Equal(t, entry.GetID(), latestEntry.GetID())
+
+		newCommitID, err := repo.r.Commit(emptyTreeHash, "refs/heads/main", "Another commit\n", false)
+		require.Nil(t, err)
+
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"main", "feature"}, false, rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		// Only main has changed, so a regular reference entry is recorded
+		latestEntry, err = rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		referenceEntry, isReferenceEntry := latestEntry.(*rsl.ReferenceEntry)
+		require.True(t, isReferenceEntry)
+		assert.Equal(t, "refs/heads/main", referenceEntry.RefName)
+		assert.Equal(t, newCommitID, referenceEntry.TargetID)
+	})
+
+	t.Run("override ref names", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReferences(testCtx, []string{"main", "main"}, false, rslopts.WithOverrideRefNames("refs/heads/a", "refs/heads/b"), rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		mainTip, err := repo.r.GetReference("refs/heads/main")
+		require.Nil(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		multiReferenceEntry, isMultiReferenceEntry := latestEntry.(*rsl.MultiReferenceEntry)
+		require.True(t, isMultiReferenceEntry)
+		assert.Equal(t, map[string]gitinterface.Hash{"refs/heads/a": mainTip, "refs/heads/b": mainTip}, multiReferenceEntry.TargetIDs)
+	})
+
+	t.Run("invalid overrides", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReferences(testCtx, []string{"main", "feature"}, false, rslopts.WithOverrideRefNames("refs/heads/a"), rslopts.WithRecordLocalOnly())
+		assert.ErrorIs(t, err, ErrInvalidRefNameOverrides)
+
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"main", "feature"}, false, rslopts.WithOverrideRefName("refs/heads/a"), rslopts.WithRecordLocalOnly())
+		assert.ErrorIs(t, err, ErrInvalidRefNameOverrides)
+	})
+
+	t.Run("duplicate reference", func(t *testing.T) {
+		err := repo.RecordRSLEntryForReferences(testCtx, []string{"main", "refs/heads/main"}, false, rslopts.WithSkipCheckForDuplicateEntry(), rslopts.WithRecordLocalOnly())
+		assert.ErrorIs(t, err, ErrDuplicateReference)
+	})
+
+	t.Run("record deletions", func(t *testing.T) {
+		// b must be deleted locally before its deletion is recorded
+		mainTip, err := repo.r.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		require.Nil(t, repo.r.SetReference("refs/heads/b", mainTip))
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"b"}, false, rslopts.WithRecordDeletion(), rslopts.WithRecordLocalOnly())
+		assert.ErrorIs(t, err, ErrReferenceNotDeleted)
+
+		// b only has entries in the RSL after it's deleted locally
+		err = repo.r.DeleteReference("refs/heads/b")
+		require.Nil(t, err)
+
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"b"}, false, rslopts.WithRecordDeletion(), rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		referenceEntry, isReferenceEntry := latestEntry.(*rsl.ReferenceEntry)
+		require.True(t, isReferenceEntry)
+		assert.Equal(t, "refs/heads/b", referenceEntry.RefName)
+		assert.True(t, referenceEntry.IsDeletion())
+
+		// The deletion is already recorded
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"refs/heads/b"}, false, rslopts.WithRecordDeletion(), rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		newLatestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		assert.Equal(t, latestEntry.GetID(), newLatestEntry.GetID())
+
+		// An empty reference name deletes the override, like ':<dst>'
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{"main", ""}, false, rslopts.WithOverrideRefNames("", "refs/heads/a"), rslopts.WithSkipCheckForDuplicateEntry(), rslopts.WithRecordLocalOnly())
+		require.Nil(t, err)
+
+		latestEntry, err = rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		multiReferenceEntry, isMultiReferenceEntry := latestEntry.(*rsl.MultiReferenceEntry)
+		require.True(t, isMultiReferenceEntry)
+		assert.Equal(t, map[string]gitinterface.Hash{"refs/heads/main": mainTip, "refs/heads/a": repo.r.ZeroHash()}, multiReferenceEntry.TargetIDs)
+
+		err = repo.RecordRSLEntryForReferences(testCtx, []string{""}, false, rslopts.WithRecordLocalOnly())
+		assert.ErrorIs(t, err, ErrInvalidRefNameOverrides)
+	})
+}
+
 func TestRecordRSLEntryForReferenceAtTarget(t *testing.T) {
 	t.Setenv(dev.DevModeKey, "1")
 
@@ -351,6 +481,30 @@ func TestRecordRSLAnnotation(t *testing.T) {
 	assert.Equal(t, []gitinterface.Hash{entryID}, annotation.RSLEntryIDs)
 	assert.True(t, annotation.Skip)
 
+	t.Run("with data", func(t *testing.T) {
+		data := map[string]any{
+			"incident": "INC-1234",
+			"severity": 2,
+			"details":  json.RawMessage(`{"revert":true}`),
+		}
+		err := repo.RecordRSLAnnotation(testCtx, []string{entryID.String()}, false, "incident annotation", false, rslopts.WithAnnotateLocalOnly(), rslopts.WithAnnotateData(data))
+		assert.Nil(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotation := latestEntry.(*rsl.AnnotationEntry)
+		assert.Equal(t, map[string]json.RawMessage{
+			"incident": json.RawMessage(`"INC-1234"`),
+			"severity": json.RawMessage(`2`),
+			"details":  json.RawMessage(`{"revert":true}`),
+		}, annotation.Data)
+
+		err = repo.RecordRSLAnnotation(testCtx, []string{entryID.String()}, false, "incident annotation", false, rslopts.WithAnnotateLocalOnly(), rslopts.WithAnnotateData(map[string]any{"incident id": "INC-1234"}))
+		assert.ErrorIs(t, err, ErrInvalidAnnotationDataKey)
+	})
+
 	t.Run("miscellaneous error checking", func(t *testing.T) {
 		tempDir := t.TempDir()
 		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
@@ -367,6 +521,183 @@ func TestRecordRSLAnnotation(t *testing.T) {
 	})
 }
 
+func TestRecordRSLHeartbeat(t *testing.T) {
+	tempDir := t.TempDir()
+	r := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	repo := &Repository{r: r}
+
+	err := repo.RecordRSLHeartbeat(testCtx, false)
+	assert.ErrorIs(t, err, ErrRemoteNotSpecified)
+
+	err = repo.RecordRSLHeartbeat(testCtx, false, rslopts.WithHeartbeatRemote("origin"), rslopts.WithHeartbeatLocalOnly())
+	assert.ErrorIs(t, err, ErrCannotUseRemoteAndLocalOnly)
+
+	before := time.Now().Truncate(time.Second)
+	err = repo.RecordRSLHeartbeat(testCtx, true, rslopts.WithHeartbeatLocalOnly(), rslopts.WithHeartbeatSigningKeyBytes(gpgKeyBytes))
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.IsType(t, &rsl.HeartbeatEntry{}, latestEntry)
+
+	heartbeat := latestEntry.(*rsl.HeartbeatEntry)
+	assert.False(t, heartbeat.Timestamp.Before(before))
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.r.VerifySignature(testCtx, heartbeat.GetID(), gpgKey)
+	assert.Nil(t, err)
+
+	t.Run("miscellaneous error checking", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+		nr := &Repository{r: repo}
+
+		// Test signCommit
+		err := repo.SetGitConfig("user.signingkey", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = nr.RecordRSLHeartbeat(testCtx, true, rslopts.WithHeartbeatLocalOnly())
+		assert.ErrorIs(t, err, gitinterface.ErrSigningKeyNotSpecified)
+	})
+}
+
+func TestRecordRSLCheckpoint(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	err := repo.RecordRSLCheckpoint(testCtx, nil, false)
+	assert.ErrorIs(t, err, ErrRemoteNotSpecified)
+
+	err = repo.RecordRSLCheckpoint(testCtx, nil, false, rslopts.WithCheckpointRemote("origin"), rslopts.WithCheckpointLocalOnly())
+	assert.ErrorIs(t, err, ErrCannotUseRemoteAndLocalOnly)
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	err = repo.RecordRSLCheckpoint(testCtx, []sslibdsse.SignerVerifier{rootSigner}, false, rslopts.WithCheckpointLocalOnly())
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.IsType(t, &rsl.CheckpointEntry{}, latestEntry)
+
+	checkpoint := latestEntry.(*rsl.CheckpointEntry)
+	assert.Equal(t, map[string]gitinterface.Hash{refName: entryID}, checkpoint.RefEntryIDs)
+	assert.NotNil(t, checkpoint.Signatures)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err = repo.VerifyRef(testCtx, refName)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, verifyopts.WithFull())
+	assert.Nil(t, err)
+}
+
+func TestBootstrapRSL(t *testing.T) {
+	t.Run("remote options", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicyAuthorizingGitSigningKey(t)
+
+		_, err := repo.BootstrapRSL(testCtx, true)
+		assert.ErrorIs(t, err, ErrRemoteNotSpecified)
+
+		_, err = repo.BootstrapRSL(testCtx, true, rslopts.WithBootstrapRemote("origin"), rslopts.WithBootstrapLocalOnly())
+		assert.ErrorIs(t, err, ErrCannotUseRemoteAndLocalOnly)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := &Repository{r: gitinterface.CreateTestGitRepository(t, tempDir, false)}
+		common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/main", 1, gpgKeyBytes)
+
+		_, err := repo.BootstrapRSL(testCtx, true, rslopts.WithBootstrapLocalOnly())
+		assert.ErrorIs(t, err, policy.ErrPolicyNotFound)
+	})
+
+	t.Run("bootstrap existing references", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicyAuthorizingGitSigningKey(t)
+
+		// History that predates gittuf is signed by an unauthorized key
+		mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/main", 2, gpgUnauthorizedKeyBytes)
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/feature", 1, gpgUnauthorizedKeyBytes)
+		require.Nil(t, repo.r.SetReference("refs/tags/v1", mainCommitIDs[0]))
+
+		// References already recorded in the RSL are not bootstrapped
+		common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/recorded", 1, rsaKeyBytes)
+		require.Nil(t, repo.RecordRSLEntryForReference(testCtx, "refs/heads/recorded", true, rslopts.WithRecordLocalOnly()))
+
+		refNames, err := repo.BootstrapRSL(testCtx, true, rslopts.WithBootstrapLocalOnly())
+		require.Nil(t, err)
+		assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main", "refs/tags/v1"}, refNames)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		require.Nil(t, err)
+		annotation, isAnnotation := latestEntry.(*rsl.AnnotationEntry)
+		require.True(t, isAnnotation)
+		assert.False(t, annotation.Skip)
+		isBaseline := false
+		hasBaseline, err := annotation.GetData(policy.BaselineAnnotationDataKey, &isBaseline)
+		require.Nil(t, err)
+		assert.True(t, hasBaseline)
+		assert.True(t, isBaseline)
+
+		baselineEntry, err := rsl.GetParentForEntry(repo.r, annotation)
+		require.Nil(t, err)
+		assert.Equal(t, []gitinterface.Hash{baselineEntry.GetID()}, annotation.RSLEntryIDs)
+		multiReferenceEntry, isMultiReferenceEntry := baselineEntry.(*rsl.MultiReferenceEntry)
+		require.True(t, isMultiReferenceEntry)
+		assert.Equal(t, map[string]gitinterface.Hash{
+			"refs/heads/feature": featureCommitIDs[0],
+			"refs/heads/main":    mainCommitIDs[1],
+			"refs/tags/v1":       mainCommitIDs[0],
+		}, multiReferenceEntry.TargetIDs)
+
+		assert.Nil(t, repo.VerifyRef(testCtx, "refs/heads/main", verifyopts.WithFull()))
+
+		refNames, err = repo.BootstrapRSL(testCtx, true, rslopts.WithBootstrapLocalOnly())
+		assert.Nil(t, err)
+		assert.Empty(t, refNames)
+	})
+}
+
+func TestEvaluateHistoryForReference(t *testing.T) {
+	repo := createTestRepositoryWithPolicyAuthorizingGitSigningKey(t)
+	refName := "refs/heads/main"
+
+	authorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, rsaKeyBytes)
+	unauthorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+
+	results, err := repo.EvaluateHistoryForReference(testCtx, "main")
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+
+	for _, result := range results {
+		assert.True(t, result.Signed)
+		switch {
+		case result.ID.Equal(authorizedCommitIDs[0]):
+			assert.True(t, result.SatisfiesPolicy)
+		case result.ID.Equal(unauthorizedCommitIDs[0]):
+			assert.False(t, result.SatisfiesPolicy)
+		default:
+			t.Errorf("unexpected commit '%s'", result.ID.String())
+		}
+	}
+}
+
 func TestReconcileLocalRSLWithRemote(t *testing.T) {
 	remoteName := "origin"
 	refName := "refs/heads/main"
@@ -702,6 +1033,69 @@ func TestReconcileLocalRSLWithRemote(t *testing.T) {
 		assert.Equal(t, originalEntry.(*rsl.ReferenceEntry).TargetID, currentEntry.(*rsl.ReferenceEntry).TargetID)
 	})
 
+	t.Run("remote and local have diverged, local annotation is reapplied", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		remoteR := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+		remoteRepo := &Repository{r: remoteR}
+
+		treeBuilder := gitinterface.NewTreeBuilder(remoteR)
+		emptyTreeHash, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.Nil(t, err)
+
+		// Simulate remote actions
+		_, err = remoteR.Commit(emptyTreeHash, refName, "Test commit", false)
+		require.Nil(t, err)
+		require.Nil(t, remoteRepo.RecordRSLEntryForReference(testCtx, refName, false, rslopts.WithRecordLocalOnly()))
+
+		// Clone remote repository
+		localTmpDir := filepath.Join(os.TempDir(), fmt.Sprintf("local-%s", t.Name()))
+		defer os.RemoveAll(localTmpDir) //nolint:errcheck
+		localR, err := gitinterface.CloneAndFetchRepository(tmpDir, localTmpDir, refName, []string{rsl.Ref}, true)
+		require.Nil(t, err)
+		require.Nil(t, localR.SetGitConfig("user.name", "Jane Doe"))
+		require.Nil(t, localR.SetGitConfig("user.email", "jane.doe@example.com"))
+		localRepo := &Repository{r: localR}
+
+		// Simulate remote actions
+		_, err = remoteRepo.r.Commit(emptyTreeHash, refName, "Test commit", false)
+		require.Nil(t, err)
+		require.Nil(t, remoteRepo.RecordRSLEntryForReference(testCtx, refName, false, rslopts.WithRecordLocalOnly()))
+
+		// Simulate local actions: an entry for another ref and an
+		// annotation with data for it
+		_, err = localRepo.r.Commit(emptyTreeHash, anotherRefName, "Test commit", false)
+		require.Nil(t, err)
+		require.Nil(t, localRepo.RecordRSLEntryForReference(testCtx, anotherRefName, false, rslopts.WithRecordLocalOnly()))
+		localEntryID, err := localRepo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{localEntryID}, false, "Baseline")
+		annotation.Data = map[string]json.RawMessage{policy.BaselineAnnotationDataKey: json.RawMessage(`true`)}
+		require.Nil(t, annotation.Commit(localRepo.r, false))
+
+		err = localRepo.ReconcileLocalRSLWithRemote(testCtx, remoteName, false)
+		assert.Nil(t, err)
+
+		// The reapplied annotation must retain its data and refer to the
+		// reapplied entry
+		currentLocalRSLTip, err := localRepo.r.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		currentEntry, err := rsl.GetEntry(localRepo.r, currentLocalRSLTip)
+		require.Nil(t, err)
+		reappliedAnnotation, isAnnotation := currentEntry.(*rsl.AnnotationEntry)
+		require.True(t, isAnnotation)
+		assert.Equal(t, annotation.Data, reappliedAnnotation.Data)
+
+		parentIDs, err := localRepo.r.GetCommitParentIDs(currentLocalRSLTip)
+		require.Nil(t, err)
+		assert.Equal(t, []gitinterface.Hash{parentIDs[0]}, reappliedAnnotation.RSLEntryIDs)
+		assert.NotEqual(t, localEntryID, parentIDs[0])
+
+		reappliedEntry, err := rsl.GetEntry(localRepo.r, parentIDs[0])
+		require.Nil(t, err)
+		assert.Equal(t, anotherRefName, reappliedEntry.(*rsl.ReferenceEntry).RefName)
+	})
+
 	t.Run("remote and local have diverged but modify same ref", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		remoteR := gitinterface.CreateTestGitRepository(t, tmpDir, false)
@@ -2282,3 +2676,193 @@ func TestRecordRSLEntryForReferenceSigningKeyBytesIgnoredWhenUnsigned(t *testing
 	err = repo.r.VerifySignature(testCtx, entry.GetID(), publicKey)
 	assert.Error(t, err, "entry must not be signed when signCommit=false")
 }
+
+func TestCheckRSL(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+	refName := "refs/heads/main"
+
+	// The entries created while setting up the policy are unsigned
+	report, err := repo.CheckRSL(testCtx)
+	assert.Nil(t, err)
+	unsignedEntriesCount := len(report.Entries)
+	assert.Len(t, report.Issues, unsignedEntriesCount)
+	for _, issue := range report.Issues {
+		assert.ErrorIs(t, issue, ErrRSLEntryNotSignedByKnownKey)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	report, err = repo.CheckRSL(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, unsignedEntriesCount)
+
+	// Entry signed by a key that doesn't appear in any policy
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	unknownSignerEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	report, err = repo.CheckRSL(testCtx)
+	assert.Nil(t, err)
+	require.Len(t, report.Issues, unsignedEntriesCount+1)
+	assert.Equal(t, unknownSignerEntryID, report.Issues[unsignedEntriesCount].EntryID)
+	assert.ErrorIs(t, report.Issues[unsignedEntriesCount], ErrRSLEntryNotSignedByKnownKey)
+
+	// Signature verification results are recorded in the persistent cache
+	require.Nil(t, cache.PopulatePersistentCache(repo.r))
+	report, err = repo.CheckRSL(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, unsignedEntriesCount+1)
+
+	persistentCache, err := cache.LoadPersistentCache(repo.r)
+	require.Nil(t, err)
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	require.Nil(t, err)
+	verified, has := persistentCache.GetGitSignatureResult(unknownSignerEntryID, gpgKeyR)
+	assert.True(t, has)
+	assert.False(t, verified)
+}
+
+func TestGetRSLEntrySigners(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+	refName := "refs/heads/main"
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	signedEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	unknownSignerEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	entrySigners, err := repo.GetRSLEntrySigners(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{signedEntryID.String(): {gpgKeyR.KeyID}}, entrySigners)
+	assert.NotContains(t, entrySigners, unknownSignerEntryID.String())
+
+	// Record a policy with a different root of trust that trusts the
+	// unauthorized key, which fails verification using the prior policy
+	otherTmpDir := t.TempDir()
+	otherRepo := &Repository{r: gitinterface.CreateTestGitRepository(t, otherTmpDir, false)}
+	otherSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	require.Nil(t, otherRepo.InitializeRoot(testCtx, otherSigner, false))
+	require.Nil(t, otherRepo.AddTopLevelTargetsKey(testCtx, otherSigner, tufv01.NewKeyFromSSLibKey(otherSigner.MetadataKey()), false))
+	require.Nil(t, otherRepo.InitializeTargets(testCtx, otherSigner, policy.TargetsRoleName, false))
+	unauthorizedKeyR, err := gpg.LoadGPGKeyFromBytes(gpgUnauthorizedKeyBytes)
+	require.Nil(t, err)
+	require.Nil(t, otherRepo.AddPrincipalToTargets(testCtx, otherSigner, policy.TargetsRoleName, []tuf.Principal{tufv01.NewKeyFromSSLibKey(unauthorizedKeyR)}, false))
+
+	require.Nil(t, repo.r.FetchRefSpec(otherTmpDir, []string{fmt.Sprintf("+%s:%s", policy.PolicyStagingRef, policy.PolicyRef)}))
+	otherPolicyTip, err := repo.r.GetReference(policy.PolicyRef)
+	require.Nil(t, err)
+	require.Nil(t, rsl.NewReferenceEntry(policy.PolicyRef, otherPolicyTip).Commit(repo.r, false))
+
+	entrySigners, err = repo.GetRSLEntrySigners(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{signedEntryID.String(): {gpgKeyR.KeyID}}, entrySigners)
+}
+
+func TestCompareRemoteRSLs(t *testing.T) {
+	// Remote A has two entries
+	remoteATmpDir := t.TempDir()
+	remoteAR := gitinterface.CreateTestGitRepository(t, remoteATmpDir, true)
+	require.Nil(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(remoteAR, false))
+	firstEntryID, err := remoteAR.GetReference(rsl.Ref)
+	require.Nil(t, err)
+	require.Nil(t, rsl.NewReferenceEntry("refs/heads/feature", gitinterface.ZeroHash).Commit(remoteAR, false))
+	remoteATipID, err := remoteAR.GetReference(rsl.Ref)
+	require.Nil(t, err)
+
+	// Mirror B has an additional entry
+	mirrorBTmpDir := t.TempDir()
+	mirrorBR := gitinterface.CreateTestGitRepository(t, mirrorBTmpDir, true)
+	require.Nil(t, mirrorBR.FetchRefSpec(remoteATmpDir, []string{fmt.Sprintf("%s:%s", rsl.Ref, rsl.Ref)}))
+	require.Nil(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(mirrorBR, false))
+
+	// Mirror C has a different second entry
+	mirrorCTmpDir := t.TempDir()
+	mirrorCR := gitinterface.CreateTestGitRepository(t, mirrorCTmpDir, true)
+	require.Nil(t, mirrorCR.FetchRefSpec(remoteATmpDir, []string{fmt.Sprintf("%s:%s", rsl.Ref, rsl.Ref)}))
+	require.Nil(t, mirrorCR.SetReference(rsl.Ref, firstEntryID))
+	require.Nil(t, rsl.NewReferenceEntry("refs/heads/other", gitinterface.ZeroHash).Commit(mirrorCR, false))
+
+	localTmpDir := t.TempDir()
+	localR := gitinterface.CreateTestGitRepository(t, localTmpDir, false)
+	require.Nil(t, localR.AddRemote("origin", gittufTransportPrefix+remoteATmpDir))
+	repo := &Repository{r: localR}
+
+	states, comparisons, err := repo.CompareRemoteRSLs("origin", mirrorBTmpDir, mirrorCTmpDir)
+	assert.Nil(t, err)
+
+	require.Len(t, states, 3)
+	assert.Equal(t, "origin", states[0].Remote)
+	assert.Equal(t, remoteATipID, states[0].TipID)
+	assert.Equal(t, uint64(2), states[0].TipNumber)
+	assert.Equal(t, uint64(3), states[1].TipNumber)
+	assert.Equal(t, uint64(2), states[2].TipNumber)
+
+	require.Len(t, comparisons, 3)
+
+	// A and B: A is a prefix of B
+	assert.True(t, comparisons[0].AIsPrefixOfB())
+	assert.False(t, comparisons[0].Diverged())
+	assert.Equal(t, remoteATipID, comparisons[0].CommonEntryID)
+	assert.Equal(t, 1, comparisons[0].EntriesOnlyInB)
+
+	// A and C: diverged after the first entry
+	assert.True(t, comparisons[1].Diverged())
+	assert.Equal(t, firstEntryID, comparisons[1].CommonEntryID)
+	assert.Equal(t, 1, comparisons[1].EntriesOnlyInA)
+	assert.Equal(t, 1, comparisons[1].EntriesOnlyInB)
+
+	// B and C: diverged after the first entry
+	assert.True(t, comparisons[2].Diverged())
+	assert.Equal(t, firstEntryID, comparisons[2].CommonEntryID)
+	assert.Equal(t, 2, comparisons[2].EntriesOnlyInA)
+	assert.Equal(t, 1, comparisons[2].EntriesOnlyInB)
+
+	// Identical RSLs
+	states, comparisons, err = repo.CompareRemoteRSLs("origin", remoteATmpDir)
+	assert.Nil(t, err)
+	assert.Len(t, states, 2)
+	require.Len(t, comparisons, 1)
+	assert.True(t, comparisons[0].Identical())
+
+	// The local RSL is not modified and temporary refs are removed
+	_, err = localR.GetReference(rsl.Ref)
+	assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+	_, err = localR.GetReference(fmt.Sprintf(compareRSLTrackerRef, 0))
+	assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+
+	t.Run("RSLs with no shared history", func(t *testing.T) {
+		mirrorDTmpDir := t.TempDir()
+		mirrorDR := gitinterface.CreateTestGitRepository(t, mirrorDTmpDir, true)
+		require.Nil(t, rsl.NewReferenceEntry("refs/heads/unrelated", gitinterface.ZeroHash).Commit(mirrorDR, false))
+
+		_, comparisons, err := repo.CompareRemoteRSLs("origin", mirrorDTmpDir)
+		assert.Nil(t, err)
+		require.Len(t, comparisons, 1)
+		assert.True(t, comparisons[0].CommonEntryID.IsZero())
+		assert.True(t, comparisons[0].Diverged())
+		assert.Equal(t, 2, comparisons[0].EntriesOnlyInA)
+		assert.Equal(t, 1, comparisons[0].EntriesOnlyInB)
+	})
+
+	t.Run("error finding common entry", func(t *testing.T) {
+		missingTipID, err := gitinterface.NewHash("ffffffffffffffffffffffffffffffffffffffff")
+		require.Nil(t, err)
+
+		stateA := &RemoteRSLState{Remote: "origin", TipID: remoteATipID}
+		stateB := &RemoteRSLState{Remote: "missing", TipID: missingTipID}
+
+		_, err = repo.compareRSLStates(stateA, stateB)
+		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, gitinterface.ErrNoCommonAncestor)
+	})
+}
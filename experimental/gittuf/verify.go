@@ -8,11 +8,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	verifyallopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifyall"
 	verifymergeableopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifymergeable"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
@@ -25,6 +30,33 @@ import (
 // another is to create a new RSL entry for the current state.
 var ErrRefStateDoesNotMatchRSL = errors.New("current state of Git reference does not match latest RSL entry")
 
+// ErrVerifyAllFailed is returned when at least one of the references inspected
+// by VerifyAll fails verification. The per-reference results identify the
+// failing references.
+var ErrVerifyAllFailed = errors.New("one or more references failed gittuf verification")
+
+// RefVerificationResult records the outcome of verifying a single reference
+// during VerifyAll, VerifyPushedReferenceUpdates, or Mirror.
+type RefVerificationResult struct {
+	// RefName is the absolute name of the verified reference.
+	RefName string
+
+	// Skipped is set when the reference is not verified, such as when it does
+	// not exist in the local repository, so its tip cannot be checked against
+	// the RSL, or when a pushed or mirrored reference is not protected by the
+	// policy.
+	Skipped bool
+
+	// DelegatedToForge is set when a pushed reference is delegated to the
+	// forge trusted in the policy, which records the push in the RSL once it
+	// is applied.
+	DelegatedToForge bool
+
+	// Err is the verification error for the reference, nil if verification
+	// succeeded.
+	Err error
+}
+
 func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...verifyopts.Option) error {
 	var (
 		expectedTip gitinterface.Hash
@@ -37,7 +69,7 @@ func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...veri
 	}
 
 	slog.Debug("Identifying absolute reference path...")
-	refName, err = r.r.AbsoluteReference(refName)
+	refName, err = r.absoluteReference(refName, true)
 	if err != nil {
 		return err
 	}
@@ -50,7 +82,7 @@ func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...veri
 		// We must consider RSL entries that have refNameOverride rather than
 		// refName
 		slog.Debug("Name of reference overridden to match remote reference name, identifying absolute reference path...")
-		refNameOverride, err := r.r.AbsoluteReference(options.RefNameOverride)
+		refNameOverride, err := r.absoluteReference(options.RefNameOverride, true)
 		if err != nil {
 			return err
 		}
@@ -62,9 +94,12 @@ func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...veri
 
 	verifier := policy.NewPolicyVerifier(r.r)
 
-	if options.LatestOnly {
+	switch {
+	case options.LatestOnly:
 		expectedTip, err = verifier.VerifyRef(ctx, refName)
-	} else {
+	case options.Full:
+		expectedTip, err = verifier.VerifyRefFromFirstEntry(ctx, refName)
+	default:
 		expectedTip, err = verifier.VerifyRefFull(ctx, refName)
 	}
 	if err != nil {
@@ -77,6 +112,16 @@ func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...veri
 		return err
 	}
 
+	slog.Debug("Verifying RSL is up to date using heartbeats...")
+	if err := verifier.VerifyHeartbeat(ctx, time.Now()); err != nil {
+		isStale := errors.Is(err, policy.ErrHeartbeatStale) || errors.Is(err, policy.ErrHeartbeatNotFound)
+		if !isStale || !options.WarnOnStaleHeartbeat {
+			return err
+		}
+
+		slog.Warn("RSL may be outdated", "error", err)
+	}
+
 	slog.Debug("Verification successful!")
 	return nil
 }
@@ -94,7 +139,7 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, refName, entryID st
 	var err error
 
 	slog.Debug("Identifying absolute reference path...")
-	refName, err = r.r.AbsoluteReference(refName)
+	refName, err = r.absoluteReference(refName, true)
 	if err != nil {
 		return err
 	}
@@ -112,7 +157,7 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, refName, entryID st
 		// We must consider RSL entries that have refNameOverride rather than
 		// refName
 		slog.Debug("Name of reference overridden to match remote reference name, identifying absolute reference path...")
-		refNameOverride, err := r.r.AbsoluteReference(options.RefNameOverride)
+		refNameOverride, err := r.absoluteReference(options.RefNameOverride, true)
 		if err != nil {
 			return err
 		}
@@ -137,6 +182,192 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, refName, entryID st
 	return nil
 }
 
+// VerifyAll verifies every reference with entries in the RSL, or with
+// verifyallopts.WithProtectedOnly, every local reference protected by a Git
+// namespace rule in the current policy. References that don't exist locally
+// are verified if their latest RSL entries record their deletion, and skipped
+// otherwise. A single policy verifier is used for all references so that
+// policy and attestation states are loaded once, and the RSL heartbeat is
+// checked once for all references. The result for each reference is returned;
+// ErrVerifyAllFailed is returned if any of them failed verification.
+func (r *Repository) VerifyAll(ctx context.Context, opts ...verifyallopts.Option) ([]RefVerificationResult, error) {
+	options := &verifyallopts.Options{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	sinceEntryID := gitinterface.ZeroHash
+	if options.ChangedSinceEntry != "" {
+		var err error
+		sinceEntryID, err = gitinterface.NewHash(options.ChangedSinceEntry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Identifying references with entries in the RSL...")
+	refNames, err := rsl.GetReferencesUpdatedSince(r.r, sinceEntryID)
+	if err != nil {
+		return nil, err
+	}
+
+	skipAuthorizer, err := r.currentSkipAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.ProtectedOnly {
+		refNames, err = r.getProtectedReferences(ctx, refNames, options.ChangedSinceEntry != "", skipAuthorizer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verifier := policy.NewPolicyVerifier(r.r)
+
+	results := make([]RefVerificationResult, 0, len(refNames))
+	failed := false
+	for _, refName := range refNames {
+		result := RefVerificationResult{RefName: refName}
+
+		if _, err := r.r.GetReference(refName); err != nil {
+			if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+				return nil, err
+			}
+
+			isDeleted, err := r.isDeletedReference(refName, skipAuthorizer)
+			if err != nil {
+				return nil, err
+			}
+			if !isDeleted {
+				slog.Debug(fmt.Sprintf("Reference '%s' does not exist locally, skipping...", refName))
+				result.Skipped = true
+				results = append(results, result)
+				continue
+			}
+
+			slog.Debug(fmt.Sprintf("Reference '%s' was deleted, verifying its deletion...", refName))
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", refName))
+		var expectedTip gitinterface.Hash
+		if options.LatestOnly {
+			expectedTip, err = verifier.VerifyRef(ctx, refName)
+		} else {
+			expectedTip, err = verifier.VerifyRefFull(ctx, refName)
+		}
+		if err == nil {
+			err = r.verifyRefTip(refName, expectedTip)
+		}
+
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Verification failed for '%s': %s", refName, err.Error()))
+			result.Err = err
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	slog.Debug("Verifying RSL is up to date using heartbeats...")
+	var heartbeatErr error
+	if err := verifier.VerifyHeartbeat(ctx, time.Now()); err != nil {
+		isStale := errors.Is(err, policy.ErrHeartbeatStale) || errors.Is(err, policy.ErrHeartbeatNotFound)
+		if !isStale || !options.WarnOnStaleHeartbeat {
+			heartbeatErr = err
+		} else {
+			slog.Warn("RSL may be outdated", "error", err)
+		}
+	}
+
+	if failed {
+		return results, errors.Join(ErrVerifyAllFailed, heartbeatErr)
+	}
+	if heartbeatErr != nil {
+		return results, heartbeatErr
+	}
+
+	slog.Debug("Verification successful!")
+	return results, nil
+}
+
+// isDeletedReference indicates if the latest unskipped RSL entry for refName
+// records the deletion of the reference. Skip annotations are only honored if
+// skipAuthorizer permits them, nil honors all of them.
+func (r *Repository) isDeletedReference(refName string, skipAuthorizer rsl.SkipAuthorizer) (bool, error) {
+	latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(r.r, rsl.ForReference(refName), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(skipAuthorizer))
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return latestEntry.GetTargetID().IsZero(), nil
+}
+
+// getProtectedReferences returns the local references protected by a Git
+// namespace rule in the current policy, along with the protected references
+// in changedRefNames whose latest RSL entries record their deletion. When
+// onlyChanged is set, the references are further restricted to those in
+// changedRefNames.
+func (r *Repository) getProtectedReferences(ctx context.Context, changedRefNames []string, onlyChanged bool, skipAuthorizer rsl.SkipAuthorizer) ([]string, error) {
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying local references protected by policy...")
+	localRefNames, err := r.r.ListReferences("")
+	if err != nil {
+		return nil, err
+	}
+
+	protectedRefNames := []string{}
+	for _, refName := range localRefNames {
+		if strings.HasPrefix(refName, "refs/gittuf/") || strings.HasPrefix(refName, gitinterface.RemoteRefPrefix) {
+			continue
+		}
+		if onlyChanged && !slices.Contains(changedRefNames, refName) {
+			continue
+		}
+
+		isProtected, err := state.IsProtectedReference(refName)
+		if err != nil {
+			return nil, err
+		}
+		if isProtected {
+			protectedRefNames = append(protectedRefNames, refName)
+		}
+	}
+
+	slog.Debug("Identifying deleted references protected by policy...")
+	for _, refName := range changedRefNames {
+		if strings.HasPrefix(refName, "refs/gittuf/") || slices.Contains(localRefNames, refName) {
+			continue
+		}
+
+		isProtected, err := state.IsProtectedReference(refName)
+		if err != nil {
+			return nil, err
+		}
+		if !isProtected {
+			continue
+		}
+
+		isDeleted, err := r.isDeletedReference(refName, skipAuthorizer)
+		if err != nil {
+			return nil, err
+		}
+		if isDeleted {
+			protectedRefNames = append(protectedRefNames, refName)
+		}
+	}
+	slices.Sort(protectedRefNames)
+
+	return protectedRefNames, nil
+}
+
 // VerifyMergeable checks if the targetRef can be updated to reflect the changes
 // in featureRef. It checks if sufficient authorizations / approvals exist for
 // the merge to happen, indicated by the error being nil. Additionally, a
@@ -205,10 +436,15 @@ func (r *Repository) VerifyNetwork(ctx context.Context) error {
 }
 
 // verifyRefTip inspects the specified reference in the local repository to
-// check if it points to the expected Git object.
+// check if it points to the expected Git object. If the expected object is the
+// zero hash, the reference must not exist.
 func (r *Repository) verifyRefTip(target string, expectedTip gitinterface.Hash) error {
 	refTip, err := r.r.GetReference(target)
 	if err != nil {
+		if errors.Is(err, gitinterface.ErrReferenceNotFound) && expectedTip.IsZero() {
+			// The RSL records that the reference was deleted
+			return nil
+		}
 		return err
 	}
 
@@ -6,18 +6,24 @@ package gittuf
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	attestopts "github.com/gittuf/gittuf/experimental/gittuf/options/attest"
 	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
 	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	verifyallopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifyall"
 	verifymergeableopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifymergeable"
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVerifyRef(t *testing.T) {
@@ -57,6 +63,85 @@ func TestVerifyRefRecordedWithGitSigning(t *testing.T) {
 	}
 }
 
+func TestVerifyRefDeleted(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	if err := repo.r.DeleteReference(refName); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ref is deleted but the RSL doesn't record it
+	err := repo.VerifyRef(testCtx, refName)
+	assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+
+	entry = rsl.NewReferenceEntry(refName, repo.r.ZeroHash())
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err = repo.VerifyRef(testCtx, refName)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, "main", verifyopts.WithLatestOnly())
+	assert.Nil(t, err)
+
+	// The ref is recreated but the RSL doesn't record it
+	common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+	err = repo.VerifyRef(testCtx, refName)
+	assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+}
+
+func TestVerifyRefWithHeartbeat(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	if err := repo.SetHeartbeat(testCtx, rootSigner, []tuf.Principal{gpgKey}, time.Hour, false, trustpolicyopts.WithRSLEntry()); err != nil {
+		t.Fatal(err)
+	}
+	if err := policy.Apply(testCtx, repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	err = repo.VerifyRef(testCtx, refName)
+	assert.ErrorIs(t, err, policy.ErrHeartbeatNotFound)
+
+	err = repo.VerifyRef(testCtx, refName, verifyopts.WithWarnOnStaleHeartbeat())
+	assert.Nil(t, err)
+
+	if err := rsl.NewHeartbeatEntry(time.Now().Add(-2*time.Hour)).CommitUsingSpecificKey(repo.r, gpgKeyBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyRef(testCtx, refName, verifyopts.WithLatestOnly())
+	assert.ErrorIs(t, err, policy.ErrHeartbeatStale)
+
+	err = repo.VerifyRef(testCtx, refName, verifyopts.WithLatestOnly(), verifyopts.WithWarnOnStaleHeartbeat())
+	assert.Nil(t, err)
+
+	if err := rsl.NewHeartbeatEntry(time.Now()).CommitUsingSpecificKey(repo.r, gpgKeyBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyRef(testCtx, refName)
+	assert.Nil(t, err)
+}
+
 func testVerifyRef(t *testing.T, objectFormat gitinterface.ObjectFormat) {
 	t.Helper()
 
@@ -247,6 +332,143 @@ func testVerifyRefFromEntry(t *testing.T, objectFormat gitinterface.ObjectFormat
 	assert.ErrorIs(t, err, policy.ErrVerificationFailed)
 }
 
+func TestVerifyAll(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+	remoteOnlyRef := "refs/heads/remote-only"
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+	entry := rsl.NewReferenceEntry(mainRef, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sinceEntryID := latestEntry.GetID()
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, featureRef, 1, gpgUnauthorizedKeyBytes)
+	entry = rsl.NewReferenceEntry(featureRef, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	// This ref is recorded in the RSL but doesn't exist locally
+	entry = rsl.NewReferenceEntry(remoteOnlyRef, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	t.Run("all refs in RSL", func(t *testing.T) {
+		results, err := repo.VerifyAll(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{
+			{RefName: featureRef},
+			{RefName: mainRef},
+			{RefName: remoteOnlyRef, Skipped: true},
+		}, results)
+
+		results, err = repo.VerifyAll(testCtx, verifyallopts.WithLatestOnly())
+		assert.Nil(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	t.Run("protected refs only", func(t *testing.T) {
+		results, err := repo.VerifyAll(testCtx, verifyallopts.WithProtectedOnly())
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef}}, results)
+	})
+
+	t.Run("changed since entry", func(t *testing.T) {
+		results, err := repo.VerifyAll(testCtx, verifyallopts.WithChangedSinceEntry(sinceEntryID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{
+			{RefName: featureRef},
+			{RefName: remoteOnlyRef, Skipped: true},
+		}, results)
+
+		results, err = repo.VerifyAll(testCtx, verifyallopts.WithChangedSinceEntry(sinceEntryID.String()), verifyallopts.WithProtectedOnly())
+		assert.Nil(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("violation in protected ref", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(mainRef, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		results, err := repo.VerifyAll(testCtx)
+		assert.ErrorIs(t, err, ErrVerifyAllFailed)
+		assert.Len(t, results, 3)
+		assert.Nil(t, results[0].Err)
+		assert.Equal(t, mainRef, results[1].RefName)
+		assert.ErrorIs(t, results[1].Err, policy.ErrVerificationFailed)
+	})
+
+	t.Run("deleted protected ref", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(mainRef, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		require.Nil(t, repo.r.DeleteReference(mainRef))
+		entry = rsl.NewReferenceEntry(mainRef, gitinterface.ZeroHash)
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		results, err := repo.VerifyAll(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef}}, results)
+
+		results, err = repo.VerifyAll(testCtx, verifyallopts.WithProtectedOnly())
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef}}, results)
+
+		// Recreate and delete main without authorization
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(mainRef, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		require.Nil(t, repo.r.DeleteReference(mainRef))
+		entry = rsl.NewReferenceEntry(mainRef, gitinterface.ZeroHash)
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		results, err = repo.VerifyAll(testCtx, verifyallopts.WithLatestOnly())
+		assert.ErrorIs(t, err, ErrVerifyAllFailed)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Skipped)
+		assert.ErrorIs(t, results[0].Err, policy.ErrVerificationFailed)
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+		gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+		require.Nil(t, err)
+		gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+		require.Nil(t, repo.SetHeartbeat(testCtx, rootSigner, []tuf.Principal{gpgKey}, time.Hour, false, trustpolicyopts.WithRSLEntry()))
+		require.Nil(t, policy.Apply(testCtx, repo.r, false))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, mainRef, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(mainRef, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		results, err := repo.VerifyAll(testCtx)
+		assert.ErrorIs(t, err, policy.ErrHeartbeatNotFound)
+		assert.NotErrorIs(t, err, ErrVerifyAllFailed)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef}}, results)
+
+		results, err = repo.VerifyAll(testCtx, verifyallopts.WithWarnOnStaleHeartbeat())
+		assert.Nil(t, err)
+		assert.Equal(t, []RefVerificationResult{{RefName: mainRef}}, results)
+
+		require.Nil(t, rsl.NewHeartbeatEntry(time.Now()).CommitUsingSpecificKey(repo.r, gpgKeyBytes))
+
+		_, err = repo.VerifyAll(testCtx)
+		assert.Nil(t, err)
+	})
+}
+
 func TestVerifyMergeable(t *testing.T) {
 	targetRef := "refs/heads/main"
 	featureRef := "refs/heads/feature"
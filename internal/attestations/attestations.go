@@ -25,6 +25,8 @@ const (
 	codeReviewApprovalAttestationsTreeEntryName = "code-review-approvals"
 	codeReviewApprovalIndexTreeEntryName        = "review-index.json"
 
+	pushCertificatesTreeEntryName = "push-certificates"
+
 	initialCommitMessage = "Initial commit"
 	defaultCommitMessage = "Update attestations"
 )
@@ -70,6 +72,14 @@ type Attestations struct {
 	// attestations namespace as a special blob in the
 	// codeReviewApprovalAttestations tree.
 	codeReviewApprovalIndex map[string]string
+
+	// pushCertificates maps each reference update recorded in a Git push
+	// certificate to the blob ID of the certificate. The key is a path of the
+	// form `<ref-path>/<from-id>-<to-id>`, where `ref-path` is the absolute ref
+	// path such as `refs/heads/main` and `from-id` and `to-id` are the IDs the
+	// ref was moved from and to. As a push certificate can record several
+	// reference updates, more than one key may point to the same blob.
+	pushCertificates map[string]gitinterface.Hash
 }
 
 // LoadCurrentAttestations inspects the repository's attestations namespace and
@@ -116,6 +126,7 @@ func LoadAttestationsForEntry(repo *gitinterface.Repository, entry rsl.Reference
 		githubPullRequestAttestations:  map[string]gitinterface.Hash{},
 		codeReviewApprovalAttestations: map[string]gitinterface.Hash{},
 		codeReviewApprovalIndex:        map[string]string{},
+		pushCertificates:               map[string]gitinterface.Hash{},
 	}
 
 	for name, blobID := range treeContents {
@@ -126,6 +137,8 @@ func LoadAttestationsForEntry(repo *gitinterface.Repository, entry rsl.Reference
 			attestations.githubPullRequestAttestations[strings.TrimPrefix(name, githubPullRequestAttestationsTreeEntryName+"/")] = blobID
 		case strings.HasPrefix(name, codeReviewApprovalAttestationsTreeEntryName+"/"):
 			attestations.codeReviewApprovalAttestations[strings.TrimPrefix(name, codeReviewApprovalAttestationsTreeEntryName+"/")] = blobID
+		case strings.HasPrefix(name, pushCertificatesTreeEntryName+"/"):
+			attestations.pushCertificates[strings.TrimPrefix(name, pushCertificatesTreeEntryName+"/")] = blobID
 		}
 	}
 
@@ -179,6 +192,9 @@ func (a *Attestations) Commit(repo *gitinterface.Repository, commitMessage strin
 	for name, blobID := range a.codeReviewApprovalAttestations {
 		allAttestations = append(allAttestations, gitinterface.NewEntryBlob(path.Join(codeReviewApprovalAttestationsTreeEntryName, name), blobID))
 	}
+	for name, blobID := range a.pushCertificates {
+		allAttestations = append(allAttestations, gitinterface.NewEntryBlob(path.Join(pushCertificatesTreeEntryName, name), blobID))
+	}
 
 	attestationsTreeID, err := treeBuilder.WriteTreeFromEntries(allAttestations)
 	if err != nil {
@@ -0,0 +1,79 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var ErrPushCertificateNotFound = errors.New("requested push certificate not found")
+
+// SetPushCertificate writes the push certificate to the object store and tracks
+// it in the current attestations state for each reference update recorded in
+// the certificate.
+func (a *Attestations) SetPushCertificate(repo *gitinterface.Repository, certificateBytes []byte) error {
+	certificate, err := gitinterface.ParsePushCertificate(certificateBytes)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := repo.WriteBlob(certificateBytes)
+	if err != nil {
+		return err
+	}
+
+	if a.pushCertificates == nil {
+		a.pushCertificates = map[string]gitinterface.Hash{}
+	}
+
+	for _, update := range certificate.Updates {
+		a.pushCertificates[PushCertificatePath(update.RefName, update.OldID.String(), update.NewID.String())] = blobID
+	}
+
+	return nil
+}
+
+// GetPushCertificateFor returns the push certificate that records the update of
+// refName from fromID to toID. The certificate's signature is not verified.
+func (a *Attestations) GetPushCertificateFor(repo *gitinterface.Repository, refName, fromID, toID string) (*gitinterface.PushCertificate, error) {
+	blobID, has := a.pushCertificates[PushCertificatePath(refName, fromID, toID)]
+	if !has {
+		return nil, ErrPushCertificateNotFound
+	}
+
+	certificateBytes, err := repo.ReadBlob(blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := gitinterface.ParsePushCertificate(certificateBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	fromHash, err := gitinterface.NewHash(fromID)
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := gitinterface.NewHash(toID)
+	if err != nil {
+		return nil, err
+	}
+	if !certificate.HasUpdate(refName, fromHash, toHash) {
+		// The certificate is stored at the wrong path
+		return nil, fmt.Errorf("%w: certificate does not record update of '%s' from '%s' to '%s'", gitinterface.ErrInvalidPushCertificate, refName, fromID, toID)
+	}
+
+	return certificate, nil
+}
+
+// PushCertificatePath constructs the expected path on-disk for the push
+// certificate that records the update of refName from fromID to toID.
+func PushCertificatePath(refName, fromID, toID string) string {
+	return path.Join(refName, fmt.Sprintf("%s-%s", fromID, toID))
+}
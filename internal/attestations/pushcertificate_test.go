@@ -0,0 +1,77 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPushCertificate(t *testing.T) {
+	t.Parallel()
+
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testID := gitinterface.ZeroHash.String()
+
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	certificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{
+		{RefName: testRef, OldID: gitinterface.ZeroHash, NewID: gitinterface.ZeroHash},
+		{RefName: testAnotherRef, OldID: gitinterface.ZeroHash, NewID: gitinterface.ZeroHash},
+	}, artifacts.SSHED25519Private)
+
+	attestations := &Attestations{}
+
+	err := attestations.SetPushCertificate(repo, certificate)
+	assert.Nil(t, err)
+	assert.Contains(t, attestations.pushCertificates, PushCertificatePath(testRef, testID, testID))
+	assert.Contains(t, attestations.pushCertificates, PushCertificatePath(testAnotherRef, testID, testID))
+	assert.Equal(t, attestations.pushCertificates[PushCertificatePath(testRef, testID, testID)], attestations.pushCertificates[PushCertificatePath(testAnotherRef, testID, testID)])
+
+	err = attestations.SetPushCertificate(repo, []byte("not a push certificate"))
+	assert.ErrorIs(t, err, gitinterface.ErrInvalidPushCertificate)
+}
+
+func TestGetPushCertificateFor(t *testing.T) {
+	t.Parallel()
+
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testID := gitinterface.ZeroHash.String()
+
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	certificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{
+		{RefName: testRef, OldID: gitinterface.ZeroHash, NewID: gitinterface.ZeroHash},
+	}, artifacts.SSHED25519Private)
+
+	attestations := &Attestations{}
+	err := attestations.SetPushCertificate(repo, certificate)
+	require.Nil(t, err)
+
+	err = attestations.Commit(repo, "Test commit", true, false)
+	require.Nil(t, err)
+
+	attestations, err = LoadCurrentAttestations(repo)
+	require.Nil(t, err)
+
+	pushCertificate, err := attestations.GetPushCertificateFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	assert.True(t, pushCertificate.HasUpdate(testRef, gitinterface.ZeroHash, gitinterface.ZeroHash))
+
+	_, err = attestations.GetPushCertificateFor(repo, testAnotherRef, testID, testID)
+	assert.ErrorIs(t, err, ErrPushCertificateNotFound)
+
+	// Store the certificate at a path it doesn't record
+	attestations.pushCertificates[PushCertificatePath(testAnotherRef, testID, testID)] = attestations.pushCertificates[PushCertificatePath(testRef, testID, testID)]
+	_, err = attestations.GetPushCertificateFor(repo, testAnotherRef, testID, testID)
+	assert.ErrorIs(t, err, gitinterface.ErrInvalidPushCertificate)
+}
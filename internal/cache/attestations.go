@@ -40,6 +40,21 @@ func (p *Persistent) FindAttestationsEntryNumberForEntry(entryNumber uint64) (RS
 	return p.AttestationEntries[index-1], false
 }
 
+// FindAttestationsEntriesInRange returns the indices of the attestations
+// entries numbered between firstNumber and lastNumber, both included.
+func (p *Persistent) FindAttestationsEntriesInRange(firstNumber, lastNumber uint64) []RSLEntryIndex {
+	firstIndex, _ := slices.BinarySearchFunc(p.AttestationEntries, RSLEntryIndex{EntryNumber: firstNumber}, binarySearch)
+
+	lastIndex, has := slices.BinarySearchFunc(p.AttestationEntries, RSLEntryIndex{EntryNumber: lastNumber}, binarySearch)
+	if has {
+		// When has, lastIndex is an entry we want to return, so we increment
+		// lastIndex to ensure the corresponding entry is included in the return
+		lastIndex++
+	}
+
+	return p.AttestationEntries[firstIndex:lastIndex]
+}
+
 func (p *Persistent) InsertAttestationEntryNumber(entryNumber uint64, entryID gitinterface.Hash) {
 	if entryNumber == 0 {
 		// For now, we don't have a way to track non-numbered entries
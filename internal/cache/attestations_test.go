@@ -60,6 +60,43 @@ func TestFindAttestationsEntryNumberForEntry(t *testing.T) {
 	})
 }
 
+func TestFindAttestationsEntriesInRange(t *testing.T) {
+	t.Run("no entries", func(t *testing.T) {
+		p := &Persistent{
+			AttestationEntries: []RSLEntryIndex{},
+		}
+
+		indices := p.FindAttestationsEntriesInRange(uint64(1), uint64(3))
+		assert.Empty(t, indices)
+	})
+
+	t.Run("entries exist in range", func(t *testing.T) {
+		p := &Persistent{
+			AttestationEntries: []RSLEntryIndex{
+				{EntryNumber: 1, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+				{EntryNumber: 3, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c0980"},
+				{EntryNumber: 4, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c9080"},
+				{EntryNumber: 6, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c1234"},
+			},
+		}
+
+		indices := p.FindAttestationsEntriesInRange(uint64(3), uint64(4)) // checking range with both limits in bound
+		assert.Equal(t, []RSLEntryIndex{
+			{EntryNumber: 3, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c0980"},
+			{EntryNumber: 4, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c9080"},
+		}, indices)
+
+		indices = p.FindAttestationsEntriesInRange(uint64(2), uint64(5)) // checking range with limits not in cache
+		assert.Equal(t, []RSLEntryIndex{
+			{EntryNumber: 3, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c0980"},
+			{EntryNumber: 4, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c9080"},
+		}, indices)
+
+		indices = p.FindAttestationsEntriesInRange(uint64(7), uint64(9)) // checking range after all entries
+		assert.Empty(t, indices)
+	})
+}
+
 func TestInsertAttestationEntryNumber(t *testing.T) {
 	t.Run("zero entry", func(t *testing.T) {
 		p := &Persistent{
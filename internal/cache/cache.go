@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"sync"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/rsl"
@@ -46,15 +47,33 @@ type Persistent struct {
 	// LastVerifiedEntryForRef is a map that indicates the last verified RSL
 	// entry for a ref.
 	LastVerifiedEntryForRef map[string]RSLEntryIndex `json:"lastVerifiedEntryForRef"`
+
+	// GitSignatures maps Git object IDs to the results of verifying their
+	// signatures, keyed by the ID of the key used.
+	GitSignatures map[string]map[string]SignatureResult `json:"gitSignatures,omitempty"`
+
+	// EnvelopeSignatures maps the digests of DSSE envelopes to the results of
+	// verifying their signatures, keyed by the ID of the key used.
+	EnvelopeSignatures map[string]map[string]SignatureResult `json:"envelopeSignatures,omitempty"`
+
+	// RSLIndex is an index of the RSL used to look up entries without walking
+	// the RSL. It is updated as new entries are added to the RSL.
+	RSLIndex *rsl.Index `json:"rslIndex,omitempty"`
+
+	// signaturesMu protects the signature results as signatures may be
+	// verified concurrently.
+	signaturesMu sync.Mutex
 }
 
 func (p *Persistent) Commit(repo *gitinterface.Repository) error {
-	if len(p.PolicyEntries) == 0 && len(p.AttestationEntries) == 0 && p.AddedAttestationsBeforeNumber == 0 && len(p.LastVerifiedEntryForRef) == 0 {
+	if len(p.PolicyEntries) == 0 && len(p.AttestationEntries) == 0 && p.AddedAttestationsBeforeNumber == 0 && len(p.LastVerifiedEntryForRef) == 0 && len(p.GitSignatures) == 0 && len(p.EnvelopeSignatures) == 0 && p.RSLIndex == nil {
 		// nothing to do
 		return nil
 	}
 
+	p.signaturesMu.Lock()
 	contents, err := json.Marshal(p)
+	p.signaturesMu.Unlock()
 	if err != nil {
 		return err
 	}
@@ -84,8 +103,8 @@ func (p *Persistent) Commit(repo *gitinterface.Repository) error {
 }
 
 // PopulatePersistentCache scans the repository's RSL and generates a persistent
-// local-only cache of policy and attestation entries. This makes subsequent
-// verifications faster.
+// local-only cache of policy and attestation entries as well as an index of the
+// RSL. This makes subsequent verifications faster.
 func PopulatePersistentCache(repo *gitinterface.Repository) error {
 	persistent := &Persistent{
 		PolicyEntries:      []RSLEntryIndex{},
@@ -127,12 +146,18 @@ func PopulatePersistentCache(repo *gitinterface.Repository) error {
 		}
 	}
 
+	persistent.RSLIndex = rsl.NewIndex()
+	if err := persistent.RSLIndex.Update(repo); err != nil {
+		return err
+	}
+
 	return persistent.Commit(repo)
 }
 
 // LoadPersistentCache loads the persistent cache from the tip of the local ref.
 // If an instance has already been loaded and a pointer has been stored in
-// memory, that instance is returned.
+// memory, that instance is returned. If the cache includes an index of the RSL,
+// it is used for subsequent RSL lookups in the repository.
 func LoadPersistentCache(repo *gitinterface.Repository) (*Persistent, error) {
 	slog.Debug("Loading persistent cache from disk...")
 
@@ -175,6 +200,10 @@ func LoadPersistentCache(repo *gitinterface.Repository) (*Persistent, error) {
 		return nil, err
 	}
 
+	if persistentCache.RSLIndex != nil {
+		rsl.UseIndex(repo, persistentCache.RSLIndex)
+	}
+
 	slog.Debug("Loaded persistent cache")
 	return persistentCache, nil
 }
@@ -198,6 +227,8 @@ func DeletePersistentCache(repo *gitinterface.Repository) error {
 		return err
 	}
 
+	rsl.UseIndex(repo, nil)
+
 	return nil
 }
 
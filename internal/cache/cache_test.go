@@ -155,12 +155,16 @@ func TestPopulatePersistentCache(t *testing.T) {
 		err = PopulatePersistentCache(repo)
 		assert.Nil(t, err)
 
+		expectedIndex := rsl.NewIndex()
+		require.Nil(t, expectedIndex.Update(repo))
+
 		sampleCache := Persistent{
 			PolicyEntries: []RSLEntryIndex{
 				{EntryNumber: 1, EntryID: "a2f603abf945588e8ad0d6b1f71a37bdcaf87e13"},
 			},
 			AttestationEntries:            []RSLEntryIndex{},
 			AddedAttestationsBeforeNumber: 1,
+			RSLIndex:                      expectedIndex,
 		}
 		cache, err := LoadPersistentCache(repo)
 		require.Nil(t, err)
@@ -202,12 +206,16 @@ func TestLoadPersistantCache(t *testing.T) {
 		require.Nil(t, err)
 		assert.NotNil(t, persistentCache) // the cache should be loaded successfully if the persistentCache exists
 
+		expectedIndex := rsl.NewIndex()
+		require.Nil(t, expectedIndex.Update(repo))
+
 		sampleCache := Persistent{
 			PolicyEntries: []RSLEntryIndex{
 				{EntryNumber: 1, EntryID: "a2f603abf945588e8ad0d6b1f71a37bdcaf87e13"},
 			},
 			AttestationEntries:            []RSLEntryIndex{},
 			AddedAttestationsBeforeNumber: 1,
+			RSLIndex:                      expectedIndex,
 		}
 		assert.Equal(t, &sampleCache, persistentCache)
 	})
@@ -229,12 +237,16 @@ func TestLoadPersistantCache(t *testing.T) {
 		require.Nil(t, err)
 		assert.NotNil(t, persistentCache)
 
+		expectedIndex := rsl.NewIndex()
+		require.Nil(t, expectedIndex.Update(repo))
+
 		sampleCache := Persistent{
 			PolicyEntries: []RSLEntryIndex{},
 			AttestationEntries: []RSLEntryIndex{
 				{EntryNumber: 1, EntryID: "4f6fd1c67daa2acf4f2dd8626ddd8d6a51fcd026"},
 			},
 			AddedAttestationsBeforeNumber: 1,
+			RSLIndex:                      expectedIndex,
 		}
 		assert.Equal(t, &sampleCache, persistentCache)
 	})
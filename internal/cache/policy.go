@@ -63,7 +63,7 @@ func (p *Persistent) FindPolicyEntriesInRange(firstNumber, lastNumber uint64) ([
 	}
 
 	firstIndex, has := slices.BinarySearchFunc(p.PolicyEntries, RSLEntryIndex{EntryNumber: firstNumber}, binarySearch)
-	if !has {
+	if !has && firstIndex != 0 {
 		// When !has, index is point of insertion, but we want the applicable
 		// entry which is index-1
 		firstIndex--
@@ -135,6 +135,13 @@ func TestFindPolicyEntriesInRange(t *testing.T) {
 			{EntryNumber: 3, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c0980"},
 			{EntryNumber: 4, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c9080"},
 		}, indices)
+
+		indices, err = p.FindPolicyEntriesInRange(uint64(0), uint64(2)) // checking range with first number before all entries
+		require.Nil(t, err)
+		require.Equal(t, []RSLEntryIndex{
+			{EntryNumber: 1, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+			{EntryNumber: 2, EntryID: "e69de29bb2d1d6434b8b29ae775ad8c2e48c1234"},
+		}, indices)
 	})
 }
 
@@ -0,0 +1,136 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+)
+
+// SignatureResult records the outcome of verifying a signature using a specific
+// key. The result is bound to the key's full definition via KeyFingerprint:
+// if a policy change alters the key trusted for a key ID, the cached result no
+// longer applies and the signature is verified again.
+type SignatureResult struct {
+	KeyFingerprint string `json:"keyFingerprint"`
+	Verified       bool   `json:"verified"`
+}
+
+// GetGitSignatureResult returns the cached result of verifying the signature
+// of the specified Git object using the key. The second return value indicates
+// if a valid cached result was found.
+func (p *Persistent) GetGitSignatureResult(objectID gitinterface.Hash, key *signerverifier.SSLibKey) (bool, bool) {
+	p.signaturesMu.Lock()
+	defer p.signaturesMu.Unlock()
+
+	return getSignatureResult(p.GitSignatures, objectID.String(), key)
+}
+
+// SetGitSignatureResult records the result of verifying the signature of the
+// specified Git object using the key.
+func (p *Persistent) SetGitSignatureResult(objectID gitinterface.Hash, key *signerverifier.SSLibKey, verified bool) {
+	p.signaturesMu.Lock()
+	defer p.signaturesMu.Unlock()
+
+	if p.GitSignatures == nil {
+		p.GitSignatures = map[string]map[string]SignatureResult{}
+	}
+	setSignatureResult(p.GitSignatures, objectID.String(), key, verified)
+}
+
+// VerifyGitSignature verifies the signature of the specified Git object using
+// the key. A previously recorded result is used when available, and new
+// results are recorded.
+func (p *Persistent) VerifyGitSignature(ctx context.Context, repo *gitinterface.Repository, objectID gitinterface.Hash, key *signerverifier.SSLibKey) error {
+	if verified, has := p.GetGitSignatureResult(objectID, key); has {
+		slog.Debug(fmt.Sprintf("Using cached result of verifying signature of Git object '%s' with public key '%s'...", objectID.String(), key.KeyID))
+		if verified {
+			return nil
+		}
+		return gitinterface.ErrIncorrectVerificationKey
+	}
+
+	err := repo.VerifySignature(ctx, objectID, key)
+	switch {
+	case err == nil:
+		p.SetGitSignatureResult(objectID, key, true)
+	case errors.Is(err, gitinterface.ErrIncorrectVerificationKey):
+		p.SetGitSignatureResult(objectID, key, false)
+	}
+
+	return err
+}
+
+// GetEnvelopeSignatureResult returns the cached result of verifying the
+// signatures of the DSSE envelope identified by its digest using the key. The
+// second return value indicates if a valid cached result was found.
+func (p *Persistent) GetEnvelopeSignatureResult(envelopeDigest string, key *signerverifier.SSLibKey) (bool, bool) {
+	p.signaturesMu.Lock()
+	defer p.signaturesMu.Unlock()
+
+	return getSignatureResult(p.EnvelopeSignatures, envelopeDigest, key)
+}
+
+// SetEnvelopeSignatureResult records the result of verifying the signatures of
+// the DSSE envelope identified by its digest using the key.
+func (p *Persistent) SetEnvelopeSignatureResult(envelopeDigest string, key *signerverifier.SSLibKey, verified bool) {
+	p.signaturesMu.Lock()
+	defer p.signaturesMu.Unlock()
+
+	if p.EnvelopeSignatures == nil {
+		p.EnvelopeSignatures = map[string]map[string]SignatureResult{}
+	}
+	setSignatureResult(p.EnvelopeSignatures, envelopeDigest, key, verified)
+}
+
+func getSignatureResult(results map[string]map[string]SignatureResult, objectID string, key *signerverifier.SSLibKey) (bool, bool) {
+	objectResults, has := results[objectID]
+	if !has {
+		return false, false
+	}
+
+	result, has := objectResults[key.KeyID]
+	if !has {
+		return false, false
+	}
+
+	fingerprint, err := keyFingerprint(key)
+	if err != nil || result.KeyFingerprint != fingerprint {
+		// The key trusted for this ID has changed, the result is stale
+		return false, false
+	}
+
+	return result.Verified, true
+}
+
+func setSignatureResult(results map[string]map[string]SignatureResult, objectID string, key *signerverifier.SSLibKey, verified bool) {
+	fingerprint, err := keyFingerprint(key)
+	if err != nil {
+		// Not caching the result is always safe
+		return
+	}
+
+	if _, has := results[objectID]; !has {
+		results[objectID] = map[string]SignatureResult{}
+	}
+	results[objectID][key.KeyID] = SignatureResult{KeyFingerprint: fingerprint, Verified: verified}
+}
+
+func keyFingerprint(key *signerverifier.SSLibKey) (string, error) {
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(keyBytes)
+	return hex.EncodeToString(digest[:]), nil
+}
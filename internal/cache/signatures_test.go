@@ -0,0 +1,104 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitSignatureResult(t *testing.T) {
+	objectID, _ := gitinterface.NewHash("e69de29bb2d1d6434b8b29ae775ad8c2e48c5391")
+	key := &signerverifier.SSLibKey{KeyID: "key", KeyType: "ssh", Scheme: "ssh-ed25519", KeyVal: signerverifier.KeyVal{Public: "public"}}
+
+	t.Run("no result", func(t *testing.T) {
+		p := &Persistent{}
+
+		_, has := p.GetGitSignatureResult(objectID, key)
+		assert.False(t, has)
+	})
+
+	t.Run("result recorded", func(t *testing.T) {
+		p := &Persistent{}
+
+		p.SetGitSignatureResult(objectID, key, true)
+		verified, has := p.GetGitSignatureResult(objectID, key)
+		assert.True(t, has)
+		assert.True(t, verified)
+
+		p.SetGitSignatureResult(objectID, key, false)
+		verified, has = p.GetGitSignatureResult(objectID, key)
+		assert.True(t, has)
+		assert.False(t, verified)
+	})
+
+	t.Run("key changed for key ID", func(t *testing.T) {
+		p := &Persistent{}
+
+		p.SetGitSignatureResult(objectID, key, true)
+
+		changedKey := &signerverifier.SSLibKey{KeyID: "key", KeyType: "ssh", Scheme: "ssh-ed25519", KeyVal: signerverifier.KeyVal{Public: "other-public"}}
+		_, has := p.GetGitSignatureResult(objectID, changedKey)
+		assert.False(t, has)
+	})
+}
+
+func TestVerifyGitSignature(t *testing.T) {
+	repo := gitinterface.CreateTestGitRepository(t, t.TempDir(), false)
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, artifacts.SSHED25519Private)
+	key := ssh.NewKeyFromBytes(t, artifacts.SSHED25519PublicSSH)
+	otherKey := ssh.NewKeyFromBytes(t, artifacts.SSHECDSAPublicSSH)
+
+	t.Run("results are recorded", func(t *testing.T) {
+		p := &Persistent{}
+
+		err := p.VerifyGitSignature(context.Background(), repo, commitIDs[0], key)
+		assert.Nil(t, err)
+		verified, has := p.GetGitSignatureResult(commitIDs[0], key)
+		assert.True(t, has)
+		assert.True(t, verified)
+
+		err = p.VerifyGitSignature(context.Background(), repo, commitIDs[0], otherKey)
+		assert.ErrorIs(t, err, gitinterface.ErrIncorrectVerificationKey)
+		verified, has = p.GetGitSignatureResult(commitIDs[0], otherKey)
+		assert.True(t, has)
+		assert.False(t, verified)
+	})
+
+	t.Run("recorded results are used", func(t *testing.T) {
+		p := &Persistent{}
+
+		p.SetGitSignatureResult(commitIDs[0], key, false)
+		err := p.VerifyGitSignature(context.Background(), repo, commitIDs[0], key)
+		assert.ErrorIs(t, err, gitinterface.ErrIncorrectVerificationKey)
+
+		p.SetGitSignatureResult(commitIDs[0], otherKey, true)
+		err = p.VerifyGitSignature(context.Background(), repo, commitIDs[0], otherKey)
+		assert.Nil(t, err)
+	})
+}
+
+func TestEnvelopeSignatureResult(t *testing.T) {
+	key := &signerverifier.SSLibKey{KeyID: "key", KeyType: "ssh", Scheme: "ssh-ed25519", KeyVal: signerverifier.KeyVal{Public: "public"}}
+
+	p := &Persistent{}
+
+	_, has := p.GetEnvelopeSignatureResult("digest", key)
+	assert.False(t, has)
+
+	p.SetEnvelopeSignatureResult("digest", key, true)
+	verified, has := p.GetEnvelopeSignatureResult("digest", key)
+	assert.True(t, has)
+	assert.True(t, verified)
+
+	_, has = p.GetEnvelopeSignatureResult("other-digest", key)
+	assert.False(t, has)
+}
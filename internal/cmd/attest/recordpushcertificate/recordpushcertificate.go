@@ -0,0 +1,79 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordpushcertificate
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	attestopts "github.com/gittuf/gittuf/experimental/gittuf/options/attest"
+	"github.com/gittuf/gittuf/internal/cmd/attest/persistent"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/spf13/cobra"
+)
+
+// pushCertificateEnvKey is set by Git for the receiving repository's hooks to
+// the blob ID of the push certificate sent with a signed push.
+const pushCertificateEnvKey = "GIT_PUSH_CERT"
+
+var ErrNoPushCertificate = errors.New("push certificate path not specified and GIT_PUSH_CERT is not set")
+
+type options struct {
+	p *persistent.Options
+}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	var certificate []byte
+	if len(args) > 0 {
+		certificate, err = os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		blobIDString := os.Getenv(pushCertificateEnvKey)
+		if blobIDString == "" {
+			return ErrNoPushCertificate
+		}
+
+		blobID, err := gitinterface.NewHash(blobIDString)
+		if err != nil {
+			return err
+		}
+
+		certificate, err = repo.GetGitRepository().ReadBlob(blobID)
+		if err != nil {
+			return err
+		}
+	}
+
+	opts := []attestopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, attestopts.WithRSLEntry())
+	}
+
+	return repo.AddPushCertificate(certificate, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "record-push-certificate [<path>]",
+		Short:             "Record a Git push certificate",
+		Long:              "The 'record-push-certificate' command records a Git push certificate, created using 'git push --signed', in the repository's attestations. If a path is not specified, the certificate is read from the object identified by the GIT_PUSH_CERT environment variable, which Git sets for the hooks of the receiving repository. A push certificate signed by a principal authorized to update a reference is accepted as evidence for the RSL entry that records the update, even if the entry is created by someone else, such as a server. The certificate's pushee must match the repository location set in the root of trust, so certificates issued for pushes to other repositories are not accepted.",
+		Args:              cobra.MaximumNArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
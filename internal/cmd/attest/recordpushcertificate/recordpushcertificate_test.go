@@ -0,0 +1,97 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordpushcertificate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/cmd/attest/persistent"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordPushCertificate(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{}), "push-certificate")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("no push certificate", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		t.Setenv(pushCertificateEnvKey, "")
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{}))
+		assert.ErrorIs(t, err, ErrNoPushCertificate)
+	})
+
+	t.Run("success with path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		certificatePath := filepath.Join(tmpDir, "push-certificate")
+		certificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: gitinterface.ZeroHash}}, artifacts.SSHED25519Private)
+		require.NoError(t, os.WriteFile(certificatePath, certificate, 0o600))
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{WithRSLEntry: true}), certificatePath)
+		require.NoError(t, err)
+
+		allAttestations, err := attestations.LoadCurrentAttestations(repo)
+		require.NoError(t, err)
+		_, err = allAttestations.GetPushCertificateFor(repo, refName, gitinterface.ZeroHash.String(), gitinterface.ZeroHash.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("success with GIT_PUSH_CERT", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		certificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: gitinterface.ZeroHash}}, artifacts.SSHED25519Private)
+		blobID, err := repo.WriteBlob(certificate)
+		require.NoError(t, err)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		t.Setenv(pushCertificateEnvKey, blobID.String())
+		_, _, _, err = cmd.ExecuteCommandC(New(&persistent.Options{WithRSLEntry: true}))
+		require.NoError(t, err)
+
+		allAttestations, err := attestations.LoadCurrentAttestations(repo)
+		require.NoError(t, err)
+		_, err = allAttestations.GetPushCertificateFor(repo, refName, gitinterface.ZeroHash.String(), gitinterface.ZeroHash.String())
+		assert.NoError(t, err)
+	})
+}
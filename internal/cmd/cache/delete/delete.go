@@ -26,7 +26,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "delete",
 		Short:             "Delete the local persistent cache",
-		Long:              "The 'delete' command deletes the local persistent cache used by gittuf. It is used to reclaim space or clear a stale cache. The cache must be reinitialized manually with 'gittuf cache init' before it can be used again.",
+		Long:              "The 'delete' command deletes the local persistent cache used by gittuf. This includes the recorded results of signature verifications. It is used to reclaim space or clear a stale cache. The cache must be reinitialized manually with 'gittuf cache init' before it can be used again.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -26,7 +26,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "init",
 		Short:             "Initialize persistent cache",
-		Long:              `The 'init' command initializes the local persistent cache for a gittuf repository, intended to improve performance of gittuf operations. This cache is local-only and is not synchronized with the remote.`,
+		Long:              `The 'init' command initializes the local persistent cache for a gittuf repository, intended to improve performance of gittuf operations. In addition to indexing the RSL, the cache records the results of signature verifications performed by gittuf so they are not repeated. Initializing the cache again discards previously recorded results. This cache is local-only and is not synchronized with the remote.`,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
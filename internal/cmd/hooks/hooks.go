@@ -0,0 +1,24 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hooks
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/hooks/postreceive"
+	"github.com/gittuf/gittuf/internal/cmd/hooks/prereceive"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "hooks",
+		Short:             "Tools to run gittuf in Git hooks",
+		Long:              "The 'hooks' subcommand provides commands that are invoked by Git hooks configured for the repository. For example, a Git server's pre-receive hook can run gittuf to verify pushes before they are accepted, and its post-receive hook can run gittuf to record pushes in the RSL as the forge.",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(postreceive.New())
+	cmd.AddCommand(prereceive.New())
+
+	return cmd
+}
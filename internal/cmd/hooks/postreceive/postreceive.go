@@ -0,0 +1,53 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postreceive
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	updates, err := gittuf.ReadReferenceUpdates(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	receipt, err := repo.RecordPushedReferenceUpdates(cmd.Context(), updates, true)
+	if err != nil {
+		return err
+	}
+	if receipt == nil {
+		return nil
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), gittuf.EncodePushReceipt(receipt))
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "post-receive",
+		Short:             "Record pushed references in the RSL as the forge in a post-receive hook",
+		Long:              "The 'post-receive' command is meant to be invoked by a Git server's post-receive hook when the server acts as the forge trusted in the repository's gittuf policy. It reads the reference updates of a push from stdin as '<old> <new> <ref>' lines and records the updates to references delegated to the forge in a single RSL entry, signed using the server's Git signing configuration. This avoids pushers racing to update the RSL. The entry is printed as a receipt prefixed with 'gittuf-receipt: ', which Git relays to the pusher. The gittuf transport stores the receipt automatically when pushing, otherwise the pusher can store it using 'gittuf rsl receipt store'. The pusher can later detect if the push was dropped using 'gittuf rsl receipt check'.",
+		Args:              cobra.ExactArgs(0),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
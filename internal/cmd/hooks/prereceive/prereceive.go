@@ -0,0 +1,64 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prereceive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	updates, err := gittuf.ReadReferenceUpdates(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	results, err := repo.VerifyPushedReferenceUpdates(cmd.Context(), updates)
+	if err != nil && !errors.Is(err, gittuf.ErrPushVerificationFailed) {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(stdOut, "%s: accepted (not protected by gittuf policy)\n", result.RefName)
+		case result.Err != nil:
+			fmt.Fprintf(stdOut, "%s: rejected (%s)\n", result.RefName, result.Err.Error())
+		case result.DelegatedToForge:
+			fmt.Fprintf(stdOut, "%s: accepted (recorded by forge)\n", result.RefName)
+		default:
+			fmt.Fprintf(stdOut, "%s: verified\n", result.RefName)
+		}
+	}
+
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "pre-receive",
+		Short:             "Verify pushed references against gittuf policy in a pre-receive hook",
+		Long:              "The 'pre-receive' command is meant to be invoked by a Git server's pre-receive hook. It reads the reference updates of a push from stdin as '<old> <new> <ref>' lines and verifies them against the repository's gittuf policy before they are applied, using the objects received in the push. The pushed RSL must extend the repository's RSL, and each updated reference protected by the policy must have an RSL entry for its new tip that meets the policy, unless the reference is delegated to the forge trusted in the policy. The forge records such updates using 'gittuf hooks post-receive', so they must only meet the policy's file rules. The result for each reference is printed, and the command fails if any reference is rejected, which causes Git to refuse the push. This protects clients that fetch from the server without gittuf.",
+		Args:              cobra.ExactArgs(0),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
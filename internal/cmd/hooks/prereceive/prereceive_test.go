@@ -0,0 +1,84 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prereceive
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreReceive(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		_, _, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("unexpected arguments", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		gitinterface.CreateTestGitRepository(t, tmpDir, true)
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "refs/heads/main")
+		assert.ErrorContains(t, err, "accepts 0 arg(s)")
+	})
+
+	t.Run("invalid reference update", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		gitinterface.CreateTestGitRepository(t, tmpDir, true)
+
+		preReceiveCmd := New()
+		preReceiveCmd.SetIn(strings.NewReader("refs/heads/main\n"))
+		_, _, _, err = cmd.ExecuteCommandC(preReceiveCmd)
+		assert.ErrorIs(t, err, gittuf.ErrInvalidReferenceUpdate)
+
+		preReceiveCmd = New()
+		preReceiveCmd.SetIn(strings.NewReader("invalid invalid refs/heads/main\n"))
+		_, _, _, err = cmd.ExecuteCommandC(preReceiveCmd)
+		assert.ErrorIs(t, err, gittuf.ErrInvalidReferenceUpdate)
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, true)
+		treeBuilder := gitinterface.NewTreeBuilder(repo)
+		emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.NoError(t, err)
+		commitID, err := repo.Commit(emptyTreeID, "refs/heads/feature", "Initial commit\n", false)
+		require.NoError(t, err)
+
+		preReceiveCmd := New()
+		preReceiveCmd.SetIn(strings.NewReader(fmt.Sprintf("%s %s refs/heads/main\n", repo.ZeroHash().String(), commitID.String())))
+		_, stdOut, _, err := cmd.ExecuteCommandC(preReceiveCmd)
+		assert.NoError(t, err)
+		assert.Equal(t, "refs/heads/main: accepted (not protected by gittuf policy)\n", stdOut.String())
+	})
+}
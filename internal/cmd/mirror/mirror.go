@@ -0,0 +1,66 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	results, err := repo.Mirror(cmd.Context(), args[0], args[1])
+	if err != nil && !errors.Is(err, gittuf.ErrMirrorVerificationFailed) {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	if len(results) == 0 && err == nil {
+		fmt.Fprintf(stdOut, "'%s' is up to date with '%s'\n", args[1], args[0])
+		return nil
+	}
+	// Nothing is mirrored if any reference fails verification
+	mirrored := "mirrored"
+	if err != nil {
+		mirrored = "not mirrored"
+	}
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(stdOut, "%s: skipped (not protected by gittuf policy), %s\n", result.RefName, mirrored)
+		case result.Err != nil:
+			fmt.Fprintf(stdOut, "%s: not mirrored (%s)\n", result.RefName, result.Err.Error())
+		default:
+			fmt.Fprintf(stdOut, "%s: verified and %s\n", result.RefName, mirrored)
+		}
+	}
+
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "mirror <source-remote> <destination-remote>",
+		Short:             "Mirror verified references from one remote to another",
+		Long:              "The 'mirror' command propagates the branches, tags, and gittuf references of the source remote to the destination remote. Each reference that differs between the remotes is verified against the source's RSL and gittuf policy before it is mirrored, The source's RSL must extend the destination's RSL and every protected reference must pass verification, otherwise nothing is mirrored. References not protected by the policy are mirrored without verification. All updates are pushed to the destination atomically, and the local repository's references are not modified.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
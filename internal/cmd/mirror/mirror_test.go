@@ -0,0 +1,53 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "source", "destination")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("missing destination remote argument", func(t *testing.T) {
+		_, _, _, err := cmd.ExecuteCommandC(New(), "source")
+		assert.ErrorContains(t, err, "accepts 2 arg(s)")
+	})
+
+	t.Run("source without RSL", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		currentDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(tmpDir))
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		sourceDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, sourceDir, true)
+		destinationDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, destinationDir, true)
+
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+		require.Nil(t, repo.AddRemote("source", sourceDir))
+		require.Nil(t, repo.AddRemote("destination", destinationDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "source", "destination")
+		assert.ErrorIs(t, err, gittuf.ErrSourceRSLNotFound)
+	})
+}
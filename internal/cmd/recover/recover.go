@@ -0,0 +1,155 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recover
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	recoveropts "github.com/gittuf/gittuf/experimental/gittuf/options/recover"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	revert             bool
+	skipInvalidEntries bool
+	dryRun             bool
+	remoteName         string
+	localOnly          bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.revert,
+		"revert",
+		false,
+		"create a new commit restoring the last valid state instead of resetting the reference",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.skipInvalidEntries,
+		"skip-invalid-entries",
+		false,
+		"record an annotation skipping the invalid RSL entries for the reference",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"display the recovery plan without making any changes",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote-name",
+		"",
+		"name of the remote to push the RSL entries to",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.localOnly,
+		"local-only",
+		false,
+		"perform this operation locally without pushing to a remote repository",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("remote-name", "local-only")
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+
+	plan, err := repo.PlanRecovery(cmd.Context(), args[0])
+	if err != nil {
+		if errors.Is(err, policy.ErrNothingToRecover) {
+			fmt.Fprintf(stdOut, "%s: verified, nothing to recover\n", args[0])
+			return nil
+		}
+		return err
+	}
+
+	o.printPlan(stdOut, plan)
+	if o.dryRun {
+		return nil
+	}
+
+	opts := []recoveropts.Option{recoveropts.WithRemote(o.remoteName)}
+	if o.revert {
+		opts = append(opts, recoveropts.WithRevert())
+	}
+	if o.skipInvalidEntries {
+		opts = append(opts, recoveropts.WithSkipInvalidEntries())
+	}
+	if o.localOnly {
+		opts = append(opts, recoveropts.WithLocalOnly())
+	}
+
+	if err := repo.Recover(cmd.Context(), plan, true, opts...); err != nil {
+		return err
+	}
+
+	// The reference may still fail verification, such as when the invalid
+	// entries are not skipped
+	if err := repo.VerifyRef(cmd.Context(), plan.RefName); err != nil {
+		fmt.Fprintf(stdOut, "%s: restored to last valid state, but verification still fails (%s)\n", plan.RefName, err.Error())
+		if !o.skipInvalidEntries && len(plan.EntriesToSkip) != 0 {
+			fmt.Fprintln(stdOut, "The invalid entries must be skipped, use --skip-invalid-entries")
+		}
+		return err
+	}
+
+	fmt.Fprintf(stdOut, "%s: recovered and verified\n", plan.RefName)
+	return nil
+}
+
+func (o *options) printPlan(w io.Writer, plan *policy.RecoveryPlan) {
+	fmt.Fprintf(w, "Reference: %s\n", plan.RefName)
+	fmt.Fprintf(w, "Invalid entry: %s (%s)\n", plan.InvalidEntry.GetID().String(), plan.VerificationErr.Error())
+	fmt.Fprintf(w, "Last valid entry: %s (target %s)\n", plan.LastGoodEntry.GetID().String(), plan.LastGoodEntry.GetTargetID().String())
+
+	if len(plan.EntriesToSkip) != 0 {
+		if o.skipInvalidEntries {
+			fmt.Fprintln(w, "Entries to skip:")
+		} else {
+			fmt.Fprintln(w, "Entries to skip (use --skip-invalid-entries to skip them):")
+		}
+		for _, entry := range plan.EntriesToSkip {
+			fmt.Fprintf(w, "  %s\n", entry.GetID().String())
+		}
+	}
+
+	lastGoodTargetID := plan.LastGoodEntry.GetTargetID()
+	switch {
+	case lastGoodTargetID.IsZero():
+		fmt.Fprintf(w, "Action: delete %s\n", plan.RefName)
+	case o.revert:
+		fmt.Fprintf(w, "Action: create a commit on %s restoring the tree of %s\n", plan.RefName, lastGoodTargetID.String())
+	default:
+		fmt.Fprintf(w, "Action: reset %s to %s\n", plan.RefName, lastGoodTargetID.String())
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "recover <ref>",
+		Short:             "Restore a Git reference to its last valid state",
+		Long:              "The 'recover' command restores a Git reference that fails verification to its last valid state in the RSL. The last valid state is identified the same way as during verification: it is the latest entry for the reference before the first invalid entry that has not been skipped. The recovery plan is displayed before any changes are made. By default, the reference is reset to its last valid state; with --revert, a new commit restoring that state is created instead. A signed RSL entry is recorded for the restored reference, and with --skip-invalid-entries, the invalid entries are skipped using an RSL annotation. Once recovered, the reference is verified again; if the invalid entries are not skipped, it continues to fail verification and the command returns an error. With --dry-run, only the plan is displayed. The reference must not be checked out.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
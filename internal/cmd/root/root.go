@@ -12,13 +12,18 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/attest"
 	"github.com/gittuf/gittuf/internal/cmd/cache"
 	"github.com/gittuf/gittuf/internal/cmd/clone"
+	"github.com/gittuf/gittuf/internal/cmd/hooks"
+	"github.com/gittuf/gittuf/internal/cmd/mirror"
 	"github.com/gittuf/gittuf/internal/cmd/policy"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/cmd/profile"
+	"github.com/gittuf/gittuf/internal/cmd/recover"
 	"github.com/gittuf/gittuf/internal/cmd/rsl"
+	"github.com/gittuf/gittuf/internal/cmd/serve"
 	"github.com/gittuf/gittuf/internal/cmd/sync"
 	"github.com/gittuf/gittuf/internal/cmd/trust"
 	"github.com/gittuf/gittuf/internal/cmd/tui"
+	"github.com/gittuf/gittuf/internal/cmd/verifyall"
 	"github.com/gittuf/gittuf/internal/cmd/verifymergeable"
 	"github.com/gittuf/gittuf/internal/cmd/verifynetwork"
 	"github.com/gittuf/gittuf/internal/cmd/verifyref"
@@ -115,10 +120,15 @@ func New() *cobra.Command {
 	cmd.AddCommand(attest.New())
 	cmd.AddCommand(cache.New())
 	cmd.AddCommand(clone.New())
+	cmd.AddCommand(hooks.New())
+	cmd.AddCommand(mirror.New())
 	cmd.AddCommand(trust.New())
 	cmd.AddCommand(policy.New())
+	cmd.AddCommand(recover.New())
 	cmd.AddCommand(rsl.New())
+	cmd.AddCommand(serve.New())
 	cmd.AddCommand(sync.New())
+	cmd.AddCommand(verifyall.New())
 	cmd.AddCommand(verifymergeable.New())
 	cmd.AddCommand(verifynetwork.New())
 	cmd.AddCommand(verifyref.New())
@@ -4,6 +4,10 @@
 package annotate
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
 	"github.com/spf13/cobra"
@@ -12,6 +16,7 @@ import (
 type options struct {
 	skip       bool
 	message    string
+	data       []string
 	remoteName string
 	localOnly  bool
 }
@@ -34,6 +39,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 	)
 	cmd.MarkFlagRequired("message") //nolint:errcheck
 
+	cmd.Flags().StringArrayVar(
+		&o.data,
+		"data",
+		nil,
+		"structured data to record in the annotation as key=value, where value is interpreted as JSON if valid and as a string otherwise",
+	)
+
 	cmd.Flags().StringVar(
 		&o.remoteName,
 		"remote-name",
@@ -63,15 +75,43 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		opts = append(opts, rslopts.WithAnnotateLocalOnly())
 	}
 
+	if len(o.data) != 0 {
+		data, err := parseData(o.data)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, rslopts.WithAnnotateData(data))
+	}
+
 	return repo.RecordRSLAnnotation(cmd.Context(), args, o.skip, o.message, true, opts...)
 }
 
+// parseData parses key=value pairs into the annotation's data. Values that are
+// valid JSON are recorded as is, other values are recorded as strings.
+func parseData(pairs []string) (map[string]any, error) {
+	data := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid data '%s', must be in the format key=value", pair)
+		}
+
+		if json.Valid([]byte(value)) {
+			data[key] = json.RawMessage(value)
+		} else {
+			data[key] = value
+		}
+	}
+
+	return data, nil
+}
+
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
 		Use:               "annotate",
 		Short:             "Annotate prior RSL entries",
-		Long:              "The 'annotate' command adds annotations to prior RSL entries in the repository's RSL. It is used to add a message to an entry for additional context or mark an entry to be skipped, in cases where RSL recovery or reconciliation is needed.",
+		Long:              "The 'annotate' command adds annotations to prior RSL entries in the repository's RSL. It is used to add a message to an entry for additional context or mark an entry to be skipped, in cases where RSL recovery or reconciliation is needed. Structured data such as incident IDs, revert reasons, or references to attestations can be recorded using '--data key=value'.",
 		Args:              cobra.MinimumNArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -4,6 +4,7 @@
 package annotate
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
 
@@ -179,4 +180,59 @@ func TestAnnotate(t *testing.T) {
 		assert.Equal(t, []gitinterface.Hash{entryID}, annotation.RSLEntryIDs)
 		assert.True(t, annotation.Skip)
 	})
+	t.Run("successful local annotation with data", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		r := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		libRepo, err := gittuf.LoadRepository(".")
+		require.NoError(t, err)
+
+		treeBuilder := gitinterface.NewTreeBuilder(r)
+		emptyTreeHash, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.NoError(t, err)
+
+		_, err = r.Commit(emptyTreeHash, "refs/heads/main", "Initial commit\n", false)
+		require.NoError(t, err)
+
+		err = libRepo.RecordRSLEntryForReference(t.Context(), "refs/heads/main", false, rslopts.WithRecordLocalOnly())
+		require.NoError(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(r)
+		require.NoError(t, err)
+		entryID := latestEntry.GetID()
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), entryID.String(), "-m", "test data message", "--data", "incident=INC-1234", "--data", "severity=2", "--data", `reason={"revert":true}`, "--local-only")
+		assert.NoError(t, err)
+
+		latestEntry, err = rsl.GetLatestEntry(r)
+		require.NoError(t, err)
+		assert.IsType(t, &rsl.AnnotationEntry{}, latestEntry)
+
+		annotation := latestEntry.(*rsl.AnnotationEntry)
+		assert.Equal(t, map[string]json.RawMessage{
+			"incident": json.RawMessage(`"INC-1234"`),
+			"severity": json.RawMessage(`2`),
+			"reason":   json.RawMessage(`{"revert":true}`),
+		}, annotation.Data)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "some-entry-id", "-m", "annotation message", "--data", "incident", "--local-only")
+		assert.ErrorContains(t, err, "must be in the format key=value")
+	})
 }
@@ -0,0 +1,128 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	report     bool
+	remoteName string
+	localOnly  bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.report,
+		"report",
+		false,
+		"report which historical commits on the bootstrapped branches are signed and satisfy the current policy",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote-name",
+		"",
+		"name of the remote to push the baseline to",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.localOnly,
+		"local-only",
+		false,
+		"perform this operation locally without pushing to a remote repository",
+	)
+
+	cmd.MarkFlagsOneRequired("remote-name", "local-only")
+	cmd.MarkFlagsMutuallyExclusive("remote-name", "local-only")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	opts := []rslopts.BootstrapOption{rslopts.WithBootstrapRemote(o.remoteName)}
+	if o.localOnly {
+		opts = append(opts, rslopts.WithBootstrapLocalOnly())
+	}
+
+	refNames, err := repo.BootstrapRSL(cmd.Context(), true, opts...)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	if len(refNames) == 0 {
+		fmt.Fprintln(stdOut, "All references are already recorded in the RSL, nothing to bootstrap")
+		return nil
+	}
+
+	for _, refName := range refNames {
+		fmt.Fprintf(stdOut, "%s: recorded in baseline\n", refName)
+	}
+
+	if !o.report {
+		return nil
+	}
+
+	for _, refName := range refNames {
+		if !strings.HasPrefix(refName, gitinterface.BranchRefPrefix) {
+			continue
+		}
+
+		results, err := repo.EvaluateHistoryForReference(cmd.Context(), refName)
+		if err != nil {
+			return err
+		}
+
+		signedCount, satisfiesPolicyCount := 0, 0
+		for _, result := range results {
+			if result.Signed {
+				signedCount++
+			}
+			if result.SatisfiesPolicy {
+				satisfiesPolicyCount++
+			}
+		}
+
+		fmt.Fprintf(stdOut, "\n%s: %d commits, %d signed, %d satisfy current policy\n", refName, len(results), signedCount, satisfiesPolicyCount)
+		for _, result := range results {
+			if !result.Signed {
+				continue
+			}
+
+			if result.SatisfiesPolicy {
+				fmt.Fprintf(stdOut, "  %s: signed, satisfies policy\n", result.ID.String())
+			} else {
+				fmt.Fprintf(stdOut, "  %s: signed, does not satisfy policy\n", result.ID.String())
+			}
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "bootstrap",
+		Short:             "Record a baseline for existing references when adopting gittuf",
+		Long:              "The 'bootstrap' command is used when adopting gittuf in a repository with existing history. It records a signed RSL entry for every branch and tag that is not yet recorded in the RSL at its current tip, along with an annotation identifying the entry as the baseline for these references. History prior to the baseline cannot be verified, so verification treats the baseline as the trusted starting point for each reference. The baseline is only trusted if it is signed by a root principal, and the policy must be applied first. With --report, each bootstrapped branch's history is evaluated against the current policy, listing the signed commits and whether they would satisfy it.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrap(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "--local-only")
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("missing remote-name or local-only", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorContains(t, err, "at least one of the flags in the group [remote-name local-only] is required")
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "--local-only")
+		assert.ErrorIs(t, err, policy.ErrPolicyNotFound)
+	})
+
+	t.Run("successful local bootstrap with report", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		r := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		keyPath := filepath.Join(tmpDir, "test-key")
+		require.NoError(t, os.WriteFile(keyPath, artifacts.SSHED25519Private, 0o600))
+		require.NoError(t, os.WriteFile(keyPath+".pub", artifacts.SSHED25519PublicSSH, 0o600))
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		repo, err := gittuf.LoadRepository(".")
+		require.NoError(t, err)
+		signer, err := gittuf.LoadSigner(repo, keyPath)
+		require.NoError(t, err)
+		require.NoError(t, repo.InitializeRoot(t.Context(), signer, false))
+		require.NoError(t, repo.StagePolicy(t.Context(), "", true, false))
+		require.NoError(t, repo.ApplyPolicy(t.Context(), "", true, false))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, "refs/heads/main", 1, artifacts.GPGKey1Private)
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New(), "--local-only", "--report")
+		assert.NoError(t, err)
+		assert.Contains(t, stdOut.String(), "refs/heads/main: recorded in baseline")
+		assert.Contains(t, stdOut.String(), "refs/heads/main: 1 commits, 1 signed, 1 satisfy current policy")
+		assert.Contains(t, stdOut.String(), commitIDs[0].String())
+
+		latestEntry, err := rsl.GetLatestEntry(r)
+		require.NoError(t, err)
+		assert.IsType(t, &rsl.AnnotationEntry{}, latestEntry)
+
+		_, stdOut, _, err = cmd.ExecuteCommandC(New(), "--local-only")
+		assert.NoError(t, err)
+		assert.Contains(t, stdOut.String(), "nothing to bootstrap")
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package check
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+var ErrRSLCheckFailed = errors.New("issues found in RSL")
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	report, err := repo.CheckRSL(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	for _, issue := range report.Issues {
+		fmt.Fprintln(stdOut, issue.Error())
+	}
+
+	if len(report.Issues) != 0 {
+		return fmt.Errorf("%w: %d issue(s) found", ErrRSLCheckFailed, len(report.Issues))
+	}
+
+	fmt.Fprintf(stdOut, "Checked %d RSL entries, no issues found\n", len(report.Entries))
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "check",
+		Short:             "Check the structure of the RSL independently of the policy",
+		Long:              "The 'check' command validates the structure of the Reference State Log (RSL) without verifying it against the repository's policy. It checks that entry numbers increase monotonically with no gaps, that every entry can be parsed, that annotations refer to earlier entries, that propagation entries reference plausible upstream entries, and that every entry is signed by a key that appears in some policy recorded in the RSL. It is used to debug corrupted or manually edited RSLs.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
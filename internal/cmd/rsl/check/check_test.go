@@ -0,0 +1,67 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package check
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New())
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+		require.NoError(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false))
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New())
+		assert.NoError(t, err)
+		assert.Contains(t, stdOut.String(), "Checked 1 RSL entries, no issues found")
+	})
+
+	t.Run("issues found", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+		require.NoError(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false))
+
+		emptyTreeID, err := repo.EmptyTree()
+		require.NoError(t, err)
+		malformedEntryID, err := repo.Commit(emptyTreeID, rsl.Ref, "Not an RSL entry\n", false)
+		require.NoError(t, err)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New())
+		assert.ErrorIs(t, err, ErrRSLCheckFailed)
+		assert.Contains(t, stdOut.String(), malformedEntryID.String())
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package checkpoint
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKeys []string
+	remoteName  string
+	localOnly   bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(
+		&o.signingKeys,
+		"signing-key",
+		"k",
+		[]string{},
+		"additional root signing key to sign the checkpoint with, the checkpoint commit is signed using the Git signing configuration",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote-name",
+		"",
+		"name of the remote to push the checkpoint to",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.localOnly,
+		"local-only",
+		false,
+		"perform this operation locally without pushing to a remote repository",
+	)
+
+	cmd.MarkFlagsOneRequired("remote-name", "local-only")
+	cmd.MarkFlagsMutuallyExclusive("remote-name", "local-only")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signers := []sslibdsse.SignerVerifier{}
+	for _, signingKey := range o.signingKeys {
+		signer, err := gittuf.LoadSigner(repo, signingKey)
+		if err != nil {
+			return err
+		}
+		signers = append(signers, signer)
+	}
+
+	opts := []rslopts.CheckpointOption{rslopts.WithCheckpointRemote(o.remoteName)}
+	if o.localOnly {
+		opts = append(opts, rslopts.WithCheckpointLocalOnly())
+	}
+
+	return repo.RecordRSLCheckpoint(cmd.Context(), signers, true, opts...)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "checkpoint",
+		Short:             "Record a checkpoint of the verified RSL state",
+		Long:              "The 'checkpoint' command verifies all references in the repository and records a checkpoint entry in the RSL that summarizes the verified state. Once a checkpoint is trusted by a threshold of root principals, verification starts from it rather than from the first entry in the RSL. Use 'gittuf verify-ref --full' to verify the entire RSL regardless of checkpoints.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+var ErrRSLsDiverged = errors.New("RSLs fetched from remotes have diverged")
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	states, comparisons, err := repo.CompareRemoteRSLs(args...)
+	if err != nil {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	for _, state := range states {
+		fmt.Fprintf(stdOut, "%s: latest entry '%s' (number %d), recorded %s\n", state.Remote, state.TipID.String(), state.TipNumber, state.TipTime.Format(time.RFC3339))
+	}
+
+	diverged := false
+	for _, comparison := range comparisons {
+		a, b := comparison.A.Remote, comparison.B.Remote
+
+		switch {
+		case comparison.Identical():
+			fmt.Fprintf(stdOut, "%s and %s: identical\n", a, b)
+		case comparison.AIsPrefixOfB():
+			fmt.Fprintf(stdOut, "%s and %s: %s is %d entries behind %s\n", a, b, a, comparison.EntriesOnlyInB, b)
+		case comparison.BIsPrefixOfA():
+			fmt.Fprintf(stdOut, "%s and %s: %s is %d entries behind %s\n", a, b, b, comparison.EntriesOnlyInA, a)
+		default:
+			diverged = true
+			if comparison.CommonEntryID.IsZero() {
+				fmt.Fprintf(stdOut, "%s and %s: diverged, no entries in common\n", a, b)
+			} else {
+				fmt.Fprintf(stdOut, "%s and %s: diverged after entry '%s', %d entries only in %s, %d entries only in %s\n", a, b, comparison.CommonEntryID.String(), comparison.EntriesOnlyInA, a, comparison.EntriesOnlyInB, b)
+			}
+		}
+	}
+
+	if diverged {
+		return ErrRSLsDiverged
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "compare <remote> <remote> [<remote>...]",
+		Short:             "Compare the RSLs of several remotes or mirrors",
+		Long:              "The 'compare' command fetches the Reference State Log (RSL) from each specified remote or mirror, which may be a configured remote's name or a URL, and compares every pair of them. For each pair, it reports whether the RSLs are identical, whether one is a prefix of the other and by how many entries it is behind, or where they diverge. A remote that is behind may be withholding entries (a freeze attack), while diverged RSLs indicate that the remotes are presenting different histories (a fork attack). The command exits with an error if any RSLs have diverged. The local RSL is not modified.",
+		Args:              cobra.MinimumNArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
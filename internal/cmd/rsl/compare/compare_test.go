@@ -0,0 +1,63 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compare
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("missing arguments", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "origin")
+		assert.ErrorContains(t, err, "requires at least 2 arg(s), only received 1")
+	})
+
+	t.Run("prefix and diverged", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		remoteR := gitinterface.CreateTestGitRepository(t, remoteTmpDir, true)
+		require.NoError(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(remoteR, false))
+
+		mirrorTmpDir := t.TempDir()
+		mirrorR := gitinterface.CreateTestGitRepository(t, mirrorTmpDir, true)
+		require.NoError(t, mirrorR.FetchRefSpec(remoteTmpDir, []string{fmt.Sprintf("%s:%s", rsl.Ref, rsl.Ref)}))
+		require.NoError(t, rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(mirrorR, false))
+
+		tmpDir := t.TempDir()
+		gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		_, stdOut, _, err := cmd.ExecuteCommandC(New(), remoteTmpDir, mirrorTmpDir)
+		assert.NoError(t, err)
+		assert.Contains(t, stdOut.String(), fmt.Sprintf("%s is 1 entries behind %s", remoteTmpDir, mirrorTmpDir))
+
+		// Diverge the remote from the mirror
+		require.NoError(t, rsl.NewReferenceEntry("refs/heads/feature", gitinterface.ZeroHash).Commit(remoteR, false))
+
+		_, stdOut, _, err = cmd.ExecuteCommandC(New(), remoteTmpDir, mirrorTmpDir)
+		assert.ErrorIs(t, err, ErrRSLsDiverged)
+		assert.Contains(t, stdOut.String(), "diverged after entry")
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package heartbeat
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	remoteName string
+	localOnly  bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote-name",
+		"",
+		"name of the remote to push the heartbeat to",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.localOnly,
+		"local-only",
+		false,
+		"perform this operation locally without pushing to a remote repository",
+	)
+
+	cmd.MarkFlagsOneRequired("remote-name", "local-only")
+	cmd.MarkFlagsMutuallyExclusive("remote-name", "local-only")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	opts := []rslopts.HeartbeatOption{rslopts.WithHeartbeatRemote(o.remoteName)}
+	if o.localOnly {
+		opts = append(opts, rslopts.WithHeartbeatLocalOnly())
+	}
+
+	return repo.RecordRSLHeartbeat(cmd.Context(), true, opts...)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "heartbeat",
+		Short:             "Record a heartbeat entry in the RSL",
+		Long:              "The 'heartbeat' command records a timestamped heartbeat entry in the repository's RSL. When the root of trust requires heartbeats, they must be recorded periodically by a trusted principal so that verifiers can detect an outdated RSL.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
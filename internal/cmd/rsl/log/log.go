@@ -4,15 +4,28 @@
 package log //nolint:revive
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	"github.com/gittuf/gittuf/internal/display"
 	"github.com/spf13/cobra"
 )
 
+const dateLayout = "2006-01-02"
+
 type options struct {
-	refs []string
+	refs               []string
+	entryTypes         []string
+	signers            []string
+	includeSigners     bool
+	since              string
+	until              string
+	annotationStatus   string
+	propagationSources []string
+	format             string
+	template           string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -22,15 +35,131 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		nil,
 		"only display RSL entries for the specified references",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&o.entryTypes,
+		"type",
+		nil,
+		fmt.Sprintf("only display RSL entries of the specified types (%s, %s, %s, %s, %s)", display.RSLEntryTypeReference, display.RSLEntryTypeMultiReference, display.RSLEntryTypePropagation, display.RSLEntryTypeHeartbeat, display.RSLEntryTypeCheckpoint),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.signers,
+		"signer",
+		nil,
+		"only display RSL entries signed by the specified principals, identified using the policies recorded in the RSL",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.includeSigners,
+		"include-signers",
+		false,
+		"include the principals who signed each RSL entry in structured output",
+	)
+
+	cmd.Flags().StringVar(
+		&o.since,
+		"since",
+		"",
+		"only display RSL entries created at or after the specified time (RFC 3339 or YYYY-MM-DD)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.until,
+		"until",
+		"",
+		"only display RSL entries created at or before the specified time (RFC 3339 or YYYY-MM-DD)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.annotationStatus,
+		"annotation-status",
+		"",
+		fmt.Sprintf("only display RSL entries that are %s or %s", display.AnnotationStatusSkipped, display.AnnotationStatusUnskipped),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.propagationSources,
+		"propagation-source",
+		nil,
+		"only display propagation entries for the specified upstream repositories",
+	)
+
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		display.FormatText,
+		fmt.Sprintf("output format (%s, %s, %s, %s)", display.FormatText, display.FormatJSON, display.FormatNDJSON, display.FormatTemplate),
+	)
+
+	cmd.Flags().StringVar(
+		&o.template,
+		"template",
+		"",
+		"Go template used to display each RSL entry with the template format",
+	)
 }
 
-func (o *options) Run(_ *cobra.Command, _ []string) error {
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	since, err := parseTime(o.since, false)
+	if err != nil {
+		return err
+	}
+	until, err := parseTime(o.until, true)
+	if err != nil {
+		return err
+	}
+
 	repo, err := gittuf.LoadRepository(".")
 	if err != nil {
 		return err
 	}
 
-	return display.RSLLog(repo.GetGitRepository(), display.NewDisplayWriter(os.Stdout), display.WithReferences(o.refs))
+	opts := []display.Option{
+		display.WithReferences(o.refs),
+		display.WithEntryTypes(o.entryTypes),
+		display.WithSigners(o.signers),
+		display.WithTimeRange(since, until),
+		display.WithAnnotationStatus(o.annotationStatus),
+		display.WithPropagationSources(o.propagationSources),
+		display.WithFormat(o.format, o.template),
+	}
+
+	// Identifying signers requires verifying every entry's signature, so we
+	// only do so when filtering by signer or when explicitly requested
+	if len(o.signers) != 0 || o.includeSigners {
+		entrySigners, err := repo.GetRSLEntrySigners(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts = append(opts, display.WithEntrySigners(entrySigners))
+	}
+
+	return display.RSLLog(repo.GetGitRepository(), display.NewDisplayWriter(os.Stdout), opts...)
+}
+
+// parseTime parses a timestamp in the RFC 3339 format or a date. If endOfDay is
+// set, a date is interpreted as the last instant of that day so that the entire
+// day is included.
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+
+	date, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s', must be in the RFC 3339 format or YYYY-MM-DD", value)
+	}
+
+	if endOfDay {
+		date = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return date, nil
 }
 
 func New() *cobra.Command {
@@ -38,7 +167,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "log",
 		Short:             "Display the repository's Reference State Log",
-		Long:              "The 'log' command displays the repository's RSL. It is used to view the history of reference state changes and inspect prior entries in the RSL.",
+		Long:              "The 'log' command displays the repository's RSL. It is used to view the history of reference state changes and inspect prior entries in the RSL. Entries can be filtered by reference, type, signer, time, annotation status, and propagation source. In addition to the default text format, entries can be displayed as JSON, as newline delimited JSON, or using a Go template applied to each entry.",
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -0,0 +1,32 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log //nolint:revive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	timestamp, err := parseTime("", false)
+	assert.Nil(t, err)
+	assert.True(t, timestamp.IsZero())
+
+	timestamp, err = parseTime("2025-01-02T03:04:05Z", true)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC), timestamp)
+
+	timestamp, err = parseTime("2025-01-02", false)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.Local), timestamp)
+
+	timestamp, err = parseTime("2025-01-02", true)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond), timestamp)
+
+	_, err = parseTime("yesterday", false)
+	assert.ErrorContains(t, err, "invalid time 'yesterday'")
+}
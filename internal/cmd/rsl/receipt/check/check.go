@@ -0,0 +1,56 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	results, err := repo.CheckPushReceipts(cmd.Context())
+	if err != nil && !errors.Is(err, gittuf.ErrDroppedPushes) {
+		return err
+	}
+
+	stdOut := cmd.OutOrStdout()
+	for _, result := range results {
+		refNames := strings.Join(result.RefNames, ", ")
+		if result.Err != nil {
+			fmt.Fprintf(stdOut, "%s (%s): dropped (%s)\n", result.EntryID.String(), refNames, result.Err.Error())
+		} else {
+			fmt.Fprintf(stdOut, "%s (%s): recorded\n", result.EntryID.String(), refNames)
+		}
+	}
+
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "check",
+		Short:             "Check that pushes acknowledged by the forge are in the RSL",
+		Long:              "The 'check' command checks that the RSL entry of each stored push receipt is in the local RSL, which must first be fetched from the forge. The result for each receipt is printed, and the command fails if the forge dropped any of the pushes. Receipts whose entries are found in the RSL are removed.",
+		Args:              cobra.ExactArgs(0),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
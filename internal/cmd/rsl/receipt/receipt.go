@@ -0,0 +1,24 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receipt
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/rsl/receipt/check"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/receipt/store"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "receipt",
+		Short:             "Tools for managing push receipts from the forge",
+		Long:              "The 'receipt' command provides tools for managing the receipts a forge trusted in the repository's policy returns for pushes it records in the RSL. Receipts are stored locally and checked against the RSL to detect pushes the forge acknowledged but dropped.",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(check.New())
+	cmd.AddCommand(store.New())
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	receipt, err := gittuf.DecodePushReceipt(args[0])
+	if err != nil {
+		return err
+	}
+
+	entryID, err := repo.StorePushReceipt(cmd.Context(), receipt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Stored receipt for RSL entry '%s'\n", entryID.String())
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "store <receipt>",
+		Short:             "Store a push receipt returned by the forge",
+		Long:              "The 'store' command validates and stores a receipt returned by the forge for a push, as printed by 'gittuf hooks post-receive' on the server. Receipts are stored automatically when pushing using the gittuf transport. The receipt must be an RSL entry signed by the forge trusted in the current policy for references delegated to it.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
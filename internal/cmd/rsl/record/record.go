@@ -14,6 +14,7 @@ type options struct {
 	skipDuplicateCheck bool
 	remoteName         string
 	localOnly          bool
+	deletion           bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -45,6 +46,13 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"perform this operation locally without pushing to a remote repository",
 	)
 
+	cmd.Flags().BoolVar(
+		&o.deletion,
+		"delete",
+		false,
+		"record that the reference has been deleted",
+	)
+
 	cmd.MarkFlagsOneRequired("remote-name", "local-only")
 	cmd.MarkFlagsMutuallyExclusive("remote-name", "local-only")
 }
@@ -65,6 +73,9 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 	if o.localOnly {
 		opts = append(opts, rslopts.WithRecordLocalOnly())
 	}
+	if o.deletion {
+		opts = append(opts, rslopts.WithRecordDeletion())
+	}
 
 	return repo.RecordRSLEntryForReference(cmd.Context(), args[0], true, opts...)
 }
@@ -74,7 +85,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "record",
 		Short:             "Record latest state of a Git reference (e.g., 'main') in the RSL",
-		Long:              "The 'record' command records the latest state of a Git reference in the repository's RSL. It is used to capture and track changes to references over time so they can be audited and verified. The argument must be a valid Git reference, such as 'main', 'HEAD', or a tag name.",
+		Long:              "The 'record' command records the latest state of a Git reference in the repository's RSL. It is used to capture and track changes to references over time so they can be audited and verified. The argument must be a valid Git reference, such as 'main', 'HEAD', or a tag name. With --delete, the deletion of the reference is recorded instead, and the reference must no longer exist locally.",
 		Args:              cobra.ExactArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
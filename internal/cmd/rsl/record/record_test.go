@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,4 +93,35 @@ func TestRecord(t *testing.T) {
 		_, _, _, err = cmd.ExecuteCommandC(New(), "main", "--local-only")
 		assert.ErrorIs(t, err, gitinterface.ErrSigningKeyNotSpecified)
 	})
+
+	t.Run("record deletion", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		r := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		cwd, err := os.Getwd()
+		require.NoError(t, err)
+		defer os.Chdir(cwd) //nolint:errcheck
+
+		require.NoError(t, os.Chdir(tmpDir))
+
+		treeBuilder := gitinterface.NewTreeBuilder(r)
+		emptyTreeHash, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.NoError(t, err)
+
+		_, err = r.Commit(emptyTreeHash, "refs/heads/main", "Initial commit\n", false)
+		require.NoError(t, err)
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "main", "--local-only")
+		require.NoError(t, err)
+
+		require.NoError(t, r.DeleteReference("refs/heads/main"))
+
+		_, _, _, err = cmd.ExecuteCommandC(New(), "main", "--local-only", "--delete")
+		require.NoError(t, err)
+
+		latestEntry, err := rsl.GetLatestEntry(r)
+		require.NoError(t, err)
+		assert.Equal(t, "refs/heads/main", latestEntry.(*rsl.ReferenceEntry).RefName)
+		assert.True(t, latestEntry.(*rsl.ReferenceEntry).IsDeletion())
+	})
 }
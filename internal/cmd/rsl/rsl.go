@@ -5,8 +5,14 @@ package rsl
 
 import (
 	"github.com/gittuf/gittuf/internal/cmd/rsl/annotate"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/bootstrap"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/check"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/checkpoint"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/compare"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/heartbeat"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/log"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/propagate"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/receipt"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/record"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/remote"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/skiprewritten"
@@ -22,8 +28,14 @@ func New() *cobra.Command {
 	}
 
 	cmd.AddCommand(annotate.New())
+	cmd.AddCommand(bootstrap.New())
+	cmd.AddCommand(check.New())
+	cmd.AddCommand(checkpoint.New())
+	cmd.AddCommand(compare.New())
+	cmd.AddCommand(heartbeat.New())
 	cmd.AddCommand(log.New())
 	cmd.AddCommand(propagate.New())
+	cmd.AddCommand(receipt.New())
 	cmd.AddCommand(record.New())
 	cmd.AddCommand(remote.New())
 	cmd.AddCommand(skiprewritten.New())
@@ -0,0 +1,175 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/spf13/cobra"
+)
+
+const (
+	preReceiveHook  = "pre-receive"
+	postReceiveHook = "post-receive"
+
+	shutdownTimeout = 10 * time.Second
+)
+
+type options struct {
+	address string
+	forge   bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.address,
+		"address",
+		"localhost:8080",
+		"address to listen on",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.forge,
+		"forge",
+		false,
+		"record pushed references in the RSL as the forge trusted in the repository's root of trust",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := gitinterface.LoadRepository(args[0])
+	if err != nil {
+		return err
+	}
+
+	gittufPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	hooksPath, err := os.MkdirTemp("", "gittuf-serve-hooks-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(hooksPath) //nolint:errcheck
+
+	if err := writeHooks(hooksPath, gittufPath, o.forge); err != nil {
+		return err
+	}
+
+	handler, err := newHandler(repo.GetGitDir(), hooksPath)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", o.address)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: shutdownTimeout}
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx) //nolint:errcheck
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving '%s' at http://%s/\n", repo.GetGitDir(), listener.Addr().String())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "serve <repository>",
+		Short:             "Serve a repository over HTTP with gittuf verification of pushes",
+		Long:              "The 'serve' command serves a Git repository using Git's smart HTTP protocol, backed by 'git http-backend'. It is meant for trying out gittuf and for small deployments, and does not authenticate clients. All references are served, including gittuf's references, while local-only references are hidden. Pushes are verified against the repository's gittuf policy using 'gittuf hooks pre-receive', which is invoked in place of the repository's hooks. If '--forge' is set, pushed references delegated to the forge are also recorded in the RSL using 'gittuf hooks post-receive', signed using the repository's Git signing configuration.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+// newHandler returns an HTTP handler that serves the repository at gitDir
+// using git-http-backend. If hooksPath is set, the hooks in it are used in
+// place of the repository's hooks.
+func newHandler(gitDir, hooksPath string) (http.Handler, error) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return nil, err
+	}
+
+	gitConfig := map[string]string{
+		// Pushes are verified by the hooks rather than authenticated
+		"http.receivepack": "true",
+		// Local-only refs such as gittuf's persistent cache must not be
+		// served
+		"transfer.hideRefs": "refs/local/",
+	}
+	if hooksPath != "" {
+		gitConfig["core.hooksPath"] = hooksPath
+	}
+
+	env := append(os.Environ(), fmt.Sprintf("GIT_PROJECT_ROOT=%s", gitDir), "GIT_HTTP_EXPORT_ALL=1", fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(gitConfig)))
+	index := 0
+	for key, value := range gitConfig {
+		env = append(env, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", index, key), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", index, value))
+		index++
+	}
+
+	backend := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  env,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.Debug(fmt.Sprintf("%s %s", r.Method, r.URL.String()))
+		backend.ServeHTTP(w, r)
+	}), nil
+}
+
+// writeHooks writes hooks to hooksPath that invoke the gittuf executable at
+// gittufPath to verify pushes and, if forge is set, record them in the RSL.
+func writeHooks(hooksPath, gittufPath string, forge bool) error {
+	hooks := []string{preReceiveHook}
+	if forge {
+		hooks = append(hooks, postReceiveHook)
+	}
+
+	// The path is quoted for the shell that Git uses to run hooks
+	quotedPath := "'" + strings.ReplaceAll(gittufPath, "'", `'\''`) + "'"
+	for _, hook := range hooks {
+		contents := fmt.Sprintf("#!/bin/sh\nexec %s hooks %s\n", quotedPath, hook)
+		if err := os.WriteFile(filepath.Join(hooksPath, hook), []byte(contents), 0o755); err != nil { //nolint:gosec
+			return err
+		}
+	}
+
+	return nil
+}
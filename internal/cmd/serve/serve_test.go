@@ -0,0 +1,364 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package serve
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/internal/cmd"
+	"github.com/gittuf/gittuf/internal/cmd/hooks"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rootKeyBytes       = artifacts.SSHRSAPrivate
+	rootPubKeyBytes    = artifacts.SSHRSAPublicSSH
+	targetsKeyBytes    = artifacts.SSHECDSAPrivate
+	targetsPubKeyBytes = artifacts.SSHECDSAPublicSSH
+
+	testCtx = context.Background()
+)
+
+// TestMain runs the test binary as gittuf's hooks subcommand when it is
+// invoked as gittuf by the hooks written for the server, see
+// TestNewHandlerWithTransport.
+func TestMain(m *testing.M) {
+	if filepath.Base(os.Args[0]) == "gittuf" {
+		rootCmd := &cobra.Command{Use: "gittuf", SilenceUsage: true}
+		rootCmd.AddCommand(hooks.New())
+		if err := rootCmd.Execute(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestServe(t *testing.T) {
+	t.Run("no repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		_, _, _, err := cmd.ExecuteCommandC(New(), tmpDir)
+		assert.ErrorContains(t, err, "unable to identify git directory")
+	})
+
+	t.Run("missing repository argument", func(t *testing.T) {
+		_, _, _, err := cmd.ExecuteCommandC(New())
+		assert.ErrorContains(t, err, "accepts 1 arg(s)")
+	})
+}
+
+func TestNewHandler(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, true)
+
+	treeBuilder := gitinterface.NewTreeBuilder(repo)
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	require.Nil(t, err)
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+	require.Nil(t, err)
+	require.Nil(t, repo.SetReference("refs/gittuf/reference-state-log", commitID))
+	require.Nil(t, repo.SetReference("refs/local/gittuf/persistent-cache", commitID))
+
+	handler, err := newHandler(repo.GetGitDir(), "")
+	require.Nil(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	t.Run("gittuf references are served", func(t *testing.T) {
+		output, err := exec.Command("git", "ls-remote", server.URL).CombinedOutput()
+		require.Nil(t, err, string(output))
+
+		assert.Contains(t, string(output), commitID.String()+"\trefs/heads/main")
+		assert.Contains(t, string(output), commitID.String()+"\trefs/gittuf/reference-state-log")
+		assert.NotContains(t, string(output), "refs/local/")
+	})
+
+	t.Run("push", func(t *testing.T) {
+		output, err := exec.Command("git", "--git-dir", repo.GetGitDir(), "push", server.URL, "refs/heads/main:refs/heads/feature").CombinedOutput()
+		require.Nil(t, err, string(output))
+
+		featureTip, err := repo.GetReference("refs/heads/feature")
+		require.Nil(t, err)
+		assert.Equal(t, commitID, featureTip)
+	})
+}
+
+func TestNewHandlerWithTransport(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("transport tests are not supported on Windows")
+	}
+
+	gittufPath := setupTransport(t)
+
+	upstreamRepo := createTestRepositoryWithPolicy(t, false)
+	serverPath := filepath.Join(t.TempDir(), "repository.git")
+	runGit(t, "", "clone", "--mirror", upstreamRepo.GetGitDir(), serverPath)
+	serverRepo, err := gitinterface.LoadRepository(serverPath)
+	require.Nil(t, err)
+
+	hooksPath := t.TempDir()
+	require.Nil(t, writeHooks(hooksPath, gittufPath, false))
+	handler, err := newHandler(serverRepo.GetGitDir(), hooksPath)
+	require.Nil(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	clientDir := filepath.Join(t.TempDir(), "client")
+	runGit(t, "", "clone", "gittuf::"+server.URL, clientDir)
+	clientRepo, err := gitinterface.LoadRepository(clientDir)
+	require.Nil(t, err)
+	require.Nil(t, clientRepo.SetGitConfig("user.name", "Jane Doe"))
+	require.Nil(t, clientRepo.SetGitConfig("user.email", "jane.doe@example.com"))
+	require.Nil(t, clientRepo.SetGitConfig("gpg.format", "ssh"))
+	setSigningKey(t, clientRepo, rootKeyBytes, rootPubKeyBytes)
+
+	t.Run("clone", func(t *testing.T) {
+		for _, refName := range []string{rsl.Ref, policy.PolicyRef, "refs/heads/main"} {
+			expectedTip, err := serverRepo.GetReference(refName)
+			require.Nil(t, err)
+			tip, err := clientRepo.GetReference(refName)
+			require.Nil(t, err, refName)
+			assert.Equal(t, expectedTip, tip, refName)
+		}
+	})
+
+	t.Run("push", func(t *testing.T) {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, clientRepo, "refs/heads/main", 1, rootKeyBytes)
+
+		output, err := gitCommand(clientDir, "push", "origin", "refs/heads/main:refs/heads/main").CombinedOutput()
+		require.Nil(t, err, string(output))
+		assert.Contains(t, string(output), "refs/heads/main: verified")
+
+		tip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		assert.Equal(t, commitIDs[0], tip)
+	})
+
+	t.Run("push rejected", func(t *testing.T) {
+		serverTip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		serverRSLTip, err := serverRepo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		// The RSL entry for the push is signed using a key that isn't
+		// authorized for main, and the transport's policy check is
+		// overridden so that the server must reject the push
+		setSigningKey(t, clientRepo, targetsKeyBytes, targetsPubKeyBytes)
+		common.AddNTestCommitsToSpecifiedRef(t, clientRepo, "refs/heads/main", 1, targetsKeyBytes)
+
+		output, err := gitCommand(clientDir, "push", "--push-option=gittuf-force", "origin", "refs/heads/main:refs/heads/main").CombinedOutput()
+		assert.NotNil(t, err, string(output))
+		assert.Contains(t, string(output), "refs/heads/main: rejected")
+
+		tip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		assert.Equal(t, serverTip, tip)
+
+		rslTip, err := serverRepo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, serverRSLTip, rslTip)
+	})
+}
+
+func TestNewHandlerWithForge(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("transport tests are not supported on Windows")
+	}
+
+	gittufPath := setupTransport(t)
+
+	upstreamRepo := createTestRepositoryWithPolicy(t, true)
+	serverPath := filepath.Join(t.TempDir(), "repository.git")
+	runGit(t, "", "clone", "--mirror", upstreamRepo.GetGitDir(), serverPath)
+	serverRepo, err := gitinterface.LoadRepository(serverPath)
+	require.Nil(t, err)
+
+	// The server signs the RSL entries it creates as the forge
+	require.Nil(t, serverRepo.SetGitConfig("user.name", "Forge"))
+	require.Nil(t, serverRepo.SetGitConfig("user.email", "forge@example.com"))
+	require.Nil(t, serverRepo.SetGitConfig("gpg.format", "ssh"))
+	setSigningKey(t, serverRepo, targetsKeyBytes, targetsPubKeyBytes)
+
+	hooksPath := t.TempDir()
+	require.Nil(t, writeHooks(hooksPath, gittufPath, true))
+	handler, err := newHandler(serverRepo.GetGitDir(), hooksPath)
+	require.Nil(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	clientDir := filepath.Join(t.TempDir(), "client")
+	runGit(t, "", "clone", "gittuf::"+server.URL, clientDir)
+	clientRepo, err := gitinterface.LoadRepository(clientDir)
+	require.Nil(t, err)
+	require.Nil(t, clientRepo.SetGitConfig("user.name", "Jane Doe"))
+	require.Nil(t, clientRepo.SetGitConfig("user.email", "jane.doe@example.com"))
+	require.Nil(t, clientRepo.SetGitConfig("gpg.format", "ssh"))
+	setSigningKey(t, clientRepo, rootKeyBytes, rootPubKeyBytes)
+
+	serverRSLTip, err := serverRepo.GetReference(rsl.Ref)
+	require.Nil(t, err)
+
+	common.AddNTestCommitsToSpecifiedRef(t, clientRepo, "refs/heads/main", 1, rootKeyBytes)
+	output, err := gitCommand(clientDir, "push", "origin", "refs/heads/main:refs/heads/main").CombinedOutput()
+	require.Nil(t, err, string(output))
+	assert.NotContains(t, string(output), "unable to store push receipt")
+
+	// The forge recorded the push in the RSL
+	newServerRSLTip, err := serverRepo.GetReference(rsl.Ref)
+	require.Nil(t, err)
+	assert.NotEqual(t, serverRSLTip, newServerRSLTip)
+
+	// The transport stored the receipt returned by the forge, which is
+	// found in the RSL once it is fetched
+	_, err = clientRepo.GetReference(gittuf.PushReceiptsRef)
+	require.Nil(t, err)
+
+	runGit(t, clientDir, "fetch", "origin")
+	repo, err := gittuf.LoadRepository(clientDir)
+	require.Nil(t, err)
+	results, err := repo.CheckPushReceipts(testCtx)
+	require.Nil(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, newServerRSLTip, results[0].EntryID)
+	assert.Nil(t, results[0].Err)
+}
+
+func TestWriteHooks(t *testing.T) {
+	t.Run("without forge", func(t *testing.T) {
+		hooksPath := t.TempDir()
+
+		err := writeHooks(hooksPath, "/usr/local/bin/gittuf", false)
+		require.Nil(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(hooksPath, preReceiveHook))
+		require.Nil(t, err)
+		assert.Equal(t, "#!/bin/sh\nexec '/usr/local/bin/gittuf' hooks pre-receive\n", string(contents))
+
+		assert.NoFileExists(t, filepath.Join(hooksPath, postReceiveHook))
+	})
+
+	t.Run("with forge", func(t *testing.T) {
+		hooksPath := t.TempDir()
+
+		err := writeHooks(hooksPath, "/opt/it's here/gittuf", true)
+		require.Nil(t, err)
+
+		contents, err := os.ReadFile(filepath.Join(hooksPath, preReceiveHook))
+		require.Nil(t, err)
+		assert.Equal(t, "#!/bin/sh\nexec '/opt/it'\\''s here/gittuf' hooks pre-receive\n", string(contents))
+
+		contents, err = os.ReadFile(filepath.Join(hooksPath, postReceiveHook))
+		require.Nil(t, err)
+		assert.Equal(t, "#!/bin/sh\nexec '/opt/it'\\''s here/gittuf' hooks post-receive\n", string(contents))
+	})
+}
+
+// setupTransport makes the transport available to Git for gittuf:: URLs and
+// returns the path at which the hooks can invoke the test binary as gittuf.
+func setupTransport(t *testing.T) string {
+	t.Helper()
+
+	binDir := t.TempDir()
+	output, err := exec.Command("go", "build", "-o", filepath.Join(binDir, "git-remote-gittuf"), "github.com/gittuf/gittuf/internal/git-remote-gittuf").CombinedOutput()
+	require.Nil(t, err, string(output))
+	executable, err := os.Executable()
+	require.Nil(t, err)
+	gittufPath := filepath.Join(binDir, "gittuf")
+	require.Nil(t, os.Symlink(executable, gittufPath))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return gittufPath
+}
+
+// createTestRepositoryWithPolicy creates a repository whose policy protects
+// main, authorizing the SSH key configured as the repository's Git signing
+// key, with an RSL entry for main. If trustForge is set, the targets key is
+// also trusted as the forge for branches.
+func createTestRepositoryWithPolicy(t *testing.T, trustForge bool) *gitinterface.Repository {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	gitRepo := gitinterface.CreateTestGitRepository(t, repoDir, false)
+	repo, err := gittuf.LoadRepository(repoDir)
+	require.Nil(t, err)
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	gitSigningKey := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+
+	require.Nil(t, repo.InitializeRoot(testCtx, rootSigner, false))
+	require.Nil(t, repo.AddTopLevelTargetsKey(testCtx, rootSigner, tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey()), false))
+	require.Nil(t, repo.InitializeTargets(testCtx, targetsSigner, policy.TargetsRoleName, false))
+	require.Nil(t, repo.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{gitSigningKey}, false))
+	require.Nil(t, repo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{gitSigningKey.KeyID}, []string{"git:refs/heads/main"}, 1, false))
+	if trustForge {
+		require.Nil(t, repo.SetForge(testCtx, rootSigner, []tuf.Principal{tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey())}, []string{"refs/heads/*"}, false))
+	}
+	require.Nil(t, repo.StagePolicy(testCtx, "", true, false))
+	require.Nil(t, repo.ApplyPolicy(testCtx, "", true, false))
+
+	common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 1, rootKeyBytes)
+	require.Nil(t, repo.RecordRSLEntryForReference(testCtx, "refs/heads/main", true, rslopts.WithRecordLocalOnly()))
+
+	return gitRepo
+}
+
+// setSigningKey configures the repository to sign using the specified SSH
+// key.
+func setSigningKey(t *testing.T, repo *gitinterface.Repository, privateBytes, publicBytes []byte) {
+	t.Helper()
+
+	keysDir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(keysDir, "key"), privateBytes, 0o600))
+	require.Nil(t, os.WriteFile(filepath.Join(keysDir, "key.pub"), publicBytes, 0o600))
+	require.Nil(t, repo.SetGitConfig("user.signingkey", filepath.Join(keysDir, "key.pub")))
+}
+
+func setupSSHKeysForSigning(t *testing.T, privateBytes, publicBytes []byte) *ssh.Signer {
+	t.Helper()
+
+	keysDir := t.TempDir()
+	privKeyPath := filepath.Join(keysDir, "key")
+	pubKeyPath := filepath.Join(keysDir, "key.pub")
+	require.Nil(t, os.WriteFile(privKeyPath, privateBytes, 0o600))
+	require.Nil(t, os.WriteFile(pubKeyPath, publicBytes, 0o600))
+
+	signer, err := ssh.NewSignerFromFile(privKeyPath)
+	require.Nil(t, err)
+
+	return signer
+}
+
+func gitCommand(dir string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	output, err := gitCommand(dir, args...).CombinedOutput()
+	require.Nil(t, err, string(output))
+}
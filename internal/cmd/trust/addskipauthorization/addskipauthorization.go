@@ -0,0 +1,94 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package addskipauthorization
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p           *persistent.Options
+	name        string
+	refPatterns []string
+	principals  []string
+	threshold   int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.name,
+		"name",
+		"",
+		"name of skip authorization",
+	)
+	cmd.MarkFlagRequired("name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.refPatterns,
+		"ref-pattern",
+		[]string{},
+		"patterns used to identify Git references the skip authorization applies to (e.g., refs/heads/main)",
+	)
+	cmd.MarkFlagRequired("ref-pattern") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.principals,
+		"principal",
+		[]string{},
+		"principal trusted to skip RSL entries (path to SSH public key, \"gpg:<fingerprint>\" for GPG, or \"fulcio:<identity>::<issuer>\" for Sigstore)",
+	)
+	cmd.MarkFlagRequired("principal") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of principals who must skip an RSL entry",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	principals := []tuf.Principal{}
+	for _, principalRef := range o.principals {
+		principal, err := gittuf.LoadPublicKey(principalRef)
+		if err != nil {
+			return err
+		}
+		principals = append(principals, principal)
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.AddSkipAuthorization(cmd.Context(), signer, o.name, o.refPatterns, principals, o.threshold, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-skip-authorization",
+		Short:             "Restrict who may skip RSL entries for a set of references",
+		Long:              "The 'add-skip-authorization' command records in the root of trust the principals trusted to skip RSL entries for references matching the specified patterns. Once set, an entry for a matching reference is only treated as skipped during verification if a threshold of these principals have signed annotations that skip it. Skip annotations created by anyone else are ignored.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,77 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package setforge
+
+import (
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p           *persistent.Options
+	principals  []string
+	refPatterns []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.principals,
+		"principal",
+		[]string{},
+		"principal trusted as the forge (path to SSH public key, \"gpg:<fingerprint>\" for GPG, or \"fulcio:<identity>::<issuer>\" for Sigstore)",
+	)
+	cmd.MarkFlagRequired("principal") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.refPatterns,
+		"ref-pattern",
+		[]string{},
+		"patterns used to identify Git references delegated to the forge (e.g., refs/heads/*)",
+	)
+	cmd.MarkFlagRequired("ref-pattern") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	principals := []tuf.Principal{}
+	for _, principalRef := range o.principals {
+		principal, err := gittuf.LoadPublicKey(principalRef)
+		if err != nil {
+			return err
+		}
+		principals = append(principals, principal)
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.SetForge(cmd.Context(), signer, principals, o.refPatterns, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-forge",
+		Short:             "Trust a forge to create RSL entries for pushes",
+		Long:              "The 'set-forge' command records in the root of trust the principals trusted as the forge and the Git references delegated to it. The forge creates and signs RSL entries on receipt of pushes to these references, which avoids clients racing to update the RSL in busy repositories. During verification, entries the forge creates for delegated references are accepted in place of entries created by the pushers, while entries it creates for other references are not.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
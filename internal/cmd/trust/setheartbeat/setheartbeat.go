@@ -0,0 +1,79 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package setheartbeat
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	trustpolicyopts "github.com/gittuf/gittuf/experimental/gittuf/options/trustpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p            *persistent.Options
+	principals   []string
+	maxStaleness time.Duration
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.principals,
+		"principal",
+		[]string{},
+		"principal trusted to create RSL heartbeat entries (path to SSH public key, \"gpg:<fingerprint>\" for GPG, or \"fulcio:<identity>::<issuer>\" for Sigstore)",
+	)
+	cmd.MarkFlagRequired("principal") //nolint:errcheck
+
+	cmd.Flags().DurationVar(
+		&o.maxStaleness,
+		"max-staleness",
+		0,
+		"maximum age of the latest RSL heartbeat entry (e.g., 24h)",
+	)
+	cmd.MarkFlagRequired("max-staleness") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	signer, err := gittuf.LoadSigner(repo, o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	principals := []tuf.Principal{}
+	for _, principalRef := range o.principals {
+		principal, err := gittuf.LoadPublicKey(principalRef)
+		if err != nil {
+			return err
+		}
+		principals = append(principals, principal)
+	}
+
+	opts := []trustpolicyopts.Option{}
+	if o.p.WithRSLEntry {
+		opts = append(opts, trustpolicyopts.WithRSLEntry())
+	}
+	return repo.SetHeartbeat(cmd.Context(), signer, principals, o.maxStaleness, true, opts...)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-heartbeat",
+		Short:             "Require periodic RSL heartbeat entries",
+		Long:              "The 'set-heartbeat' command records in the root of trust the principals trusted to create RSL heartbeat entries and the maximum age allowed for the latest heartbeat. During verification, an RSL whose latest trusted heartbeat is older than this is treated as outdated, which helps detect a remote withholding newer RSL entries.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/trust/addpolicykey"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addpropagationdirective"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addrootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addskipauthorization"
 	"github.com/gittuf/gittuf/internal/cmd/trust/disablegithubappapprovals"
 	"github.com/gittuf/gittuf/internal/cmd/trust/enablegithubappapprovals"
 	"github.com/gittuf/gittuf/internal/cmd/trust/incrementversion"
@@ -22,12 +23,17 @@ import (
 	"github.com/gittuf/gittuf/internal/cmd/trust/listpropagationdirectives"
 	"github.com/gittuf/gittuf/internal/cmd/trust/makecontroller"
 	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removeforge"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removegithubapp"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removeglobalrule"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removeheartbeat"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removehook"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removepolicykey"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removepropagationdirective"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removerootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removeskipauthorization"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setforge"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setheartbeat"
 	"github.com/gittuf/gittuf/internal/cmd/trust/setrepositorylocation"
 	"github.com/gittuf/gittuf/internal/cmd/trust/sign"
 	"github.com/gittuf/gittuf/internal/cmd/trust/updateglobalrule"
@@ -60,6 +66,7 @@ func New() *cobra.Command {
 	cmd.AddCommand(addpolicykey.New(o))
 	cmd.AddCommand(addpropagationdirective.New(o))
 	cmd.AddCommand(addrootkey.New(o))
+	cmd.AddCommand(addskipauthorization.New(o))
 	cmd.AddCommand(apply.New())
 	cmd.AddCommand(disablegithubappapprovals.New(o))
 	cmd.AddCommand(enablegithubappapprovals.New(o))
@@ -70,12 +77,17 @@ func New() *cobra.Command {
 	cmd.AddCommand(listpropagationdirectives.New())
 	cmd.AddCommand(makecontroller.New(o))
 	cmd.AddCommand(remote.New())
+	cmd.AddCommand(removeforge.New(o))
 	cmd.AddCommand(removegithubapp.New(o))
 	cmd.AddCommand(removeglobalrule.New(o))
+	cmd.AddCommand(removeheartbeat.New(o))
 	cmd.AddCommand(removehook.New(o))
 	cmd.AddCommand(removepolicykey.New(o))
 	cmd.AddCommand(removepropagationdirective.New(o))
 	cmd.AddCommand(removerootkey.New(o))
+	cmd.AddCommand(removeskipauthorization.New(o))
+	cmd.AddCommand(setforge.New(o))
+	cmd.AddCommand(setheartbeat.New(o))
 	cmd.AddCommand(setrepositorylocation.New(o))
 	cmd.AddCommand(sign.New(o))
 	cmd.AddCommand(stage.New())
@@ -0,0 +1,103 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyall
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	verifyallopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifyall"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	latestOnly         bool
+	protectedOnly      bool
+	changedSince       string
+	warnStaleHeartbeat bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.latestOnly,
+		"latest-only",
+		false,
+		"perform verification against latest entry in the RSL for each reference",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.protectedOnly,
+		"protected-only",
+		false,
+		"verify local references protected by a Git namespace rule instead of all references in the RSL",
+	)
+
+	cmd.Flags().StringVar(
+		&o.changedSince,
+		"changed-since",
+		"",
+		"only verify references with RSL entries after the specified entry",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.warnStaleHeartbeat,
+		"warn-stale-heartbeat",
+		false,
+		"warn instead of failing when the RSL heartbeat required by the root of trust is missing or stale",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := gittuf.LoadRepository(".")
+	if err != nil {
+		return err
+	}
+
+	opts := []verifyallopts.Option{}
+	if o.latestOnly {
+		opts = append(opts, verifyallopts.WithLatestOnly())
+	}
+	if o.protectedOnly {
+		opts = append(opts, verifyallopts.WithProtectedOnly())
+	}
+	if o.changedSince != "" {
+		opts = append(opts, verifyallopts.WithChangedSinceEntry(o.changedSince))
+	}
+	if o.warnStaleHeartbeat {
+		opts = append(opts, verifyallopts.WithWarnOnStaleHeartbeat())
+	}
+
+	// Results are returned alongside errors that apply to all references,
+	// such as a stale heartbeat
+	results, err := repo.VerifyAll(cmd.Context(), opts...)
+
+	stdOut := cmd.OutOrStdout()
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(stdOut, "%s: skipped (not present locally)\n", result.RefName)
+		case result.Err != nil:
+			fmt.Fprintf(stdOut, "%s: failed (%s)\n", result.RefName, result.Err.Error())
+		default:
+			fmt.Fprintf(stdOut, "%s: verified\n", result.RefName)
+		}
+	}
+
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify-all",
+		Short:             "Verify all references tracked by gittuf",
+		Long:              "The 'verify-all' command verifies every reference recorded in the repository's RSL, or every local reference protected by the policy, and prints a summary for each reference. It is used in CI and scheduled jobs to check the repository's state without enumerating references by hand.",
+		Args:              cobra.ExactArgs(0),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
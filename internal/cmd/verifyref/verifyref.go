@@ -14,8 +14,10 @@ import (
 
 type options struct {
 	latestOnly    bool
+	full          bool
 	fromEntry     string
 	remoteRefName string
+	warnStale     bool
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -35,12 +37,29 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 
 	cmd.MarkFlagsMutuallyExclusive("latest-only", "from-entry")
 
+	cmd.Flags().BoolVar(
+		&o.full,
+		"full",
+		false,
+		"perform verification from the first entry in the RSL, ignoring checkpoints and cached verification results",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("latest-only", "full")
+	cmd.MarkFlagsMutuallyExclusive("from-entry", "full")
+
 	cmd.Flags().StringVar(
 		&o.remoteRefName,
 		"remote-ref-name",
 		"",
 		"name of remote reference, if it differs from the local name",
 	)
+
+	cmd.Flags().BoolVar(
+		&o.warnStale,
+		"warn-stale-heartbeat",
+		false,
+		"warn instead of failing when the RSL heartbeat required by the root of trust is missing or stale",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -61,6 +80,12 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 	if o.latestOnly {
 		opts = append(opts, verifyopts.WithLatestOnly())
 	}
+	if o.full {
+		opts = append(opts, verifyopts.WithFull())
+	}
+	if o.warnStale {
+		opts = append(opts, verifyopts.WithWarnOnStaleHeartbeat())
+	}
 	return repo.VerifyRef(cmd.Context(), args[0], opts...)
 }
 
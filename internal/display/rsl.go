@@ -4,19 +4,60 @@
 package display
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
+const (
+	// FormatText displays the RSL in a human readable form.
+	FormatText = "text"
+	// FormatJSON displays the RSL as a JSON array of entries.
+	FormatJSON = "json"
+	// FormatNDJSON displays the RSL as newline delimited JSON entries.
+	FormatNDJSON = "ndjson"
+	// FormatTemplate displays each RSL entry using a Go template.
+	FormatTemplate = "template"
+
+	RSLEntryTypeReference      = "reference"
+	RSLEntryTypeMultiReference = "multi-reference"
+	RSLEntryTypePropagation    = "propagation"
+	RSLEntryTypeHeartbeat      = "heartbeat"
+	RSLEntryTypeCheckpoint     = "checkpoint"
+
+	AnnotationStatusSkipped   = "skipped"
+	AnnotationStatusUnskipped = "unskipped"
+)
+
+var (
+	ErrUnknownFormat           = errors.New("unknown format, must be one of 'text', 'json', 'ndjson', or 'template'")
+	ErrTemplateNotSpecified    = errors.New("template must be specified when using the template format")
+	ErrUnknownRSLEntryType     = errors.New("unknown RSL entry type, must be one of 'reference', 'multi-reference', 'propagation', 'heartbeat', or 'checkpoint'")
+	ErrUnknownAnnotationStatus = errors.New("unknown annotation status, must be one of 'skipped' or 'unskipped'")
+)
+
 type options struct {
-	refs *set.Set[string]
+	refs               *set.Set[string]
+	entryTypes         *set.Set[string]
+	signers            *set.Set[string]
+	entrySigners       map[string][]string
+	since              time.Time
+	until              time.Time
+	annotationStatus   string
+	propagationSources *set.Set[string]
+	format             string
+	template           string
 }
 
 type Option func(*options)
@@ -27,16 +68,144 @@ func WithReferences(refs []string) Option {
 	}
 }
 
+// WithEntryTypes only displays RSL entries of the specified types. Annotations
+// are always displayed alongside the entries they refer to.
+func WithEntryTypes(entryTypes []string) Option {
+	return func(o *options) {
+		o.entryTypes = set.NewSetFromItems(entryTypes...)
+	}
+}
+
+// WithSigners only displays RSL entries signed by one of the specified
+// principals. The signers of each entry must be provided using
+// WithEntrySigners.
+func WithSigners(principalIDs []string) Option {
+	return func(o *options) {
+		o.signers = set.NewSetFromItems(principalIDs...)
+	}
+}
+
+// WithEntrySigners provides the IDs of the principals who signed each RSL
+// entry, keyed by entry ID. The signers are included in structured output.
+func WithEntrySigners(entrySigners map[string][]string) Option {
+	return func(o *options) {
+		o.entrySigners = entrySigners
+	}
+}
+
+// WithTimeRange only displays RSL entries created in the specified range. A
+// zero value for either bound leaves that end of the range open.
+func WithTimeRange(since, until time.Time) Option {
+	return func(o *options) {
+		o.since = since
+		o.until = until
+	}
+}
+
+// WithAnnotationStatus only displays RSL entries that have been skipped or
+// that have not been skipped.
+func WithAnnotationStatus(annotationStatus string) Option {
+	return func(o *options) {
+		o.annotationStatus = annotationStatus
+	}
+}
+
+// WithPropagationSources only displays propagation entries for the specified
+// upstream repositories.
+func WithPropagationSources(upstreamRepositories []string) Option {
+	return func(o *options) {
+		o.propagationSources = set.NewSetFromItems(upstreamRepositories...)
+	}
+}
+
+// WithFormat sets the format used to display the RSL. The template is only
+// used with FormatTemplate.
+func WithFormat(format, template string) Option {
+	return func(o *options) {
+		o.format = format
+		o.template = template
+	}
+}
+
+// RSLLogEntry is the representation of an RSL entry used when the RSL is
+// displayed in a structured format. Fields that don't apply to the entry's
+// type are omitted.
+type RSLLogEntry struct {
+	ID                  string              `json:"id"`
+	Type                string              `json:"type"`
+	Number              uint64              `json:"number,omitempty"`
+	Time                time.Time           `json:"time"`
+	RefName             string              `json:"refName,omitempty"`
+	TargetID            string              `json:"targetID,omitempty"`
+	TargetIDs           map[string]string   `json:"targetIDs,omitempty"`
+	UpstreamRepository  string              `json:"upstreamRepository,omitempty"`
+	UpstreamEntryID     string              `json:"upstreamEntryID,omitempty"`
+	Timestamp           *time.Time          `json:"timestamp,omitempty"`
+	PolicyEntryID       string              `json:"policyEntryID,omitempty"`
+	AttestationsEntryID string              `json:"attestationsEntryID,omitempty"`
+	RefEntryIDs         map[string]string   `json:"refEntryIDs,omitempty"`
+	Skipped             bool                `json:"skipped"`
+	Annotations         []*RSLLogAnnotation `json:"annotations,omitempty"`
+	Signers             []string            `json:"signers,omitempty"`
+}
+
+// RSLLogAnnotation is the representation of an annotation on an RSLLogEntry.
+type RSLLogAnnotation struct {
+	ID      string                     `json:"id"`
+	Skip    bool                       `json:"skip"`
+	Message string                     `json:"message"`
+	Data    map[string]json.RawMessage `json:"data,omitempty"`
+	Number  uint64                     `json:"number,omitempty"`
+}
+
 // RSLLog implements the display function for `gittuf rsl log`.
 func RSLLog(repo *gitinterface.Repository, writer io.WriteCloser, opts ...Option) error {
 	defer writer.Close() //nolint:errcheck
 
-	options := &options{refs: set.NewSet[string]()}
+	options := &options{
+		refs:               set.NewSet[string](),
+		entryTypes:         set.NewSet[string](),
+		signers:            set.NewSet[string](),
+		propagationSources: set.NewSet[string](),
+		format:             FormatText,
+	}
 	for _, fn := range opts {
 		fn(options)
 	}
 
+	for _, entryType := range options.entryTypes.Contents() {
+		switch entryType {
+		case RSLEntryTypeReference, RSLEntryTypeMultiReference, RSLEntryTypePropagation, RSLEntryTypeHeartbeat, RSLEntryTypeCheckpoint:
+		default:
+			return fmt.Errorf("%w: '%s'", ErrUnknownRSLEntryType, entryType)
+		}
+	}
+
+	switch options.annotationStatus {
+	case "", AnnotationStatusSkipped, AnnotationStatusUnskipped:
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnknownAnnotationStatus, options.annotationStatus)
+	}
+
+	var entryTemplate *template.Template
+	switch options.format {
+	case FormatText, FormatJSON, FormatNDJSON:
+	case FormatTemplate:
+		if options.template == "" {
+			return ErrTemplateNotSpecified
+		}
+
+		var err error
+		entryTemplate, err = template.New("entry").Parse(options.template)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnknownFormat, options.format)
+	}
+
 	annotationsMap := make(map[string][]*rsl.AnnotationEntry)
+	logEntries := []*RSLLogEntry{} // only used with FormatJSON
 
 	iteratorEntry, err := rsl.GetLatestEntry(repo)
 	if err != nil {
@@ -57,22 +226,6 @@ func RSLLog(repo *gitinterface.Repository, writer io.WriteCloser, opts ...Option
 		}
 
 		switch iteratorEntry := iteratorEntry.(type) {
-		case *rsl.ReferenceEntry:
-			if options.refs.Len() != 0 && !options.refs.Has(iteratorEntry.RefName) {
-				// Skip this entry if it's not for the specified ref. Note that
-				// we still want to track annotation entries for this entry (if
-				// there are any) since they may apply to other entries that we
-				// do want to display.
-				slog.Debug(fmt.Sprintf("Skipping reference entry '%s' since it is for ref '%s'...", iteratorEntry.ID.String(), iteratorEntry.RefName))
-				break
-			}
-
-			slog.Debug(fmt.Sprintf("Writing reference entry '%s'...", iteratorEntry.ID.String()))
-			if err := writeRSLReferenceEntry(writer, iteratorEntry, annotationsMap[iteratorEntry.ID.String()], hasParent); err != nil {
-				// We return nil here to avoid noisy output when the writer is
-				// unexpectedly closed, such as by killing the pager
-				return nil
-			}
 		case *rsl.AnnotationEntry:
 			slog.Debug(fmt.Sprintf("Tracking annotation entry '%s'...", iteratorEntry.ID.String()))
 			for _, targetID := range iteratorEntry.RSLEntryIDs {
@@ -85,32 +238,235 @@ func RSLLog(repo *gitinterface.Repository, writer io.WriteCloser, opts ...Option
 				annotationsMap[targetIDString] = append(annotationsMap[targetIDString], iteratorEntry)
 			}
 
-		case *rsl.PropagationEntry:
-			if options.refs.Len() != 0 && !options.refs.Has(iteratorEntry.RefName) {
-				// Skip this entry if it's not for the specified ref. Note that
-				// we still want to track annotation entries for this entry (if
-				// there are any) since they may apply to other entries that we
-				// do want to display.
-				slog.Debug(fmt.Sprintf("Skipping propagation entry '%s' since it is for ref '%s'...", iteratorEntry.ID.String(), iteratorEntry.RefName))
+		default:
+			annotations := annotationsMap[iteratorEntry.GetID().String()]
+
+			// Note that we still track annotation entries for entries that
+			// aren't displayed since they may apply to other entries that we
+			// do want to display.
+			display, err := options.shouldDisplay(repo, iteratorEntry, annotations)
+			if err != nil {
+				return err
+			}
+			if !display {
+				slog.Debug(fmt.Sprintf("Skipping entry '%s' since it does not match the specified filters...", iteratorEntry.GetID().String()))
 				break
 			}
 
-			slog.Debug(fmt.Sprintf("Writing propagation entry '%s'...", iteratorEntry.ID.String()))
-			if err := writeRSLPropagationEntry(writer, iteratorEntry, hasParent); err != nil {
-				// We return nil here to avoid noisy output when
-				// the writer is unexpectedly closed, such as by
-				// killing the pager
-				return nil
+			if options.format == FormatText {
+				slog.Debug(fmt.Sprintf("Writing entry '%s'...", iteratorEntry.GetID().String()))
+				if err := writeRSLEntry(writer, iteratorEntry, annotations, hasParent); err != nil {
+					// We return nil here to avoid noisy output when the writer
+					// is unexpectedly closed, such as by killing the pager
+					return nil
+				}
+				break
+			}
+
+			logEntry, err := newRSLLogEntry(repo, iteratorEntry, annotations, options.entrySigners)
+			if err != nil {
+				return err
+			}
+
+			switch options.format {
+			case FormatJSON:
+				logEntries = append(logEntries, logEntry)
+			case FormatNDJSON:
+				if err := json.NewEncoder(writer).Encode(logEntry); err != nil {
+					return nil
+				}
+			case FormatTemplate:
+				if err := entryTemplate.Execute(writer, logEntry); err != nil {
+					return err
+				}
+				if _, err := writer.Write([]byte("\n")); err != nil {
+					return nil
+				}
 			}
 		}
 
 		if !hasParent {
 			// We're done
-			return nil
+			break
 		}
 
 		iteratorEntry = parentEntry
 	}
+
+	if options.format == FormatJSON {
+		logEntriesJSON, err := json.MarshalIndent(logEntries, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if _, err := writer.Write(append(logEntriesJSON, '\n')); err != nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// shouldDisplay returns true if the entry matches all the specified filters.
+func (o *options) shouldDisplay(repo *gitinterface.Repository, entry rsl.Entry, annotations []*rsl.AnnotationEntry) (bool, error) {
+	if o.refs.Len() != 0 {
+		switch entry := entry.(type) {
+		case *rsl.MultiReferenceEntry:
+			if !slices.ContainsFunc(entry.GetRefNames(), o.refs.Has) {
+				return false, nil
+			}
+		case rsl.ReferenceUpdaterEntry:
+			if !o.refs.Has(entry.GetRefName()) {
+				return false, nil
+			}
+		default:
+			// Heartbeats and checkpoints aren't for a specific ref, so
+			// they're only displayed when the log isn't filtered by ref
+			return false, nil
+		}
+	}
+
+	if o.entryTypes.Len() != 0 && !o.entryTypes.Has(getRSLEntryType(entry)) {
+		return false, nil
+	}
+
+	if o.propagationSources.Len() != 0 {
+		propagationEntry, isPropagationEntry := entry.(*rsl.PropagationEntry)
+		if !isPropagationEntry || !o.propagationSources.Has(propagationEntry.UpstreamRepository) {
+			return false, nil
+		}
+	}
+
+	switch o.annotationStatus {
+	case AnnotationStatusSkipped:
+		if !isSkipped(annotations) {
+			return false, nil
+		}
+	case AnnotationStatusUnskipped:
+		if isSkipped(annotations) {
+			return false, nil
+		}
+	}
+
+	if o.signers.Len() != 0 && !slices.ContainsFunc(o.entrySigners[entry.GetID().String()], o.signers.Has) {
+		return false, nil
+	}
+
+	if !o.since.IsZero() || !o.until.IsZero() {
+		entryTime, err := repo.GetCommitTime(entry.GetID())
+		if err != nil {
+			return false, err
+		}
+
+		if (!o.since.IsZero() && entryTime.Before(o.since)) || (!o.until.IsZero() && entryTime.After(o.until)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// writeRSLEntry writes the entry in the human readable form using the writer
+// for its type.
+func writeRSLEntry(writer io.WriteCloser, entry rsl.Entry, annotations []*rsl.AnnotationEntry, hasParent bool) error {
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		return writeRSLReferenceEntry(writer, entry, annotations, hasParent)
+	case *rsl.MultiReferenceEntry:
+		return writeRSLMultiReferenceEntry(writer, entry, annotations, hasParent)
+	case *rsl.PropagationEntry:
+		return writeRSLPropagationEntry(writer, entry, hasParent)
+	case *rsl.HeartbeatEntry:
+		return writeRSLHeartbeatEntry(writer, entry, hasParent)
+	case *rsl.CheckpointEntry:
+		return writeRSLCheckpointEntry(writer, entry, hasParent)
+	}
+
+	return nil
+}
+
+// newRSLLogEntry creates the structured representation of the entry.
+func newRSLLogEntry(repo *gitinterface.Repository, entry rsl.Entry, annotations []*rsl.AnnotationEntry, entrySigners map[string][]string) (*RSLLogEntry, error) {
+	entryTime, err := repo.GetCommitTime(entry.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	logEntry := &RSLLogEntry{
+		ID:      entry.GetID().String(),
+		Type:    getRSLEntryType(entry),
+		Number:  entry.GetNumber(),
+		Time:    entryTime,
+		Skipped: isSkipped(annotations),
+		Signers: entrySigners[entry.GetID().String()],
+	}
+
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		logEntry.RefName = entry.RefName
+		logEntry.TargetID = entry.TargetID.String()
+	case *rsl.MultiReferenceEntry:
+		logEntry.TargetIDs = map[string]string{}
+		for refName, targetID := range entry.TargetIDs {
+			logEntry.TargetIDs[refName] = targetID.String()
+		}
+	case *rsl.PropagationEntry:
+		logEntry.RefName = entry.RefName
+		logEntry.TargetID = entry.TargetID.String()
+		logEntry.UpstreamRepository = entry.UpstreamRepository
+		logEntry.UpstreamEntryID = entry.UpstreamEntryID.String()
+	case *rsl.HeartbeatEntry:
+		timestamp := entry.Timestamp
+		logEntry.Timestamp = &timestamp
+	case *rsl.CheckpointEntry:
+		logEntry.PolicyEntryID = entry.PolicyEntryID.String()
+		if !entry.AttestationsEntryID.IsZero() {
+			logEntry.AttestationsEntryID = entry.AttestationsEntryID.String()
+		}
+		logEntry.RefEntryIDs = map[string]string{}
+		for refName, entryID := range entry.RefEntryIDs {
+			logEntry.RefEntryIDs[refName] = entryID.String()
+		}
+	}
+
+	for _, annotation := range annotations {
+		logEntry.Annotations = append(logEntry.Annotations, &RSLLogAnnotation{
+			ID:      annotation.ID.String(),
+			Skip:    annotation.Skip,
+			Message: annotation.Message,
+			Data:    annotation.Data,
+			Number:  annotation.Number,
+		})
+	}
+
+	return logEntry, nil
+}
+
+func getRSLEntryType(entry rsl.Entry) string {
+	switch entry.(type) {
+	case *rsl.ReferenceEntry:
+		return RSLEntryTypeReference
+	case *rsl.MultiReferenceEntry:
+		return RSLEntryTypeMultiReference
+	case *rsl.PropagationEntry:
+		return RSLEntryTypePropagation
+	case *rsl.HeartbeatEntry:
+		return RSLEntryTypeHeartbeat
+	case *rsl.CheckpointEntry:
+		return RSLEntryTypeCheckpoint
+	}
+
+	return ""
+}
+
+func isSkipped(annotations []*rsl.AnnotationEntry) bool {
+	for _, annotation := range annotations {
+		if annotation.Skip {
+			return true
+		}
+	}
+
+	return false
 }
 
 // writeRSLReferenceEntry prepares the output for the given entry and its
@@ -129,6 +485,8 @@ func writeRSLReferenceEntry(writer io.WriteCloser, entry *rsl.ReferenceEntry, an
 	       Annotation ID: <annotationID>
 	       Skip:          <yes/no>
 	       Number:        <number>
+	       Data:
+	         <key>: <value>
 	       Message:
 	         <message>
 
@@ -156,6 +514,70 @@ func writeRSLReferenceEntry(writer io.WriteCloser, entry *rsl.ReferenceEntry, an
 		text += fmt.Sprintf("\n  Number: %d", entry.Number)
 	}
 
+	text += formatAnnotations(annotations)
+
+	text += "\n" // single trailing newline by default
+	if hasParent {
+		text += "\n" // extra newline for all intermediate (i.e., not last) entries
+	}
+
+	_, err := writer.Write([]byte(text))
+	return err
+}
+
+func writeRSLMultiReferenceEntry(writer io.WriteCloser, entry *rsl.MultiReferenceEntry, annotations []*rsl.AnnotationEntry, hasParent bool) error {
+	/* Output format:
+	   multi-reference entry <entryID> (skipped)
+
+	     Ref:    <refName>
+	     Target: <targetID>
+	     Ref:    <refName>
+	     Target: <targetID>
+	     Number: <number>
+
+	       Annotation ID: <annotationID>
+	       Skip:          <yes/no>
+	       Number:        <number>
+	       Data:
+	         <key>: <value>
+	       Message:
+	         <message>
+	*/
+
+	text := colorer(fmt.Sprintf("multi-reference entry %s", entry.ID.String()), yellow)
+
+	for _, annotation := range annotations {
+		if annotation.Skip {
+			text += fmt.Sprintf(" %s", colorer("(skipped)", red))
+			break
+		}
+	}
+
+	text += "\n"
+
+	for _, refName := range entry.GetRefNames() {
+		text += fmt.Sprintf("\n  Ref:    %s", refName)
+		text += fmt.Sprintf("\n  Target: %s", entry.TargetIDs[refName].String())
+	}
+	if entry.Number != 0 {
+		text += fmt.Sprintf("\n  Number: %d", entry.Number)
+	}
+
+	text += formatAnnotations(annotations)
+
+	text += "\n" // single trailing newline by default
+	if hasParent {
+		text += "\n" // extra newline for all intermediate (i.e., not last) entries
+	}
+
+	_, err := writer.Write([]byte(text))
+	return err
+}
+
+// formatAnnotations prepares the output for the annotations that apply to an
+// entry, to be displayed below the entry's details.
+func formatAnnotations(annotations []*rsl.AnnotationEntry) string {
+	text := ""
 	for _, annotation := range annotations {
 		text += "\n\n"
 		text += colorer(fmt.Sprintf("    Annotation ID: %s", annotation.ID.String()), green)
@@ -168,16 +590,15 @@ func writeRSLReferenceEntry(writer io.WriteCloser, entry *rsl.ReferenceEntry, an
 		if annotation.Number != 0 {
 			text += fmt.Sprintf("\n    Number:        %d", annotation.Number)
 		}
+		if len(annotation.Data) != 0 {
+			text += "\n    Data:"
+			for _, key := range slices.Sorted(maps.Keys(annotation.Data)) {
+				text += fmt.Sprintf("\n      %s: %s", key, string(annotation.Data[key]))
+			}
+		}
 		text += fmt.Sprintf("\n    Message:\n      %s", strings.TrimSpace(annotation.Message))
 	}
-
-	text += "\n" // single trailing newline by default
-	if hasParent {
-		text += "\n" // extra newline for all intermediate (i.e., not last) entries
-	}
-
-	_, err := writer.Write([]byte(text))
-	return err
+	return text
 }
 
 func writeRSLPropagationEntry(writer io.WriteCloser, entry *rsl.PropagationEntry, hasParent bool) error {
@@ -209,3 +630,70 @@ func writeRSLPropagationEntry(writer io.WriteCloser, entry *rsl.PropagationEntry
 	_, err := writer.Write([]byte(text))
 	return err
 }
+
+func writeRSLHeartbeatEntry(writer io.WriteCloser, entry *rsl.HeartbeatEntry, hasParent bool) error {
+	/* Output format:
+	   heartbeat entry <entryID>
+	     Timestamp: <timestamp>
+	     Number:    <number>
+	*/
+
+	text := colorer(fmt.Sprintf("heartbeat entry %s", entry.ID.String()), yellow)
+	text += "\n"
+
+	text += fmt.Sprintf("\n  Timestamp: %s", entry.Timestamp.Format(time.RFC3339))
+	if entry.Number != 0 {
+		text += fmt.Sprintf("\n  Number:    %d", entry.Number)
+	}
+
+	text += "\n" // single trailing newline by default
+	if hasParent {
+		text += "\n" // extra newline for all intermediate (i.e., not last) entries
+	}
+
+	_, err := writer.Write([]byte(text))
+	return err
+}
+
+func writeRSLCheckpointEntry(writer io.WriteCloser, entry *rsl.CheckpointEntry, hasParent bool) error {
+	/* Output format:
+	   checkpoint entry <entryID>
+	     Policy:       <policyEntryID>
+	     Attestations: <attestationsEntryID>
+	     Ref:          <refName> (<entryID>)
+	     Signatures:   <count>
+	     Number:       <number>
+	*/
+
+	text := colorer(fmt.Sprintf("checkpoint entry %s", entry.ID.String()), yellow)
+	text += "\n"
+
+	text += fmt.Sprintf("\n  Policy:       %s", entry.PolicyEntryID.String())
+	if !entry.AttestationsEntryID.IsZero() {
+		text += fmt.Sprintf("\n  Attestations: %s", entry.AttestationsEntryID.String())
+	}
+
+	refNames := make([]string, 0, len(entry.RefEntryIDs))
+	for refName := range entry.RefEntryIDs {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+	for _, refName := range refNames {
+		text += fmt.Sprintf("\n  Ref:          %s (%s)", refName, entry.RefEntryIDs[refName].String())
+	}
+
+	if entry.Signatures != nil {
+		text += fmt.Sprintf("\n  Signatures:   %d", len(entry.Signatures.Signatures))
+	}
+	if entry.Number != 0 {
+		text += fmt.Sprintf("\n  Number:       %d", entry.Number)
+	}
+
+	text += "\n" // single trailing newline by default
+	if hasParent {
+		text += "\n" // extra newline for all intermediate (i.e., not last) entries
+	}
+
+	_, err := writer.Write([]byte(text))
+	return err
+}
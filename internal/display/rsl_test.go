@@ -5,10 +5,14 @@ package display
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/rsl"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 	"github.com/stretchr/testify/assert"
 )
@@ -158,6 +162,243 @@ entry ae4467eaa656782fe9d04eaabfa30db47e9ea24b (skipped)
 	})
 }
 
+func TestRSLLogWithFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	upstreamRepository := "https://example.com/upstream"
+
+	if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	skippedEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rsl.NewAnnotationEntry([]gitinterface.Hash{skippedEntryID}, true, "msg").Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rsl.NewPropagationEntry("refs/heads/main", gitinterface.ZeroHash, upstreamRepository, gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	propagationEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rsl.NewHeartbeatEntry(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	heartbeatEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rsl.NewReferenceEntry("refs/heads/feature", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	featureEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryTime, err := repo.GetCommitTime(featureEntryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opts             []Option
+		expectedEntryIDs []gitinterface.Hash
+	}{
+		"no filters": {
+			expectedEntryIDs: []gitinterface.Hash{featureEntryID, heartbeatEntryID, propagationEntryID, skippedEntryID},
+		},
+		"filter by ref": {
+			opts:             []Option{WithReferences([]string{"refs/heads/main"})},
+			expectedEntryIDs: []gitinterface.Hash{propagationEntryID, skippedEntryID},
+		},
+		"filter by entry type": {
+			opts:             []Option{WithEntryTypes([]string{RSLEntryTypeHeartbeat, RSLEntryTypePropagation})},
+			expectedEntryIDs: []gitinterface.Hash{heartbeatEntryID, propagationEntryID},
+		},
+		"filter by skipped": {
+			opts:             []Option{WithAnnotationStatus(AnnotationStatusSkipped)},
+			expectedEntryIDs: []gitinterface.Hash{skippedEntryID},
+		},
+		"filter by unskipped": {
+			opts:             []Option{WithAnnotationStatus(AnnotationStatusUnskipped)},
+			expectedEntryIDs: []gitinterface.Hash{featureEntryID, heartbeatEntryID, propagationEntryID},
+		},
+		"filter by propagation source": {
+			opts:             []Option{WithPropagationSources([]string{upstreamRepository})},
+			expectedEntryIDs: []gitinterface.Hash{propagationEntryID},
+		},
+		"filter by unknown propagation source": {
+			opts: []Option{WithPropagationSources([]string{"https://example.com/other"})},
+		},
+		"filter by signer": {
+			opts: []Option{
+				WithSigners([]string{"alice"}),
+				WithEntrySigners(map[string][]string{featureEntryID.String(): {"alice"}, heartbeatEntryID.String(): {"bob"}}),
+			},
+			expectedEntryIDs: []gitinterface.Hash{featureEntryID},
+		},
+		"filter by time range": {
+			opts:             []Option{WithTimeRange(entryTime, entryTime)},
+			expectedEntryIDs: []gitinterface.Hash{featureEntryID, heartbeatEntryID, propagationEntryID, skippedEntryID},
+		},
+		"filter by time range excluding entries": {
+			opts: []Option{WithTimeRange(entryTime.Add(time.Second), time.Time{})},
+		},
+		"filter by ref and entry type": {
+			opts:             []Option{WithReferences([]string{"refs/heads/main"}), WithEntryTypes([]string{RSLEntryTypeReference})},
+			expectedEntryIDs: []gitinterface.Hash{skippedEntryID},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expectedOutput := ""
+			for _, entryID := range test.expectedEntryIDs {
+				expectedOutput += entryID.String() + "\n"
+			}
+
+			output := &bytes.Buffer{}
+			writer := &noopwritecloser{writer: output}
+			err := RSLLog(repo, writer, append(test.opts, WithFormat(FormatTemplate, "{{.ID}}"))...)
+			assert.Nil(t, err)
+			assert.Equal(t, expectedOutput, output.String())
+		})
+	}
+}
+
+func TestRSLLogWithFormats(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	referenceEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{referenceEntryID}, true, "msg")
+	annotation.Data = map[string]json.RawMessage{"incident": json.RawMessage(`"INC-1234"`)}
+	if err := annotation.Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	annotationEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{"refs/heads/main": gitinterface.ZeroHash, "refs/heads/feature": gitinterface.ZeroHash}).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	multiReferenceEntryID, err := repo.GetReference(rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryTime, err := repo.GetCommitTime(multiReferenceEntryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entrySigners := map[string][]string{multiReferenceEntryID.String(): {"alice"}}
+
+	expectedEntries := []*RSLLogEntry{
+		{
+			ID:        multiReferenceEntryID.String(),
+			Type:      RSLEntryTypeMultiReference,
+			Number:    3,
+			Time:      entryTime,
+			TargetIDs: map[string]string{"refs/heads/main": gitinterface.ZeroHash.String(), "refs/heads/feature": gitinterface.ZeroHash.String()},
+			Signers:   []string{"alice"},
+		},
+		{
+			ID:       referenceEntryID.String(),
+			Type:     RSLEntryTypeReference,
+			Number:   1,
+			Time:     entryTime,
+			RefName:  "refs/heads/main",
+			TargetID: gitinterface.ZeroHash.String(),
+			Skipped:  true,
+			Annotations: []*RSLLogAnnotation{
+				{ID: annotationEntryID.String(), Skip: true, Message: "msg", Data: map[string]json.RawMessage{"incident": json.RawMessage(`"INC-1234"`)}, Number: 2},
+			},
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		writer := &noopwritecloser{writer: output}
+		err := RSLLog(repo, writer, WithFormat(FormatJSON, ""), WithEntrySigners(entrySigners))
+		assert.Nil(t, err)
+
+		entries := []*RSLLogEntry{}
+		err = json.Unmarshal(output.Bytes(), &entries)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntries, entries)
+	})
+
+	t.Run("json without matching entries", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		writer := &noopwritecloser{writer: output}
+		err := RSLLog(repo, writer, WithFormat(FormatJSON, ""), WithReferences([]string{"refs/heads/other"}))
+		assert.Nil(t, err)
+		assert.Equal(t, "[]\n", output.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		writer := &noopwritecloser{writer: output}
+		err := RSLLog(repo, writer, WithFormat(FormatNDJSON, ""), WithEntrySigners(entrySigners))
+		assert.Nil(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+		assert.Len(t, lines, len(expectedEntries))
+		for index, line := range lines {
+			entry := &RSLLogEntry{}
+			err := json.Unmarshal([]byte(line), entry)
+			assert.Nil(t, err)
+			assert.Equal(t, expectedEntries[index], entry)
+		}
+	})
+
+	t.Run("template", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		writer := &noopwritecloser{writer: output}
+		err := RSLLog(repo, writer, WithFormat(FormatTemplate, "{{.Number}} {{.Type}} {{.Skipped}}"))
+		assert.Nil(t, err)
+		assert.Equal(t, "3 multi-reference false\n1 reference true\n", output.String())
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		writer := &noopwritecloser{writer: output}
+
+		err := RSLLog(repo, writer, WithFormat("yaml", ""))
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+
+		err = RSLLog(repo, writer, WithFormat(FormatTemplate, ""))
+		assert.ErrorIs(t, err, ErrTemplateNotSpecified)
+
+		err = RSLLog(repo, writer, WithEntryTypes([]string{"annotation"}))
+		assert.ErrorIs(t, err, ErrUnknownRSLEntryType)
+
+		err = RSLLog(repo, writer, WithAnnotationStatus("revoked"))
+		assert.ErrorIs(t, err, ErrUnknownAnnotationStatus)
+
+		assert.Empty(t, output.String())
+	})
+}
+
 func TestWriteRSLReferenceEntry(t *testing.T) {
 	// Set colorer to off for tests
 	colorer = colorerOff
@@ -319,6 +560,42 @@ func TestWriteRSLReferenceEntry(t *testing.T) {
 		assert.Equal(t, expectedOutput, output.String())
 	})
 
+	t.Run("with data annotation, no parent", func(t *testing.T) {
+		entry := rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash)
+		entry.ID = gitinterface.ZeroHash
+		entry.Number = 1
+
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entry.ID}, false, "msg")
+		annotation.ID = gitinterface.ZeroHash
+		annotation.Number = 2
+		annotation.Data = map[string]json.RawMessage{
+			"severity": json.RawMessage(`2`),
+			"incident": json.RawMessage(`"INC-1234"`),
+		}
+
+		expectedOutput := `entry 0000000000000000000000000000000000000000
+
+  Ref:    refs/heads/main
+  Target: 0000000000000000000000000000000000000000
+  Number: 1
+
+    Annotation ID: 0000000000000000000000000000000000000000
+    Skip:          no
+    Number:        2
+    Data:
+      incident: "INC-1234"
+      severity: 2
+    Message:
+      msg
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLReferenceEntry(testWriter, entry, []*rsl.AnnotationEntry{annotation}, false)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+
 	t.Run("with non-skip annotation, no parent", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		repo := gitinterface.CreateTestGitRepository(t, tmpDir, true)
@@ -576,3 +853,160 @@ func TestWriteRSLPropagationEntry(t *testing.T) {
 		assert.Equal(t, expectedOutput, output.String())
 	})
 }
+
+func TestWriteRSLHeartbeatEntry(t *testing.T) {
+	// Set colorer to off for tests
+	colorer = colorerOff
+
+	timestamp := time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC)
+
+	t.Run("simple, without number, without parent", func(t *testing.T) {
+		entry := rsl.NewHeartbeatEntry(timestamp)
+		entry.ID = gitinterface.ZeroHash
+
+		expectedOutput := `heartbeat entry 0000000000000000000000000000000000000000
+
+  Timestamp: 1995-10-26T09:00:00Z
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLHeartbeatEntry(testWriter, entry, false)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+
+	t.Run("simple, with number, with parent", func(t *testing.T) {
+		entry := rsl.NewHeartbeatEntry(timestamp)
+		entry.Number = 1
+		entry.ID = gitinterface.ZeroHash
+
+		expectedOutput := `heartbeat entry 0000000000000000000000000000000000000000
+
+  Timestamp: 1995-10-26T09:00:00Z
+  Number:    1
+
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLHeartbeatEntry(testWriter, entry, true)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+}
+
+func TestWriteRSLCheckpointEntry(t *testing.T) {
+	// Set colorer to off for tests
+	colorer = colorerOff
+
+	entryID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("simple, without number, without parent", func(t *testing.T) {
+		entry := rsl.NewCheckpointEntry(entryID, gitinterface.ZeroHash, map[string]gitinterface.Hash{
+			"refs/tags/v1":    entryID,
+			"refs/heads/main": entryID,
+		})
+		entry.ID = gitinterface.ZeroHash
+
+		expectedOutput := `checkpoint entry 0000000000000000000000000000000000000000
+
+  Policy:       abcdef12345678900987654321fedcbaabcdef12
+  Ref:          refs/heads/main (abcdef12345678900987654321fedcbaabcdef12)
+  Ref:          refs/tags/v1 (abcdef12345678900987654321fedcbaabcdef12)
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLCheckpointEntry(testWriter, entry, false)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+
+	t.Run("with attestations, signatures, number, and parent", func(t *testing.T) {
+		entry := rsl.NewCheckpointEntry(entryID, entryID, nil)
+		entry.Signatures = &sslibdsse.Envelope{Signatures: []sslibdsse.Signature{{KeyID: "test-key"}}}
+		entry.Number = 3
+		entry.ID = gitinterface.ZeroHash
+
+		expectedOutput := `checkpoint entry 0000000000000000000000000000000000000000
+
+  Policy:       abcdef12345678900987654321fedcbaabcdef12
+  Attestations: abcdef12345678900987654321fedcbaabcdef12
+  Signatures:   1
+  Number:       3
+
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLCheckpointEntry(testWriter, entry, true)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+}
+
+func TestWriteRSLMultiReferenceEntry(t *testing.T) {
+	// Set colorer to off for tests
+	colorer = colorerOff
+
+	targetID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("simple, without number, without parent", func(t *testing.T) {
+		entry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			"refs/tags/v1":    targetID,
+			"refs/heads/main": targetID,
+		})
+		entry.ID = gitinterface.ZeroHash
+
+		expectedOutput := `multi-reference entry 0000000000000000000000000000000000000000
+
+  Ref:    refs/heads/main
+  Target: abcdef12345678900987654321fedcbaabcdef12
+  Ref:    refs/tags/v1
+  Target: abcdef12345678900987654321fedcbaabcdef12
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLMultiReferenceEntry(testWriter, entry, nil, false)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+
+	t.Run("with number, skip annotation, and parent", func(t *testing.T) {
+		entry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{"refs/heads/main": targetID})
+		entry.ID = gitinterface.ZeroHash
+		entry.Number = 2
+
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{gitinterface.ZeroHash}, true, "msg")
+		annotation.ID = targetID
+		annotation.Number = 3
+
+		expectedOutput := `multi-reference entry 0000000000000000000000000000000000000000 (skipped)
+
+  Ref:    refs/heads/main
+  Target: abcdef12345678900987654321fedcbaabcdef12
+  Number: 2
+
+    Annotation ID: abcdef12345678900987654321fedcbaabcdef12
+    Skip:          yes
+    Number:        3
+    Message:
+      msg
+
+`
+
+		output := &bytes.Buffer{}
+		testWriter := &noopwritecloser{writer: output}
+		err := writeRSLMultiReferenceEntry(testWriter, entry, []*rsl.AnnotationEntry{annotation}, true)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedOutput, output.String())
+	})
+}
@@ -14,7 +14,6 @@ import (
 	"strings"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
-	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/rsl"
 )
@@ -24,7 +23,7 @@ var ErrFailedAuthentication = errors.New("failed getting remote refs")
 // handleCurl implements the helper for remotes configured to use the curl
 // backend. For this transport, we invoke git-remote-http, only interjecting at
 // specific points to make gittuf specific additions.
-func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url string) (map[string]string, bool, error) {
+func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url string) (_ map[string]string, _ map[string]string, _ bool, err error) {
 	// Scan git-remote-gittuf stdin for commands from the parent process
 	stdInScanner := &logScanner{name: "git-remote-gittuf stdin", scanner: bufio.NewScanner(os.Stdin)}
 	stdInScanner.Split(splitInput)
@@ -33,12 +32,12 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 	// We invoke git-remote-http, itself a Git remote helper
 	helper := exec.Command("git-remote-http", remoteName, url) //nolint:gosec
-	helper.Stderr = os.Stderr
+	helper.Stderr = remoteStderr
 
 	// We want to inspect the helper's stdout for the gittuf ref statuses
 	helperStdOutPipe, err := helper.StdoutPipe()
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 	helperStdOut := &logReadCloser{name: "git-remote-http stdout", readCloser: helperStdOutPipe}
 
@@ -46,17 +45,27 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 	// specific objects and refs
 	helperStdInPipe, err := helper.StdinPipe()
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 	helperStdIn := &logWriteCloser{name: "git-remote-http stdin", writeCloser: helperStdInPipe}
 
 	if err := helper.Start(); err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
+	defer func() {
+		if err != nil {
+			// Messages from the remote, such as why it rejected a
+			// push, are relayed to stderr until git-remote-http exits
+			helperStdIn.Close() //nolint:errcheck
+			helper.Wait()       //nolint:errcheck
+		}
+	}()
 
 	var (
 		gittufRefsTips = map[string]string{}
+		remoteRefTips  = map[string]string{}
 		isPush         bool
+		forcePush      bool
 	)
 
 	for stdInScanner.Scan() {
@@ -75,7 +84,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 			// Write to git-remote-http
 			if _, err := helperStdIn.Write(input); err != nil {
-				return nil, false, err
+				return nil, nil, false, err
 			}
 
 			// Receive the initial info sent by the service via
@@ -96,13 +105,13 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 					output := helperStdOutScanner.Bytes()
 
 					if _, err := stdOutWriter.Write(output); err != nil {
-						return nil, false, err
+						return nil, nil, false, err
 					}
 
 					// If nothing is returned, the user has likely failed to
 					// authenticate with the remote
 					if len(output) == 0 {
-						return nil, false, ErrFailedAuthentication
+						return nil, nil, false, ErrFailedAuthentication
 					}
 
 					// flushPkt is used to indicate the end of
@@ -127,26 +136,26 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 			// refs/gittuf/ as a prefix to learn about the gittuf refs on the
 			// remote during fetches, but only if the client already has
 			// ref-prefixes set.
-			hasRefPrefix := false
+			refPrefixes := []string{}
 			for stdInScanner.Scan() {
 				input = stdInScanner.Bytes()
 
-				if bytes.Contains(input, []byte("ref-prefix")) {
-					hasRefPrefix = true
+				if refPrefix, isRefPrefix := getRefPrefix(input); isRefPrefix {
+					refPrefixes = append(refPrefixes, refPrefix)
 				}
 
 				// Add ref-prefix refs/gittuf/ to the ls-refs command before
 				// flush, but only if the client sent ref-prefixes
-				if bytes.Equal(input, flushPkt) && hasRefPrefix {
+				if bytes.Equal(input, flushPkt) && len(refPrefixes) != 0 {
 					log("adding ref-prefix for refs/gittuf/")
 					gittufRefPrefixCommand := fmt.Sprintf("ref-prefix %s\n", gittufRefPrefix)
 					if _, err := helperStdIn.Write(packetEncode(gittufRefPrefixCommand)); err != nil {
-						return nil, false, err
+						return nil, nil, false, err
 					}
 				}
 
 				if _, err := helperStdIn.Write(input); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 
 				// flushPkt is used to indicate the end of input
@@ -175,14 +184,17 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 					}
 
 					refAdSplit := strings.Split(refAd, " ")
-					if strings.HasPrefix(refAdSplit[1], gittufRefPrefix) {
+					// Git updates the gittuf refs it explicitly fetches
+					// itself
+					if strings.HasPrefix(refAdSplit[1], gittufRefPrefix) && !isRequestedByGit(refAdSplit[1], refPrefixes) {
 						gittufRefsTips[refAdSplit[1]] = refAdSplit[0]
 					}
+					remoteRefTips[refAdSplit[1]] = refAdSplit[0]
 				}
 
 				// Write output to parent process
 				if _, err := stdOutWriter.Write(output); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 
 				// endOfReadPkt indicates end of response
@@ -192,97 +204,29 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 				}
 			}
 
+			// Before Git's fetch, we fetch the objects for the gittuf refs
+			// separately so that we know when they're available to set the
+			// refs
+			if err := fetchGittufObjects(repo, helperStdIn, helperStdOut, gittufRefsTips, endOfReadPkt); err != nil {
+				return nil, nil, false, err
+			}
+
 			// At this point, we enter the haves / wants negotiation, which is
 			// followed usually by the remote sending a packfile with the
 			// requested Git objects.
 
-			// We add the gittuf specific objects as wants. We don't have to
-			// specify haves as Git automatically specifies all the objects it
-			// has regardless of what refs they're reachable via.
-
 			// Read in command from parent process -> this should be
 			// command=fetch with protocol v2
-			var (
-				wroteGittufWantsAndHaves = false // track this in case there are multiple rounds of negotiation
-				wroteWants               = false
-				allWants                 = set.NewSet[string]()
-				allHaves                 = set.NewSet[string]()
-			)
+			wroteWants := false
 			for stdInScanner.Scan() {
 				input = stdInScanner.Bytes()
 
-				switch {
-				case bytes.Equal(input, flushPkt), bytes.Contains(input, []byte("done")):
-					if !wroteGittufWantsAndHaves {
-						// We only write gittuf specific haves and wants when we
-						// haven't already written them. We track this because
-						// in multiple rounds of negotiations, we only want to
-						// write them the first time.
-						log("adding gittuf wants")
-						wants, haves, err := getGittufWantsAndHaves(repo, gittufRefsTips)
-						if err != nil {
-							wants = gittufRefsTips
-						}
-
-						for _, tip := range wants {
-							if !allWants.Has(tip) {
-								// indicate we
-								// want the
-								// gittuf obj
-								wantCmd := fmt.Sprintf("want %s\n", tip)
-								if _, err := helperStdIn.Write(packetEncode(wantCmd)); err != nil {
-									return nil, false, err
-								}
-							}
-						}
-
-						for _, tip := range haves {
-							if !allHaves.Has(tip) {
-								// indicate we
-								// have the
-								// gittuf obj
-								haveCmd := fmt.Sprintf("have %s\n", tip)
-								if _, err := helperStdIn.Write(packetEncode(haveCmd)); err != nil {
-									return nil, false, err
-								}
-							}
-						}
-						wroteGittufWantsAndHaves = true
-					}
-
-					if bytes.Equal(input, flushPkt) {
-						// On a clone, we see `done` and
-						// then flush. We need to write
-						// our wants before done, but
-						// wroteWants can't be set to
-						// true until the next buffer
-						// with flush is written to the
-						// remote.
-						wroteWants = true
-					}
-				case bytes.Contains(input, []byte("want")):
-					idx := bytes.Index(input, []byte("want "))
-					sha := string(bytes.TrimSpace(input[idx+len("want "):]))
-					allWants.Add(sha)
-
-					for ref, tip := range gittufRefsTips {
-						if tip == sha {
-							// Take out this ref as
-							// something for us to
-							// update or add wants
-							// for
-							log("taking out", ref, "as it matches", sha)
-							delete(gittufRefsTips, ref)
-						}
-					}
-				case bytes.Contains(input, []byte("have")):
-					idx := bytes.Index(input, []byte("have "))
-					sha := string(bytes.TrimSpace(input[idx+len("have "):]))
-					allHaves.Add(sha)
+				if bytes.Equal(input, flushPkt) {
+					wroteWants = true
 				}
 
 				if _, err := helperStdIn.Write(input); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 
 				// Read from remote if wants are done
@@ -298,7 +242,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 						// Send along to parent process
 						if _, err := stdOutWriter.Write(output); err != nil {
-							return nil, false, err
+							return nil, nil, false, err
 						}
 
 						if bytes.Equal(output, endOfReadPkt) {
@@ -319,24 +263,13 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 								break
 							}
 
-							for ref, tip := range gittufRefsTips {
-								wantCmd := fmt.Sprintf("want %s", tip)
-								if bytes.Contains(input, []byte(wantCmd)) {
-									// Take out this ref as
-									// something for us to
-									// update or add wants
-									// for
-									delete(gittufRefsTips, ref)
-								}
-							}
-
 							// Having scanned already, we must write prior
 							// to letting the scan continue in the outer
 							// loop
 							// This assumes the very first input isn't just
 							// flush again...
 							if _, err := helperStdIn.Write(input); err != nil {
-								return nil, false, err
+								return nil, nil, false, err
 							}
 							wroteWants = false
 							break
@@ -361,7 +294,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 			// Write it to git-remote-http
 			if _, err := helperStdIn.Write(input); err != nil {
-				return nil, false, err
+				return nil, nil, false, err
 			}
 
 			// Read remote refs
@@ -374,7 +307,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 				// If nothing is returned, the user has likely failed to
 				// authenticate with the remote
 				if len(output) == 0 {
-					return nil, false, ErrFailedAuthentication
+					return nil, nil, false, ErrFailedAuthentication
 				}
 
 				refAdSplit := strings.Split(strings.TrimSpace(string(output)), " ")
@@ -387,7 +320,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 				// Pass remote ref status to parent process
 				if _, err := stdOutWriter.Write(output); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 
 				// flushPkt indicates end of message
@@ -413,7 +346,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 
 			if len(gittufRefsTips) != 0 {
 				if err := repo.ReconcileLocalRSLWithRemote(ctx, remoteName, true); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 			}
 
@@ -421,45 +354,44 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 			// to pass the response from the server for those refs
 			// back to Git
 			dstRefs := set.NewSet[string]()
-			for _, pushCommand := range pushCommands {
-				// TODO: maybe find another way to determine
-				// whether repo is gittuf enabled
-				// The remote may not have gittuf refs but the
-				// local may, meaning this won't get synced
-				if len(gittufRefsTips) != 0 {
-					pushCommandString := string(pushCommand)
-					pushCommandString = strings.TrimSpace(pushCommandString)
-
-					refSpec := strings.TrimPrefix(pushCommandString, "push ")
-					refSpecSplit := strings.Split(refSpec, ":")
-					if len(refSpecSplit) < 2 {
-						return nil, false, fmt.Errorf("invalid refspec %q: expected format src:dst", refSpec)
-					}
 
-					srcRef := refSpecSplit[0]
-					srcRef = strings.TrimPrefix(srcRef, "+") // force push
-					// TODO: during a force push, we want to also revoke prior
-					// pushes
+			// TODO: maybe find another way to determine whether repo is
+			// gittuf enabled
+			// The remote may not have gittuf refs but the local may,
+			// meaning this won't get synced
+			if len(gittufRefsTips) != 0 {
+				pushRefSpecs := make([]string, 0, len(pushCommands))
+				for _, pushCommand := range pushCommands {
+					pushRefSpecs = append(pushRefSpecs, strings.TrimPrefix(strings.TrimSpace(string(pushCommand)), "push "))
+				}
 
-					dstRef := refSpecSplit[1]
+				// TODO: during a force push, we want to also revoke prior
+				// pushes
+				pushSrcRefs, pushDstRefs, err := parsePushRefSpecs(pushRefSpecs)
+				if err != nil {
+					return nil, nil, false, err
+				}
+				for _, dstRef := range pushDstRefs {
 					dstRefs.Add(dstRef)
+				}
 
-					if !strings.HasPrefix(dstRef, gittufRefPrefix) {
-						// Create RSL entries for the ref as long as it's not a
-						// gittuf ref
-						// A gittuf ref can pop up here when it's explicitly
-						// pushed by the user
-
-						// TODO: skipping propagation; invoke it once total instead of per ref
-						if err := repo.RecordRSLEntryForReference(ctx, srcRef, true, rslopts.WithOverrideRefName(dstRef), rslopts.WithSkipCheckForDuplicateEntry(), rslopts.WithRecordLocalOnly()); err != nil {
-							return nil, false, err
-						}
+				rejections, err := recordPushInRSL(ctx, repo, pushSrcRefs, pushDstRefs, remoteRefTips, forcePush)
+				if err != nil {
+					return nil, nil, false, err
+				}
+				if len(rejections) != 0 {
+					// Nothing has been sent to git-remote-http for the push
+					if err := rejectPush(stdOutWriter, pushDstRefs, rejections); err != nil {
+						return nil, nil, false, err
 					}
+					continue
 				}
+			}
 
+			for _, pushCommand := range pushCommands {
 				// Write push command to helper
 				if _, err := helperStdIn.Write(pushCommand); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 			}
 
@@ -467,13 +399,13 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 				// Push RSL if it hasn't been explicitly pushed
 				pushCommand := fmt.Sprintf("push %s:%s\n", rsl.Ref, rsl.Ref)
 				if _, err := helperStdIn.Write([]byte(pushCommand)); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 			}
 
 			// Indicate end of push statements
 			if _, err := helperStdIn.Write([]byte("\n")); err != nil {
-				return nil, false, err
+				return nil, nil, false, err
 			}
 
 			seenTrailingNewLine := false
@@ -504,14 +436,14 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 						// if it does, just send it back
 						// to the caller
 						if _, err := stdOutWriter.Write(output); err != nil {
-							return nil, false, err
+							return nil, nil, false, err
 						}
 					} else {
 						if dstRefs.Has(strings.TrimSpace(string(outputSplit[1]))) {
 							// this was explicitly
 							// pushed by the user
 							if _, err := stdOutWriter.Write(output); err != nil {
-								return nil, false, err
+								return nil, nil, false, err
 							}
 						}
 					}
@@ -526,11 +458,21 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 					break
 				}
 			}
+		case bytes.Equal(bytes.TrimSpace(input), []byte(fmt.Sprintf("option push-option %s", forcePushOption))):
+			// This push option is meant for us, so we don't pass it on to the
+			// curl helper
+			log("cmd: option push-option", forcePushOption)
+
+			forcePush = true
+			if _, err := stdOutWriter.Write([]byte("ok\n")); err != nil {
+				return nil, nil, false, err
+			}
+
 		default:
 			// Pass through other commands we don't want to interpose to the
 			// curl helper
 			if _, err := helperStdIn.Write(input); err != nil {
-				return nil, false, err
+				return nil, nil, false, err
 			}
 
 			// Receive the initial info sent by the service
@@ -541,7 +483,7 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 				output := helperStdOutScanner.Bytes()
 
 				if _, err := stdOutWriter.Write(output); err != nil {
-					return nil, false, err
+					return nil, nil, false, err
 				}
 
 				// Check for end of message
@@ -553,16 +495,16 @@ func handleCurl(ctx context.Context, repo *gittuf.Repository, remoteName, url st
 	}
 
 	if err := helperStdIn.Close(); err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	if err := helperStdOut.Close(); err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	if err := helper.Wait(); err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
-	return gittufRefsTips, isPush, nil
+	return gittufRefsTips, remoteRefTips, isPush, nil
 }
@@ -0,0 +1,77 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+)
+
+// gitDaemonDefaultPort is the port git daemon listens on by default.
+const gitDaemonDefaultPort = "9418"
+
+// gitDaemonConnWriter closes only the writing side of the connection to git
+// daemon when the end of input for the service is indicated, so that the
+// service's remaining output can still be read.
+type gitDaemonConnWriter struct {
+	conn *net.TCPConn
+}
+
+func (g *gitDaemonConnWriter) Write(p []byte) (int, error) {
+	return g.conn.Write(p)
+}
+
+func (g *gitDaemonConnWriter) Close() error {
+	return g.conn.CloseWrite()
+}
+
+// handleGitDaemon implements the helper for remotes served by git daemon using
+// git:// URLs. For this transport, we connect to the daemon directly and
+// request the Git service for the repository, after which we interact with the
+// service as we do for SSH remotes.
+func handleGitDaemon(ctx context.Context, repo *gittuf.Repository, remoteName, url string) (map[string]string, map[string]string, bool, error) {
+	url = strings.TrimPrefix(url, "git://")
+
+	host, repository, hasRepository := strings.Cut(url, "/") // host is host[:port]
+	if !hasRepository || host == "" || repository == "" {
+		return nil, nil, false, fmt.Errorf("invalid git:// URL %q: expected format git://host[:port]/repository", url)
+	}
+	repository = "/" + repository
+
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(strings.Trim(host, "[]"), gitDaemonDefaultPort)
+	}
+
+	connectService := func(service string) (*serviceConnection, error) {
+		dialer := &net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return nil, err
+		}
+		tcpConn, isTCPConn := conn.(*net.TCPConn)
+		if !isTCPConn {
+			conn.Close() //nolint:errcheck
+			return nil, fmt.Errorf("unexpected connection type %T for git daemon", conn)
+		}
+
+		// The request identifies the service and repository, and includes
+		// the extra parameter to request Git protocol v2
+		request := fmt.Sprintf("%s %s\x00host=%s\x00\x00version=2\x00", service, repository, host)
+		if _, err := tcpConn.Write(packetEncode(request)); err != nil {
+			tcpConn.Close() //nolint:errcheck
+			return nil, err
+		}
+
+		// git daemon runs the service on its end, there's nothing for us to
+		// wait on once the connection is closed
+		return &serviceConnection{stdOut: tcpConn, stdIn: &gitDaemonConnWriter{conn: tcpConn}, wait: func() error { return nil }}, nil
+	}
+
+	return handleService(ctx, repo, remoteName, "git", connectService)
+}
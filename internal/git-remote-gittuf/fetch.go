@@ -0,0 +1,281 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const (
+	sideBandData  = 1
+	sideBandError = 3
+)
+
+var ErrUnableToFetchGittufObjects = errors.New("unable to fetch gittuf objects from remote")
+
+// fetchGittufObjects fetches the objects for the gittuf refs in gittufRefsTips
+// that are not available locally. Instead of adding these objects to the fetch
+// requested by Git, the transport sends its own protocol v2 fetch request to
+// the remote service and indexes the packfile in the response itself. As a
+// result, the objects have been written to the repository when this returns,
+// and the gittuf refs can be set without waiting on Git, which only indexes
+// the packfile it requested after the transport has seen the end of the
+// response. endOfResponsePkt is the packet that marks the end of a response
+// from the remote service.
+func fetchGittufObjects(repo *gittuf.Repository, helperStdIn io.Writer, helperStdOut io.Reader, gittufRefsTips map[string]string, endOfResponsePkt []byte) error {
+	gitRepo := repo.GetGitRepository()
+
+	wants, haves, err := getGittufWantsAndHaves(repo, gittufRefsTips)
+	if err != nil {
+		return err
+	}
+
+	wantTips := set.NewSet[string]()
+	for _, tip := range wants {
+		tipHash, err := gitinterface.NewHash(tip)
+		if err != nil {
+			return err
+		}
+		if !gitRepo.HasObject(tipHash) {
+			wantTips.Add(tip)
+		}
+	}
+	if wantTips.Len() == 0 {
+		log("gittuf objects are available locally")
+		return nil
+	}
+
+	log("fetching gittuf objects")
+	request := [][]byte{
+		packetEncode("command=fetch\n"),
+		packetEncode(fmt.Sprintf("agent=git/%s\n", gitVersion)),
+		packetEncode(fmt.Sprintf("object-format=%s\n", gitRepo.GetObjectFormat())),
+		delimiterPkt,
+		packetEncode("no-progress\n"),
+		packetEncode("ofs-delta\n"),
+	}
+	for _, tip := range wantTips.Contents() {
+		request = append(request, packetEncode(fmt.Sprintf("want %s\n", tip)))
+	}
+	for _, tip := range haves {
+		request = append(request, packetEncode(fmt.Sprintf("have %s\n", tip)))
+	}
+	request = append(request, packetEncode("done\n"), flushPkt)
+
+	for _, pkt := range request {
+		if _, err := helperStdIn.Write(pkt); err != nil {
+			return err
+		}
+	}
+
+	indexPack := exec.Command("git", "index-pack", "--stdin")
+	indexPack.Stderr = os.Stderr
+	indexPackStdIn, err := indexPack.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := indexPack.Start(); err != nil {
+		return err
+	}
+
+	// The response must be read in full so that the service is ready for the
+	// next request, even if indexing the packfile fails
+	var responseErr error
+	packfileSeen := false
+	helperStdOutScanner := bufio.NewScanner(helperStdOut)
+	helperStdOutScanner.Split(splitPacket)
+	for helperStdOutScanner.Scan() {
+		output := helperStdOutScanner.Bytes()
+
+		if bytes.Equal(output, endOfResponsePkt) {
+			break
+		}
+		if len(output) <= 4 || responseErr != nil {
+			continue
+		}
+
+		line := output[4:] // remove pkt length prefix
+		if bytes.HasPrefix(line, []byte("ERR ")) {
+			responseErr = fmt.Errorf("%w: %s", ErrUnableToFetchGittufObjects, strings.TrimSpace(string(line[4:])))
+			continue
+		}
+
+		if !packfileSeen {
+			// The packfile section is the last in the response
+			packfileSeen = bytes.Equal(line, []byte("packfile\n"))
+			continue
+		}
+
+		// The packfile is always multiplexed using the sideband in
+		// protocol v2
+		switch line[0] {
+		case sideBandData:
+			if _, err := indexPackStdIn.Write(line[1:]); err != nil {
+				responseErr = err
+			}
+		case sideBandError:
+			responseErr = fmt.Errorf("%w: %s", ErrUnableToFetchGittufObjects, strings.TrimSpace(string(line[1:])))
+		}
+	}
+	if err := helperStdOutScanner.Err(); err != nil && responseErr == nil {
+		responseErr = err
+	}
+	if !packfileSeen && responseErr == nil {
+		responseErr = fmt.Errorf("%w: packfile not received", ErrUnableToFetchGittufObjects)
+	}
+
+	if err := indexPackStdIn.Close(); err != nil && responseErr == nil {
+		responseErr = err
+	}
+	if err := indexPack.Wait(); err != nil && responseErr == nil {
+		responseErr = err
+	}
+
+	return responseErr
+}
+
+// getGittufRefTips returns the current tips of the local gittuf refs.
+func getGittufRefTips(repo *gittuf.Repository) (map[string]gitinterface.Hash, error) {
+	gitRepo := repo.GetGitRepository()
+
+	refNames, err := gitRepo.ListReferences(gittufRefPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	gittufRefTips := map[string]gitinterface.Hash{}
+	for _, refName := range refNames {
+		tip, err := gitRepo.GetReference(refName)
+		if err != nil {
+			return nil, err
+		}
+		gittufRefTips[refName] = tip
+	}
+
+	return gittufRefTips, nil
+}
+
+// getGitFetchedGittufRefTips returns the fetched tips of the gittuf refs that
+// Git explicitly fetched, i.e., the gittuf refs advertised by the remote that
+// the transport does not update itself and whose tips are now available
+// locally. Git updates the corresponding local refs as per its refspecs once
+// the transport exits.
+func getGitFetchedGittufRefTips(repo *gittuf.Repository, gittufRefsTips, remoteRefTips map[string]string) (map[string]string, error) {
+	gitRepo := repo.GetGitRepository()
+
+	gitFetchedGittufRefTips := map[string]string{}
+	for refName, tip := range remoteRefTips {
+		if !strings.HasPrefix(refName, gittufRefPrefix) {
+			continue
+		}
+		if _, has := gittufRefsTips[refName]; has {
+			continue
+		}
+
+		tipHash, err := gitinterface.NewHash(tip)
+		if err != nil {
+			return nil, err
+		}
+		if !gitRepo.HasObject(tipHash) {
+			continue
+		}
+
+		gitFetchedGittufRefTips[refName] = tip
+	}
+
+	return gitFetchedGittufRefTips, nil
+}
+
+// setGittufRefs sets each local gittuf ref in gittufRefsTips to its fetched
+// tip, unless it is already at that tip. A ref is only set if it is still at
+// its tip prior to the fetch as recorded in priorGittufRefTips, so that a
+// concurrent fetch that has already updated it is not undone. Refs that cannot
+// be set are reported on stderr. The names of the refs that were set are
+// returned.
+func setGittufRefs(repo *gittuf.Repository, gittufRefsTips map[string]string, priorGittufRefTips map[string]gitinterface.Hash) ([]string, error) {
+	gitRepo := repo.GetGitRepository()
+
+	refNames := make([]string, 0, len(gittufRefsTips))
+	for refName := range gittufRefsTips {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	setRefNames := []string{}
+	for _, refName := range refNames {
+		tip, err := gitinterface.NewHash(gittufRefsTips[refName])
+		if err != nil {
+			return nil, err
+		}
+
+		currentTip, err := gitRepo.GetReference(refName)
+		if err != nil && !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return nil, err
+		}
+		if err == nil && currentTip.Equal(tip) {
+			// When Git does not have to fetch any objects, it updates the
+			// local refs before the transport exits
+			continue
+		}
+
+		priorTip, has := priorGittufRefTips[refName]
+		if !has {
+			// The ref must not exist
+			priorTip = gitRepo.ZeroHash()
+		}
+
+		if err := gitRepo.CheckAndSetReference(refName, tip, priorTip); err != nil {
+			if currentTip, getErr := gitRepo.GetReference(refName); getErr == nil && currentTip.Equal(tip) {
+				// A concurrent fetch set the ref to the same tip
+				continue
+			}
+
+			msg := fmt.Sprintf("Unable to set reference '%s': '%s'", refName, err.Error())
+			log(msg)
+			fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", msg) //nolint:errcheck
+			continue
+		}
+		setRefNames = append(setRefNames, refName)
+	}
+
+	return setRefNames, nil
+}
+
+// restoreGittufRefs reverts the local gittuf refs in refNames to their tips
+// prior to the fetch. This is used for the gittuf refs Git explicitly fetched,
+// which are set temporarily to verify the fetched refs. Git expects these refs
+// to be at their prior tips when it updates them.
+func restoreGittufRefs(repo *gittuf.Repository, refNames []string, priorGittufRefTips map[string]gitinterface.Hash) error {
+	gitRepo := repo.GetGitRepository()
+
+	for _, refName := range refNames {
+		priorTip, has := priorGittufRefTips[refName]
+		if !has {
+			log("deleting", refName, "as it did not exist prior to the fetch")
+			if err := gitRepo.DeleteReference(refName); err != nil && !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+				return err
+			}
+			continue
+		}
+
+		log("restoring", refName, "to", priorTip.String())
+		if err := gitRepo.SetReference(refName, priorTip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
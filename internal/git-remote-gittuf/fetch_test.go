@@ -0,0 +1,112 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGitFetchedGittufRefTips(t *testing.T) {
+	repoDir := t.TempDir()
+	gitRepo := gitinterface.CreateTestGitRepository(t, repoDir, false)
+	repo, err := gittuf.LoadRepository(repoDir)
+	require.Nil(t, err)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 2, rootKeyBytes)
+	missingTip := "ffffffffffffffffffffffffffffffffffffffff"
+
+	gittufRefsTips := map[string]string{
+		"refs/gittuf/reference-state-log": commitIDs[0].String(),
+	}
+	remoteRefTips := map[string]string{
+		"refs/heads/main":                 commitIDs[1].String(),
+		"refs/gittuf/reference-state-log": commitIDs[0].String(),
+		"refs/gittuf/policy":              commitIDs[1].String(),
+		"refs/gittuf/attestations":        missingTip,
+	}
+
+	// Only the gittuf refs Git fetched itself whose tips are available
+	// locally are returned
+	gitFetchedGittufRefTips, err := getGitFetchedGittufRefTips(repo, gittufRefsTips, remoteRefTips)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"refs/gittuf/policy": commitIDs[1].String()}, gitFetchedGittufRefTips)
+}
+
+func TestSetGittufRefs(t *testing.T) {
+	repoDir := t.TempDir()
+	gitRepo := gitinterface.CreateTestGitRepository(t, repoDir, false)
+	repo, err := gittuf.LoadRepository(repoDir)
+	require.Nil(t, err)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 3, rootKeyBytes)
+
+	updatedRef := "refs/gittuf/updated"
+	newRef := "refs/gittuf/new"
+	unchangedRef := "refs/gittuf/unchanged"
+	concurrentlyUpdatedRef := "refs/gittuf/concurrently-updated"
+
+	require.Nil(t, gitRepo.SetReference(updatedRef, commitIDs[0]))
+	require.Nil(t, gitRepo.SetReference(unchangedRef, commitIDs[1]))
+	// A concurrent fetch moved the ref after its prior tip was recorded
+	require.Nil(t, gitRepo.SetReference(concurrentlyUpdatedRef, commitIDs[2]))
+
+	priorGittufRefTips := map[string]gitinterface.Hash{
+		updatedRef:             commitIDs[0],
+		unchangedRef:           commitIDs[1],
+		concurrentlyUpdatedRef: commitIDs[0],
+	}
+	gittufRefsTips := map[string]string{
+		updatedRef:             commitIDs[1].String(),
+		newRef:                 commitIDs[1].String(),
+		unchangedRef:           commitIDs[1].String(),
+		concurrentlyUpdatedRef: commitIDs[1].String(),
+	}
+
+	setRefNames, err := setGittufRefs(repo, gittufRefsTips, priorGittufRefTips)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{newRef, updatedRef}, setRefNames)
+
+	for _, refName := range []string{updatedRef, newRef, unchangedRef} {
+		tip, err := gitRepo.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, commitIDs[1], tip, refName)
+	}
+
+	// The concurrent fetch's update is not undone
+	tip, err := gitRepo.GetReference(concurrentlyUpdatedRef)
+	require.Nil(t, err)
+	assert.Equal(t, commitIDs[2], tip)
+}
+
+func TestRestoreGittufRefs(t *testing.T) {
+	repoDir := t.TempDir()
+	gitRepo := gitinterface.CreateTestGitRepository(t, repoDir, false)
+	repo, err := gittuf.LoadRepository(repoDir)
+	require.Nil(t, err)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 2, rootKeyBytes)
+
+	existingRef := "refs/gittuf/existing"
+	newRef := "refs/gittuf/new"
+	require.Nil(t, gitRepo.SetReference(existingRef, commitIDs[1]))
+	require.Nil(t, gitRepo.SetReference(newRef, commitIDs[1]))
+
+	priorGittufRefTips := map[string]gitinterface.Hash{existingRef: commitIDs[0]}
+
+	err = restoreGittufRefs(repo, []string{existingRef, newRef}, priorGittufRefTips)
+	assert.Nil(t, err)
+
+	tip, err := gitRepo.GetReference(existingRef)
+	require.Nil(t, err)
+	assert.Equal(t, commitIDs[0], tip)
+
+	_, err = gitRepo.GetReference(newRef)
+	assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+}
@@ -0,0 +1,64 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+)
+
+var ErrNonLocalFileURL = errors.New("file:// URL does not refer to the local host")
+
+// handleFile implements the helper for remotes that are local repositories,
+// specified either as a path or a file:// URL. For this transport, we invoke
+// git-upload-pack and git-receive-pack directly on the repository.
+func handleFile(ctx context.Context, repo *gittuf.Repository, remoteName, url string) (map[string]string, map[string]string, bool, error) {
+	repository, err := getLocalRepositoryPath(url)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	connectService := func(service string) (*serviceConnection, error) {
+		helper := exec.Command(service, repository) //nolint:gosec
+		// Add env var for GIT_PROTOCOL v2
+		helper.Env = append(os.Environ(), "GIT_PROTOCOL=version=2")
+		return startServiceCommand(helper)
+	}
+
+	return handleService(ctx, repo, remoteName, "file", connectService)
+}
+
+// getLocalRepositoryPath returns the path of the local repository specified
+// either as a path or a file:// URL. The URL's host must be empty or
+// localhost, and its path is percent-decoded.
+func getLocalRepositoryPath(remoteURL string) (string, error) {
+	if !strings.HasPrefix(remoteURL, "file://") {
+		return remoteURL, nil
+	}
+
+	parsedURL, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	if parsedURL.Host != "" && parsedURL.Host != "localhost" {
+		return "", fmt.Errorf("%w: %s", ErrNonLocalFileURL, parsedURL.Host)
+	}
+
+	return parsedURL.Path, nil
+}
+
+// isLocalPath returns true if the remote URL is a path that exists locally,
+// such as ./repository or ../repository.
+func isLocalPath(remoteURL string) bool {
+	_, err := os.Stat(remoteURL)
+	return err == nil
+}
@@ -0,0 +1,74 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocalRepositoryPath(t *testing.T) {
+	tests := map[string]struct {
+		url          string
+		expectedPath string
+		expectedErr  error
+	}{
+		"path": {
+			url:          "/srv/repos/gittuf.git",
+			expectedPath: "/srv/repos/gittuf.git",
+		},
+		"relative path": {
+			url:          "../gittuf.git",
+			expectedPath: "../gittuf.git",
+		},
+		"file URL": {
+			url:          "file:///srv/repos/gittuf.git",
+			expectedPath: "/srv/repos/gittuf.git",
+		},
+		"file URL for localhost": {
+			url:          "file://localhost/srv/repos/gittuf.git",
+			expectedPath: "/srv/repos/gittuf.git",
+		},
+		"percent-encoded file URL": {
+			url:          "file:///srv/my%20repos/gittuf.git",
+			expectedPath: "/srv/my repos/gittuf.git",
+		},
+		"file URL for another host": {
+			url:         "file://example.com/srv/repos/gittuf.git",
+			expectedErr: ErrNonLocalFileURL,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, err := getLocalRepositoryPath(test.url)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+func TestIsLocalPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.Nil(t, os.Mkdir(filepath.Join(tmpDir, "repository.git"), 0o755))
+
+	currentDir, err := os.Getwd()
+	require.Nil(t, err)
+	require.Nil(t, os.Chdir(tmpDir))
+	defer os.Chdir(currentDir) //nolint:errcheck
+
+	assert.True(t, isLocalPath("./repository.git"))
+	assert.True(t, isLocalPath("repository.git"))
+	assert.False(t, isLocalPath("./missing.git"))
+	assert.False(t, isLocalPath("git@github.com:gittuf/gittuf"))
+}
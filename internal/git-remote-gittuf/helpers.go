@@ -6,6 +6,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
 	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
 type logWriteCloser struct {
@@ -135,12 +137,45 @@ func packetEncode(str string) []byte {
 	return []byte(fmt.Sprintf("%04x%s", 4+len(str), str))
 }
 
+// getRefPrefix returns the prefix in a ref-prefix argument sent by Git with
+// the ls-refs command. The boolean is false if input is not such an argument.
+func getRefPrefix(input []byte) (string, bool) {
+	if len(input) <= 4 {
+		return "", false
+	}
+
+	line := strings.TrimSpace(string(input[4:])) // remove pkt length prefix
+	if !strings.HasPrefix(line, "ref-prefix ") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(line, "ref-prefix "), true
+}
+
+// isRequestedByGit returns true if refName matches one of the ref-prefixes
+// sent by Git with the ls-refs command. This indicates that a refspec Git was
+// invoked with covers refName, and so Git updates the corresponding local ref
+// itself after the fetch.
+func isRequestedByGit(refName string, refPrefixes []string) bool {
+	for _, refPrefix := range refPrefixes {
+		if strings.HasPrefix(refName, refPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getGittufWantsAndHaves(repo *gittuf.Repository, remoteTips map[string]string) (map[string]string, []string, error) {
 	wants := map[string]string{}
 	currentTips := set.NewSet[string]()
 	for remoteRef, tip := range remoteTips {
 		currentTip, err := repo.GetGitRepository().GetReference(remoteRef)
 		if err != nil {
+			if errors.Is(err, gitinterface.ErrReferenceNotFound) {
+				wants[remoteRef] = tip
+				continue
+			}
 			return nil, nil, err
 		}
 
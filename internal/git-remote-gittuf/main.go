@@ -9,11 +9,9 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/gittuf/gittuf/experimental/gittuf"
-	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
 
 /*
@@ -42,8 +40,10 @@ import (
 		* git-remote-gittuf must be in PATH.
 		* A remote must be configured to use `gittuf::` as the prefix. The
 		  result of the remote URL must indicate the underlying transport
-		  mechanism. Example: `gittuf::https://github.com/gittuf/gittuf` and
-		  `gittuf::git@github.com:gittuf/gittuf`
+		  mechanism. Example: `gittuf::https://github.com/gittuf/gittuf`,
+		  `gittuf::git@github.com:gittuf/gittuf`,
+		  `gittuf::git://git.example.com/gittuf.git`,
+		  `gittuf::/srv/repos/gittuf.git`, and `gittuf::../gittuf.git`
 
 	Invocation:
 	During an interaction with a remote configured using the gittuf:: prefix,
@@ -56,7 +56,10 @@ import (
 	is HTTP(s) / FTP(s), we just invoke git-remote-http and relay its
 	capabilities back to Git. When the underlying transport is SSH, we advertise
 	a lightweight set of capabilities to ensure Git chooses the one we want it
-	to for both cases.
+	to for both cases. Local repositories, specified using a path or a file://
+	URL for the local host, are handled like SSH remotes, except that
+	git-upload-pack and git-receive-pack are invoked directly. Similarly, for
+	git:// URLs, we connect to git daemon and request these services from it.
 
 	Anatomy of a fetch:
 		* Git invokes stateless-connect (protocol v2) to communicate with
@@ -65,18 +68,26 @@ import (
 		  the remote has and what their tips point to
 		  Note: we interpose this to learn the status of gittuf refs on the
 		  remote
+		* git-remote-gittuf sends a separate fetch command to git-upload-pack
+		  for the gittuf specific objects, and indexes the packfile it receives
 		* Git negotiates with git-upload-pack the objects it wants based on the
 		  refs that must be fetched
-		  Note: we interpose this to request gittuf specific objects as well
 		* The remote sends a packfile with the requested objects
 		* git-remote-gittuf uses update-ref to set the local gittuf refs, as Git
-		  will not do this for us
+		  will not do this for us, unless Git was asked to fetch them
+		* git-remote-gittuf verifies the fetched branches and tags using the
+		  fetched RSL and policy; depending on gittuf.transport.verification,
+		  failures are either reported or held in refs/gittuf/quarantine/
+		  instead of updating the remote-tracking refs
 
 	Anatomy of a push:
 		* Git invokes list for-push (protocol v0/v1) to list the refs available
 		  on the remote
 		* Git invokes push (protocol v0/v1) to indicate what refs must be
 		  updated to on the remote
+		* git-remote-gittuf records RSL entries for the pushed refs and checks
+		  that the push meets the policy; unless the gittuf-force push option
+		  is set, a push that does not is refused and the RSL is reset
 		* A packfile is created and streamed to git-receive-pack on the server
 */
 
@@ -109,14 +120,20 @@ func run(ctx context.Context) error {
 	remoteName := os.Args[1]
 	url := os.Args[2]
 
-	var handler func(context.Context, *gittuf.Repository, string, string) (map[string]string, bool, error)
+	var handler func(context.Context, *gittuf.Repository, string, string) (map[string]string, map[string]string, bool, error)
 	switch {
 	case strings.HasPrefix(url, "https://"), strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "ftp://"), strings.HasPrefix(url, "ftps://"):
 		log("Prefix indicates curl remote helper must be used")
 		handler = handleCurl
 	case strings.HasPrefix(url, "/"), strings.HasPrefix(url, "file://"):
 		log("Prefix indicates file helper must be used")
-		return nil
+		handler = handleFile
+	case strings.HasPrefix(url, "git://"):
+		log("Prefix indicates git daemon helper must be used")
+		handler = handleGitDaemon
+	case isLocalPath(url):
+		log("Local path indicates file helper must be used")
+		handler = handleFile
 	default:
 		log("Using ssh helper")
 		handler = handleSSH
@@ -127,76 +144,61 @@ func run(ctx context.Context) error {
 		log("Unable to load repository")
 		return err
 	}
-	gitDir := repo.GetGitRepository().GetGitDir()
 
-	gittufRefsTips, isPush, err := handler(ctx, repo, remoteName, url)
+	verificationMode, err := getVerificationMode(repo)
 	if err != nil {
 		return err
 	}
 
-	for {
-		// When cloning/fetching, we have to hang until Git sets things up
-		// before we can update-ref
-		entries, err := os.ReadDir(filepath.Join(gitDir, "objects", "pack"))
-		if err != nil {
+	// Record the state of the local refs a fetch may update so that updates
+	// that fail verification can be reverted
+	trackingRefTips, err := getTrackingRefTips(repo, remoteName)
+	if err != nil {
+		return err
+	}
+
+	// Record the state of the local gittuf refs so that they are only
+	// updated if a concurrent fetch hasn't already updated them
+	priorGittufRefTips, err := getGittufRefTips(repo)
+	if err != nil {
+		return err
+	}
+
+	gittufRefsTips, remoteRefTips, isPush, err := handler(ctx, repo, remoteName, url)
+	if err != nil {
+		return err
+	}
+
+	// When fetching, the handler has written the objects for the gittuf refs
+	// to the repository before returning, so the gittuf refs can be set
+	// without waiting for Git to index the packfile it requested
+	if !isPush {
+		if _, err := setGittufRefs(repo, gittufRefsTips, priorGittufRefTips); err != nil {
 			return err
 		}
 
-		if len(entries) == 0 {
-			break
+		// The gittuf refs Git explicitly fetched are set temporarily so that
+		// the fetched refs are verified using the fetched RSL and policy
+		gitFetchedGittufRefTips, err := getGitFetchedGittufRefTips(repo, gittufRefsTips, remoteRefTips)
+		if err != nil {
+			return err
+		}
+		gitFetchedGittufRefNames, err := setGittufRefs(repo, gitFetchedGittufRefTips, priorGittufRefTips)
+		if err != nil {
+			return err
 		}
 
-		// `entries` is sorted by name. The "regular" entries have pack- as a
-		// prefix. When actually fetching contents, it's stored in a tmp_pack or
-		// temp_rev file. Therefore, if the last entry starts with pack-, we
-		// know we don't have a tmp_ file.
+		verificationErr := verifyFetchedRefs(ctx, repo, remoteName, verificationMode, remoteRefTips, trackingRefTips)
 
-		lastEntryName := entries[len(entries)-1].Name()
-		if strings.HasPrefix(lastEntryName, "pack-") { // not tmp_pack or tmp_rev
-			break
+		if err := restoreGittufRefs(repo, gitFetchedGittufRefNames, priorGittufRefTips); err != nil {
+			return err
 		}
-	}
 
-	if !isPush {
-		// TODO: this breaks when `git fetch` is invoked explicitly for a gittuf
-		// ref because Git separately tries to update-ref.
-		// During wants, check if the latest remote gittuf ref tips are
-		// requested. Use _only_ the latest so as to avoid any unnecessary blob
-		// collisions.
-		for ref, tip := range gittufRefsTips {
-			tipH, err := gitinterface.NewHash(tip)
-			if err != nil {
-				return err
-			}
-			if err := repo.GetGitRepository().SetReference(ref, tipH); err != nil {
-				msg := fmt.Sprintf("Unable to set reference '%s': '%s'", ref, err.Error())
-				log(msg)
-				fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", msg) //nolint:errcheck
-			}
+		if verificationErr != nil {
+			return verificationErr
 		}
-
-		// Uncomment after gittuf can accept a git_dir env var; this will happen
-		// with the gitinterface PRs naturally.
-
-		// TODO: this must either be looped to address each changed ref that
-		// exists locally or gittuf needs another flag for --all.
-		// var cmd *exec.Cmd
-		// if rslTip != "" {
-		// 	log("we have rsl tip")
-		// 	cmd = exec.Command("gittuf", "verify-ref", "--from-entry", rslTip, "HEAD")
-		// } else {
-		// 	cwd, _ := os.Getwd()
-		// 	log("we don't have rsl tip", cwd)
-		// 	cmd = exec.Command("gittuf", "verify-ref", "HEAD")
-		// }
-		// _, err := cmd.Output()
-		// if err != nil {
-		// 	log(err.Error())
-		// 	if _, nerr := os.Stderr.Write([]byte("gittuf verification failed\n")); nerr != nil {
-		// 		return errors.Join(err, nerr)
-		// 	}
-		// 	return err
-		// }
+	} else {
+		storePushReceipts(ctx, repo, remoteStderr.pushReceipts())
 	}
 
 	return nil
@@ -0,0 +1,398 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	verifymergeableopts "github.com/gittuf/gittuf/experimental/gittuf/options/verifymergeable"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+// forcePushOption is the push option used to push changes that do not meet
+// the policy, i.e., `git push --push-option=gittuf-force`.
+const forcePushOption = "gittuf-force"
+
+var ErrPushDoesNotMeetPolicy = fmt.Errorf("push does not meet gittuf policy, use --push-option=%s to push anyway", forcePushOption)
+
+// handleOption returns the response to an option command from Git. Only the
+// push option to override the policy check is supported, which is indicated
+// by the returned boolean.
+func handleOption(input []byte) (string, bool) {
+	option := strings.TrimSpace(strings.TrimPrefix(string(input), "option "))
+	if option == fmt.Sprintf("push-option %s", forcePushOption) {
+		return "ok\n", true
+	}
+
+	return "unsupported\n", false
+}
+
+// pushRejection records why the push of a ref was refused.
+type pushRejection struct {
+	dstRef string
+	err    error
+}
+
+// parsePushRefSpecs splits the refspecs sent by Git with the push command into
+// the source and destination refs. The force indicator is removed from the
+// source ref. An empty source ref indicates the destination ref is deleted.
+func parsePushRefSpecs(pushRefSpecs []string) ([]string, []string, error) {
+	srcRefs := make([]string, 0, len(pushRefSpecs))
+	dstRefs := make([]string, 0, len(pushRefSpecs))
+	for _, refSpec := range pushRefSpecs {
+		refSpecSplit := strings.Split(refSpec, ":")
+		if len(refSpecSplit) < 2 {
+			return nil, nil, fmt.Errorf("invalid refspec %q: expected format src:dst", refSpec)
+		}
+
+		srcRefs = append(srcRefs, strings.TrimPrefix(refSpecSplit[0], "+"))
+		dstRefs = append(dstRefs, refSpecSplit[1])
+	}
+
+	return srcRefs, dstRefs, nil
+}
+
+// checkPushIsMergeable checks if updating each branch in dstRefs to the tip of
+// the corresponding srcRef meets the policy using only the approvals recorded
+// in the attestations. The refs for which this is not the case are returned,
+// along with tags, deleted refs, and branches that are not fast-forwarded from
+// their tips on the remote in remoteRefTips, none of which can be checked this
+// way. The push may still meet the policy for these refs using the signature
+// on the RSL entry recorded for the push, which must therefore be verified
+// once created.
+func checkPushIsMergeable(ctx context.Context, repo *gittuf.Repository, srcRefs, dstRefs []string, remoteRefTips map[string]string) ([]string, error) {
+	refsToVerify := []string{}
+	for i, dstRef := range dstRefs {
+		srcRef := srcRefs[i]
+		if srcRef == "" {
+			log("deletion of", dstRef, "RSL entry must be verified")
+			refsToVerify = append(refsToVerify, dstRef)
+			continue
+		}
+
+		if !strings.HasPrefix(dstRef, gitinterface.BranchRefPrefix) {
+			refsToVerify = append(refsToVerify, dstRef)
+			continue
+		}
+
+		isFastForward, err := isFastForwardPush(repo.GetGitRepository(), srcRef, remoteRefTips[dstRef])
+		if err != nil {
+			return nil, err
+		}
+		if !isFastForward {
+			// The approvals can't be checked against a merge of the
+			// remote's tip and the pushed tip as the push replaces the
+			// remote's history
+			log(dstRef, "is not fast-forwarded, RSL entry must be verified")
+			refsToVerify = append(refsToVerify, dstRef)
+			continue
+		}
+
+		log("checking if", dstRef, "can be updated to", srcRef)
+		rslEntrySignatureNeeded, err := repo.VerifyMergeable(ctx, dstRef, srcRef, verifymergeableopts.WithBypassRSLForFeatureRef())
+		if err != nil {
+			if !errors.Is(err, policy.ErrVerificationFailed) {
+				return nil, err
+			}
+
+			log("approvals insufficient for", dstRef, "RSL entry must be verified:", err.Error())
+			refsToVerify = append(refsToVerify, dstRef)
+			continue
+		}
+
+		if rslEntrySignatureNeeded {
+			refsToVerify = append(refsToVerify, dstRef)
+		}
+	}
+
+	return refsToVerify, nil
+}
+
+// isFastForwardPush checks if pushing srcRef updates a ref whose tip on the
+// remote is oldTip in a fast-forward manner, i.e., oldTip is an ancestor of
+// the tip of srcRef. A ref that does not exist on the remote is always
+// fast-forwarded. If oldTip is not available locally, the push cannot be a
+// fast-forward as srcRef does not contain it.
+func isFastForwardPush(repo *gitinterface.Repository, srcRef, oldTip string) (bool, error) {
+	if oldTip == "" {
+		return true, nil
+	}
+
+	oldTipID, err := gitinterface.NewHash(oldTip)
+	if err != nil {
+		return false, err
+	}
+	if oldTipID.IsZero() {
+		return true, nil
+	}
+	if !repo.HasObject(oldTipID) {
+		return false, nil
+	}
+
+	newTipID, err := repo.GetReference(srcRef)
+	if err != nil {
+		return false, err
+	}
+
+	return repo.KnowsCommit(newTipID, oldTipID)
+}
+
+// verifyPushRSLEntries verifies the latest RSL entry, i.e., the one recorded
+// for the push, for each ref in refsToVerify.
+func verifyPushRSLEntries(ctx context.Context, repo *gittuf.Repository, srcRefs, dstRefs, refsToVerify []string) []*pushRejection {
+	rejections := []*pushRejection{}
+	for i, dstRef := range dstRefs {
+		if !slices.Contains(refsToVerify, dstRef) {
+			continue
+		}
+
+		log("verifying RSL entry for", dstRef)
+		var err error
+		if srcRefs[i] == "" {
+			err = verifyPushDeletionEntry(ctx, repo, dstRef)
+		} else {
+			err = repo.VerifyRef(ctx, srcRefs[i], verifyopts.WithOverrideRefName(dstRef), verifyopts.WithLatestOnly(), verifyopts.WithWarnOnStaleHeartbeat())
+		}
+		if err != nil {
+			rejections = append(rejections, &pushRejection{dstRef: dstRef, err: err})
+		}
+	}
+
+	return rejections
+}
+
+// verifyPushDeletionEntry verifies the latest RSL entry for dstRef, which must
+// record its deletion in the push. Unlike other pushed refs, there is no local
+// ref whose tip can be checked against the entry; the local repository may
+// still have a ref named dstRef.
+func verifyPushDeletionEntry(ctx context.Context, repo *gittuf.Repository, dstRef string) error {
+	expectedTip, err := policy.NewPolicyVerifier(repo.GetGitRepository()).VerifyRef(ctx, dstRef)
+	if err != nil {
+		return err
+	}
+	if !expectedTip.IsZero() {
+		return fmt.Errorf("latest RSL entry for '%s' does not record its deletion", dstRef)
+	}
+
+	return nil
+}
+
+// rejectPush reports the refs that could not be pushed to Git. As pushes are
+// atomic, every ref in dstRefs is reported as rejected.
+func rejectPush(stdOutWriter io.Writer, dstRefs []string, rejections []*pushRejection) error {
+	for _, rejection := range rejections {
+		msg := fmt.Sprintf("push of '%s' does not meet gittuf policy: %s", rejection.dstRef, rejection.err.Error())
+		log(msg)
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", msg) //nolint:errcheck
+	}
+	fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", ErrPushDoesNotMeetPolicy.Error()) //nolint:errcheck
+
+	for _, dstRef := range dstRefs {
+		if _, err := fmt.Fprintf(stdOutWriter, "error %s gittuf policy not met\n", dstRef); err != nil {
+			return err
+		}
+	}
+
+	// Trailing newline for end of output
+	_, err := stdOutWriter.Write([]byte("\n"))
+	return err
+}
+
+// recordPushInRSL records RSL entries for the refs being pushed, except for
+// gittuf refs and refs whose RSL entries are delegated to a forge trusted in
+// the root of trust. The forge records the entries for the latter when it
+// accepts the push. Unless force is set, the push is checked against the
+// policy before the entries are recorded, and the entries for refs that cannot
+// be shown to meet the policy this way are verified after. If the push does
+// not meet the policy, the local RSL is reset to its prior state and the
+// rejected refs are returned. The push must then be refused. remoteRefTips
+// holds the tips of the refs on the remote prior to the push.
+func recordPushInRSL(ctx context.Context, repo *gittuf.Repository, srcRefs, dstRefs []string, remoteRefTips map[string]string, force bool) ([]*pushRejection, error) {
+	hasPolicy := true
+	var forge tuf.Forge
+	state, err := policy.LoadCurrentState(ctx, repo.GetGitRepository(), policy.PolicyRef)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+		hasPolicy = false
+	} else {
+		rootMetadata, err := state.GetRootMetadata(false)
+		if err != nil {
+			return nil, err
+		}
+		forge = rootMetadata.GetForge()
+	}
+
+	recordSrcRefs := []string{}
+	recordDstRefs := []string{}
+	for i, dstRef := range dstRefs {
+		// A gittuf ref can pop up here when it's explicitly pushed by the
+		// user, we explicitly push the RSL ref after all other refs are
+		// recorded
+		if strings.HasPrefix(dstRef, gittufRefPrefix) {
+			continue
+		}
+
+		if forge != nil && forge.Matches(dstRef) {
+			log("not recording RSL entry for", dstRef, "as it is delegated to the forge")
+			continue
+		}
+
+		recordSrcRefs = append(recordSrcRefs, srcRefs[i])
+		recordDstRefs = append(recordDstRefs, dstRef)
+	}
+	if len(recordSrcRefs) == 0 {
+		return nil, nil
+	}
+
+	checkPolicy := !force
+	if force {
+		log("skipping policy check for push as", forcePushOption, "is set")
+	} else if !hasPolicy {
+		log("policy not found, skipping policy check for push")
+		checkPolicy = false
+	}
+
+	var refsToVerify []string
+	if checkPolicy {
+		var err error
+		refsToVerify, err = checkPushIsMergeable(ctx, repo, recordSrcRefs, recordDstRefs, remoteRefTips)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gitRepo := repo.GetGitRepository()
+	hasPriorRSLTip := true
+	priorRSLTip, err := gitRepo.GetReference(rsl.Ref)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return nil, err
+		}
+		hasPriorRSLTip = false
+	}
+
+	// The RSL entry is recorded for all refs so that all pushed refs are
+	// updated atomically in the RSL
+	// TODO: skipping propagation; invoke it once total instead of per ref
+	if err := repo.RecordRSLEntryForReferences(ctx, recordSrcRefs, true, rslopts.WithOverrideRefNames(recordDstRefs...), rslopts.WithSkipCheckForDuplicateEntry(), rslopts.WithRecordLocalOnly()); err != nil {
+		return nil, err
+	}
+
+	if len(refsToVerify) == 0 {
+		return nil, nil
+	}
+
+	rejections := verifyPushRSLEntries(ctx, repo, recordSrcRefs, recordDstRefs, refsToVerify)
+	if len(rejections) == 0 {
+		return nil, nil
+	}
+
+	if !hasPriorRSLTip {
+		log("removing RSL as it did not exist prior to the push")
+		if err := gitRepo.DeleteReference(rsl.Ref); err != nil {
+			return nil, err
+		}
+	} else {
+		log("resetting RSL to", priorRSLTip.String())
+		if err := gitRepo.SetReference(rsl.Ref, priorRSLTip); err != nil {
+			return nil, err
+		}
+	}
+
+	return rejections, nil
+}
+
+// remoteStderr relays the messages the remote sends on stderr to the parent
+// process, collecting the push receipts returned by a forge trusted for the
+// pushed refs.
+var remoteStderr = &pushReceiptWriter{writer: os.Stderr}
+
+// pushReceiptWriter writes to the underlying writer, collecting the push
+// receipts in the lines written to it. A receipt is identified by
+// gittuf.PushReceiptPrefix, which may be preceded by a prefix such as
+// "remote: " added by Git.
+type pushReceiptWriter struct {
+	writer io.Writer
+
+	mu       sync.Mutex
+	line     []byte
+	receipts []string
+}
+
+func (p *pushReceiptWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, c := range b {
+		if c == '\n' || c == '\r' {
+			p.collectReceipt()
+			continue
+		}
+		p.line = append(p.line, c)
+	}
+
+	return p.writer.Write(b)
+}
+
+// pushReceipts returns the push receipts written so far.
+func (p *pushReceiptWriter) pushReceipts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.collectReceipt()
+	return slices.Clone(p.receipts)
+}
+
+// collectReceipt records the receipt in the current line, if any, and starts
+// a new line. The caller must hold p.mu.
+func (p *pushReceiptWriter) collectReceipt() {
+	line := string(p.line)
+	p.line = p.line[:0]
+
+	if index := strings.Index(line, gittuf.PushReceiptPrefix); index != -1 {
+		p.receipts = append(p.receipts, strings.TrimSpace(line[index:]))
+	}
+}
+
+// storePushReceipts stores the push receipts returned by the remote so that
+// the pusher can later check that the forge didn't drop the push. Receipts
+// that cannot be stored do not fail the push, which the remote has already
+// accepted.
+func storePushReceipts(ctx context.Context, repo *gittuf.Repository, receipts []string) {
+	for _, message := range receipts {
+		receipt, err := gittuf.DecodePushReceipt(message)
+		if err != nil {
+			reportPushReceiptError(err)
+			continue
+		}
+
+		entryID, err := repo.StorePushReceipt(ctx, receipt)
+		if err != nil {
+			reportPushReceiptError(err)
+			continue
+		}
+
+		log("stored push receipt for RSL entry", entryID.String())
+	}
+}
+
+func reportPushReceiptError(err error) {
+	log("unable to store push receipt:", err)
+	fmt.Fprintf(os.Stderr, "git-remote-gittuf: unable to store push receipt: %s\n", err.Error())
+}
@@ -0,0 +1,48 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushReceiptWriter(t *testing.T) {
+	firstReceipt := gittuf.EncodePushReceipt([]byte("first receipt"))
+	secondReceipt := gittuf.EncodePushReceipt([]byte("second receipt"))
+
+	output := &bytes.Buffer{}
+	writer := &pushReceiptWriter{writer: output}
+
+	// Messages may be split across writes, end in carriage returns, and be
+	// prefixed by Git
+	messages := []string{
+		"remote: refs/heads/main: verified\n",
+		"remote: " + firstReceipt[:10],
+		firstReceipt[10:] + "        \r\n",
+		"Writing objects: 100% (3/3)\r",
+		secondReceipt,
+	}
+	for _, message := range messages {
+		_, err := writer.Write([]byte(message))
+		require.Nil(t, err)
+	}
+
+	// Everything is relayed to the underlying writer
+	assert.Equal(t, strings.Join(messages, ""), output.String())
+
+	receipts := writer.pushReceipts()
+	assert.Equal(t, []string{firstReceipt, secondReceipt}, receipts)
+
+	for i, expectedReceipt := range []string{"first receipt", "second receipt"} {
+		receipt, err := gittuf.DecodePushReceipt(receipts[i])
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(expectedReceipt), receipt)
+	}
+}
@@ -0,0 +1,395 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	attestopts "github.com/gittuf/gittuf/experimental/gittuf/options/attest"
+	rslopts "github.com/gittuf/gittuf/experimental/gittuf/options/rsl"
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rootKeyBytes            = artifacts.SSHRSAPrivate
+	rootPubKeyBytes         = artifacts.SSHRSAPublicSSH
+	targetsKeyBytes         = artifacts.SSHECDSAPrivate
+	targetsPubKeyBytes      = artifacts.SSHECDSAPublicSSH
+	gpgUnauthorizedKeyBytes = artifacts.GPGKey2Private
+
+	testCtx = context.Background()
+)
+
+// TestMain runs the test binary as the transport when Git invokes it as
+// git-remote-gittuf, see useTestTransport.
+func TestMain(m *testing.M) {
+	if filepath.Base(os.Args[0]) == "git-remote-gittuf" {
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestTransport(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		// Local remotes are identified using POSIX paths
+		t.Skip("transport tests are not supported on Windows")
+	}
+
+	t.Run("file", func(t *testing.T) {
+		serverDir := t.TempDir()
+		testTransport(t, serverDir, "gittuf::"+filepath.Join(serverDir, "repository.git"))
+	})
+
+	t.Run("file URL", func(t *testing.T) {
+		// The URL's path is percent-encoded
+		serverDir := filepath.Join(t.TempDir(), "server dir")
+		require.Nil(t, os.Mkdir(serverDir, 0o755))
+		serverURL := &url.URL{Scheme: "file", Host: "localhost", Path: filepath.Join(serverDir, "repository.git")}
+		testTransport(t, serverDir, "gittuf::"+serverURL.String())
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		useTestTransport(t)
+
+		upstreamRepo := createTestRepositoryWithPolicy(t)
+		baseDir := t.TempDir()
+		runGit(t, baseDir, "clone", "--mirror", upstreamRepo.GetGitRepository().GetGitDir(), "repository.git")
+		serverRepo, err := gitinterface.LoadRepository(filepath.Join(baseDir, "repository.git"))
+		require.Nil(t, err)
+
+		runGit(t, baseDir, "clone", "gittuf::./repository.git", "client")
+		clientRepo, err := gitinterface.LoadRepository(filepath.Join(baseDir, "client"))
+		require.Nil(t, err)
+
+		assertGittufRefsMatch(t, serverRepo, clientRepo)
+	})
+
+	t.Run("git daemon", func(t *testing.T) {
+		serverDir := t.TempDir()
+		address := startGitDaemon(t, serverDir)
+		testTransport(t, serverDir, fmt.Sprintf("gittuf::git://%s/repository.git", address))
+	})
+}
+
+// testTransport serves a repository with a gittuf policy from serverDir and
+// exercises fetches and pushes through the transport using remoteURL.
+func testTransport(t *testing.T, serverDir, remoteURL string) {
+	t.Helper()
+
+	useTestTransport(t)
+
+	upstreamRepo := createTestRepositoryWithPolicy(t)
+	serverPath := filepath.Join(serverDir, "repository.git")
+	runGit(t, "", "clone", "--mirror", upstreamRepo.GetGitRepository().GetGitDir(), serverPath)
+	serverRepo, err := gitinterface.LoadRepository(serverPath)
+	require.Nil(t, err)
+
+	clientDir := t.TempDir()
+	clientRepo := gitinterface.CreateTestGitRepository(t, clientDir, false)
+	runGit(t, clientDir, "remote", "add", "origin", remoteURL)
+
+	t.Run("fetch gittuf refs", func(t *testing.T) {
+		runGit(t, clientDir, "fetch", "origin")
+
+		for _, refName := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+			expectedTip, err := serverRepo.GetReference(refName)
+			require.Nil(t, err)
+			tip, err := clientRepo.GetReference(refName)
+			require.Nil(t, err, refName)
+			assert.Equal(t, expectedTip, tip, refName)
+		}
+
+		expectedTip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		tip, err := clientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+		assert.Equal(t, expectedTip, tip)
+	})
+
+	t.Run("fetch skips unchanged refs", func(t *testing.T) {
+		logPath := filepath.Join(t.TempDir(), "transport.log")
+		t.Setenv("GITTUF_LOG_FILE", logPath)
+
+		runGit(t, clientDir, "fetch", "origin")
+
+		contents, err := os.ReadFile(logPath)
+		require.Nil(t, err)
+		assert.Contains(t, string(contents), "skipping verification of refs/heads/main as refs/remotes/origin/main already points to the fetched tip")
+	})
+
+	t.Run("fetch explicit gittuf refspecs", func(t *testing.T) {
+		otherClientDir := t.TempDir()
+		otherClientRepo := gitinterface.CreateTestGitRepository(t, otherClientDir, false)
+		runGit(t, otherClientDir, "remote", "add", "origin", remoteURL)
+
+		runGit(t, otherClientDir, "fetch", "origin", "refs/gittuf/*:refs/gittuf/*")
+		assertGittufRefsMatch(t, serverRepo, otherClientRepo)
+
+		// Explicit gittuf refspecs alongside the refspecs for branches
+		runGit(t, otherClientDir, "fetch", "origin", "refs/gittuf/*:refs/gittuf/*", "refs/heads/*:refs/remotes/origin/*")
+		assertGittufRefsMatch(t, serverRepo, otherClientRepo)
+
+		expectedTip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		tip, err := otherClientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+		assert.Equal(t, expectedTip, tip)
+	})
+
+	t.Run("concurrent fetches", func(t *testing.T) {
+		otherClientDir := t.TempDir()
+		otherClientRepo := gitinterface.CreateTestGitRepository(t, otherClientDir, false)
+		runGit(t, otherClientDir, "remote", "add", "origin", remoteURL)
+
+		fetches := []*exec.Cmd{
+			gitCommand(otherClientDir, "fetch", "origin"),
+			gitCommand(otherClientDir, "fetch", "origin", "refs/gittuf/*:refs/gittuf/*"),
+		}
+		outputs := make([]*bytes.Buffer, len(fetches))
+		for i, fetch := range fetches {
+			outputs[i] = &bytes.Buffer{}
+			fetch.Stdout = outputs[i]
+			fetch.Stderr = outputs[i]
+			require.Nil(t, fetch.Start())
+		}
+		for i, fetch := range fetches {
+			assert.Nil(t, fetch.Wait(), outputs[i].String())
+		}
+
+		assertGittufRefsMatch(t, serverRepo, otherClientRepo)
+
+		expectedTip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		tip, err := otherClientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+		assert.Equal(t, expectedTip, tip)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		originTip, err := clientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+		require.Nil(t, clientRepo.SetReference("refs/heads/main", originTip))
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, clientRepo, "refs/heads/main", 1, rootKeyBytes)
+
+		runGit(t, clientDir, "push", "origin", "refs/heads/main:refs/heads/main")
+
+		tip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		assert.Equal(t, commitIDs[0], tip)
+
+		entry, _, err := rsl.GetLatestReferenceUpdaterEntry(serverRepo, rsl.ForReference("refs/heads/main"))
+		require.Nil(t, err)
+		assert.Equal(t, commitIDs[0], entry.GetTargetID())
+	})
+
+	t.Run("push deletion", func(t *testing.T) {
+		runGit(t, clientDir, "push", "origin", ":refs/heads/feature")
+
+		_, err := serverRepo.GetReference("refs/heads/feature")
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
+
+		entry, _, err := rsl.GetLatestReferenceUpdaterEntry(serverRepo, rsl.ForReference("refs/heads/feature"))
+		require.Nil(t, err)
+		assert.True(t, entry.GetTargetID().IsZero())
+	})
+
+	t.Run("push rejected by policy", func(t *testing.T) {
+		serverTip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		clientRSLTip, err := clientRepo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		// Sign the RSL entry for the push using a key that isn't authorized
+		// for main
+		setSigningKey(t, clientRepo, targetsKeyBytes, targetsPubKeyBytes)
+		common.AddNTestCommitsToSpecifiedRef(t, clientRepo, "refs/heads/main", 1, targetsKeyBytes)
+
+		output, err := gitCommand(clientDir, "push", "origin", "refs/heads/main:refs/heads/main").CombinedOutput()
+		assert.NotNil(t, err, string(output))
+		assert.Contains(t, string(output), "push does not meet gittuf policy")
+
+		tip, err := serverRepo.GetReference("refs/heads/main")
+		require.Nil(t, err)
+		assert.Equal(t, serverTip, tip)
+
+		rslTip, err := clientRepo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+		assert.Equal(t, clientRSLTip, rslTip)
+	})
+
+	t.Run("fetch blocks refs that fail verification", func(t *testing.T) {
+		require.Nil(t, clientRepo.SetGitConfig(verificationModeConfigKey, verificationModeBlock))
+
+		trackingTip, err := clientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, serverRepo, "refs/heads/main", 1, gpgUnauthorizedKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, serverRepo, rsl.NewReferenceEntry("refs/heads/main", commitIDs[0]), gpgUnauthorizedKeyBytes)
+
+		output, err := gitCommand(clientDir, "fetch", "origin").CombinedOutput()
+		assert.NotNil(t, err, string(output))
+		assert.Contains(t, string(output), "gittuf verification failed for 'refs/heads/main'")
+
+		tip, err := clientRepo.GetReference("refs/remotes/origin/main")
+		require.Nil(t, err)
+		assert.Equal(t, trackingTip, tip)
+
+		quarantineTip, err := clientRepo.GetReference(quarantineRefName("origin", "refs/heads/main"))
+		require.Nil(t, err)
+		assert.Equal(t, commitIDs[0], quarantineTip)
+	})
+}
+
+// createTestRepositoryWithPolicy creates a repository whose policy protects
+// main, authorizing the SSH key configured as the repository's Git signing
+// key. The repository has RSL entries for main and feature, and a reference
+// authorization for merging feature into main.
+func createTestRepositoryWithPolicy(t *testing.T) *gittuf.Repository {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	gitRepo := gitinterface.CreateTestGitRepository(t, repoDir, false)
+	repo, err := gittuf.LoadRepository(repoDir)
+	require.Nil(t, err)
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	targetsSigner := setupSSHKeysForSigning(t, targetsKeyBytes, targetsPubKeyBytes)
+	gitSigningKey := tufv01.NewKeyFromSSLibKey(rootSigner.MetadataKey())
+
+	require.Nil(t, repo.InitializeRoot(testCtx, rootSigner, false))
+	require.Nil(t, repo.AddTopLevelTargetsKey(testCtx, rootSigner, tufv01.NewKeyFromSSLibKey(targetsSigner.MetadataKey()), false))
+	require.Nil(t, repo.InitializeTargets(testCtx, targetsSigner, policy.TargetsRoleName, false))
+	require.Nil(t, repo.AddPrincipalToTargets(testCtx, targetsSigner, policy.TargetsRoleName, []tuf.Principal{gitSigningKey}, false))
+	require.Nil(t, repo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{gitSigningKey.KeyID}, []string{"git:refs/heads/main"}, 1, false))
+	require.Nil(t, repo.StagePolicy(testCtx, "", true, false))
+	require.Nil(t, repo.ApplyPolicy(testCtx, "", true, false))
+
+	common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/main", 1, rootKeyBytes)
+	require.Nil(t, repo.RecordRSLEntryForReference(testCtx, "refs/heads/main", true, rslopts.WithRecordLocalOnly()))
+	common.AddNTestCommitsToSpecifiedRef(t, gitRepo, "refs/heads/feature", 2, rootKeyBytes)
+	require.Nil(t, repo.RecordRSLEntryForReference(testCtx, "refs/heads/feature", true, rslopts.WithRecordLocalOnly()))
+
+	require.Nil(t, repo.AddReferenceAuthorization(testCtx, rootSigner, "refs/heads/main", "refs/heads/feature", true, attestopts.WithRSLEntry()))
+
+	return repo
+}
+
+// assertGittufRefsMatch checks that the client's gittuf refs have the same tips
+// as the server's.
+func assertGittufRefsMatch(t *testing.T, serverRepo, clientRepo *gitinterface.Repository) {
+	t.Helper()
+
+	for _, refName := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+		expectedTip, err := serverRepo.GetReference(refName)
+		require.Nil(t, err)
+		tip, err := clientRepo.GetReference(refName)
+		require.Nil(t, err, refName)
+		assert.Equal(t, expectedTip, tip, refName)
+	}
+}
+
+// useTestTransport makes Git invoke the test binary as git-remote-gittuf for
+// the duration of the test.
+func useTestTransport(t *testing.T) {
+	t.Helper()
+
+	executable, err := os.Executable()
+	require.Nil(t, err)
+
+	binDir := t.TempDir()
+	require.Nil(t, os.Symlink(executable, filepath.Join(binDir, "git-remote-gittuf")))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// startGitDaemon serves the repositories in basePath using git daemon, with
+// pushes enabled, and returns the address it listens on.
+func startGitDaemon(t *testing.T, basePath string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	address := listener.Addr().String()
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+
+	daemon := exec.Command("git", "daemon", "--reuseaddr", "--export-all", "--enable=receive-pack", "--listen=127.0.0.1", fmt.Sprintf("--port=%d", port), "--base-path="+basePath, basePath) //nolint:gosec
+	require.Nil(t, daemon.Start())
+	t.Cleanup(func() {
+		daemon.Process.Kill() //nolint:errcheck
+		daemon.Wait()         //nolint:errcheck
+	})
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close() //nolint:errcheck
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+
+	return address
+}
+
+// setSigningKey configures the repository to sign using the specified SSH
+// key.
+func setSigningKey(t *testing.T, repo *gitinterface.Repository, privateBytes, publicBytes []byte) {
+	t.Helper()
+
+	keysDir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(keysDir, "key"), privateBytes, 0o600))
+	require.Nil(t, os.WriteFile(filepath.Join(keysDir, "key.pub"), publicBytes, 0o600))
+	require.Nil(t, repo.SetGitConfig("user.signingkey", filepath.Join(keysDir, "key.pub")))
+}
+
+func setupSSHKeysForSigning(t *testing.T, privateBytes, publicBytes []byte) *ssh.Signer {
+	t.Helper()
+
+	keysDir := t.TempDir()
+	privKeyPath := filepath.Join(keysDir, "key")
+	pubKeyPath := filepath.Join(keysDir, "key.pub")
+	require.Nil(t, os.WriteFile(privKeyPath, privateBytes, 0o600))
+	require.Nil(t, os.WriteFile(pubKeyPath, publicBytes, 0o600))
+
+	signer, err := ssh.NewSignerFromFile(privKeyPath)
+	require.Nil(t, err)
+
+	return signer
+}
+
+func gitCommand(dir string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	output, err := gitCommand(dir, args...).CombinedOutput()
+	require.Nil(t, err, string(output))
+}
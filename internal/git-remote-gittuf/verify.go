@@ -0,0 +1,261 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/experimental/gittuf"
+	verifyopts "github.com/gittuf/gittuf/experimental/gittuf/options/verify"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const (
+	// verificationModeConfigKey is the Git config key that determines how
+	// the transport handles fetched refs that fail gittuf verification. The
+	// key is lowercase as Git normalizes config keys.
+	verificationModeConfigKey = "gittuf.transport.verification"
+
+	// verificationModeWarn reports fetched refs that fail verification but
+	// lets Git update the remote-tracking refs. This is the default.
+	verificationModeWarn = "warn"
+
+	// verificationModeBlock holds fetched refs that fail verification in the
+	// quarantine namespace and fails the fetch, so Git does not update the
+	// remote-tracking refs.
+	verificationModeBlock = "block"
+
+	quarantineRefPrefix = "refs/gittuf/quarantine/"
+
+	// verificationRefPrefix is the namespace for the refs that hold fetched
+	// tips while they are verified. Each transport process uses its own refs
+	// so that concurrent fetches do not interfere with each other.
+	verificationRefPrefix = "refs/gittuf/verification/"
+)
+
+var (
+	ErrFetchVerificationFailed = errors.New("gittuf verification failed for one or more fetched references")
+	ErrInvalidVerificationMode = fmt.Errorf("invalid value for %s, must be one of '%s' or '%s'", verificationModeConfigKey, verificationModeWarn, verificationModeBlock)
+)
+
+// getVerificationMode returns the verification mode configured for the
+// repository.
+func getVerificationMode(repo *gittuf.Repository) (string, error) {
+	config, err := repo.GetGitRepository().GetGitConfig()
+	if err != nil {
+		return "", err
+	}
+
+	mode, defined := config[verificationModeConfigKey]
+	if !defined {
+		return verificationModeWarn, nil
+	}
+
+	switch mode {
+	case verificationModeWarn, verificationModeBlock:
+		return mode, nil
+	default:
+		return "", ErrInvalidVerificationMode
+	}
+}
+
+// quarantineRefName returns the ref used to hold the fetched tip of refName
+// from the specified remote while it is verified. For example, the tip of
+// refs/heads/main fetched from origin is held in
+// refs/gittuf/quarantine/origin/heads/main.
+func quarantineRefName(remoteName, refName string) string {
+	return fmt.Sprintf("%s%s/%s", quarantineRefPrefix, remoteName, strings.TrimPrefix(refName, gitinterface.RefPrefix))
+}
+
+// verificationRefName returns the ref used by the current process to hold the
+// fetched tip of refName while it is verified.
+func verificationRefName(refName string) string {
+	return fmt.Sprintf("%s%d/%s", verificationRefPrefix, os.Getpid(), strings.TrimPrefix(refName, gitinterface.RefPrefix))
+}
+
+// trackingRefName returns the local ref that Git updates with the fetched tip
+// of refName when the remote uses the default refspecs. Branches are fetched
+// into the remote-tracking refs for the remote while tags are fetched as is.
+func trackingRefName(remoteName, refName string) string {
+	if strings.HasPrefix(refName, gitinterface.BranchRefPrefix) {
+		return gitinterface.RemoteReferenceName(fmt.Sprintf("%s/%s", remoteName, strings.TrimPrefix(refName, gitinterface.BranchRefPrefix)))
+	}
+
+	return refName
+}
+
+// getTrackingRefTips returns the current tips of the local refs that a fetch
+// from the specified remote may update, i.e., the remote-tracking refs for the
+// remote and the local tags.
+func getTrackingRefTips(repo *gittuf.Repository, remoteName string) (map[string]gitinterface.Hash, error) {
+	gitRepo := repo.GetGitRepository()
+
+	trackingRefTips := map[string]gitinterface.Hash{}
+	for _, prefix := range []string{gitinterface.RemoteReferenceName(remoteName + "/"), gitinterface.TagRefPrefix} {
+		refNames, err := gitRepo.ListReferences(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, refName := range refNames {
+			tip, err := gitRepo.GetReference(refName)
+			if err != nil {
+				return nil, err
+			}
+			trackingRefTips[refName] = tip
+		}
+	}
+
+	return trackingRefTips, nil
+}
+
+// restoreTrackingRef reverts the local ref updated by Git with the fetched tip
+// of refName to its state prior to the fetch. When Git does not have to fetch
+// any objects, it updates the local refs before the transport exits, so
+// failing the fetch is insufficient to block the update.
+func restoreTrackingRef(repo *gittuf.Repository, remoteName, refName string, fetchedTip gitinterface.Hash, trackingRefTips map[string]gitinterface.Hash) error {
+	gitRepo := repo.GetGitRepository()
+	trackingRef := trackingRefName(remoteName, refName)
+
+	currentTip, err := gitRepo.GetReference(trackingRef)
+	if err != nil {
+		if errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !currentTip.Equal(fetchedTip) {
+		// Git hasn't updated the ref
+		return nil
+	}
+
+	oldTip, has := trackingRefTips[trackingRef]
+	if !has {
+		log("deleting", trackingRef, "as it did not exist prior to the fetch")
+		return gitRepo.DeleteReference(trackingRef)
+	}
+
+	log("restoring", trackingRef, "to", oldTip.String())
+	return gitRepo.SetReference(trackingRef, oldTip)
+}
+
+// verifyFetchedRefs verifies each branch and tag advertised by the remote
+// during a fetch against the fetched RSL and policy, handling failures as per
+// the specified verification mode. This includes refs whose tips were already
+// available locally, as Git may still update the corresponding remote-tracking
+// refs. Refs whose local refs already point to the fetched tips are unchanged
+// by the fetch and are not verified again, nor are refs that are neither
+// recorded in the RSL nor protected by the policy. If verification fails in the
+// block mode, the fetched tip of the ref is held in the quarantine namespace
+// and the local ref updated with it is restored using trackingRefTips, the
+// state of the local refs prior to the fetch. Failures are reported on stderr.
+// In the block mode, ErrFetchVerificationFailed is returned if any ref fails
+// verification.
+func verifyFetchedRefs(ctx context.Context, repo *gittuf.Repository, remoteName, mode string, remoteRefTips map[string]string, trackingRefTips map[string]gitinterface.Hash) error {
+	gitRepo := repo.GetGitRepository()
+
+	if _, err := gitRepo.GetReference(rsl.Ref); err != nil {
+		if errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			log("RSL not found, skipping verification of fetched refs")
+			return nil
+		}
+		return err
+	}
+
+	state, err := policy.LoadCurrentState(ctx, gitRepo, policy.PolicyRef)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			log("policy not found, skipping verification of fetched refs")
+			return nil
+		}
+		return err
+	}
+
+	refNames := make([]string, 0, len(remoteRefTips))
+	for refName := range remoteRefTips {
+		if strings.HasPrefix(refName, gitinterface.BranchRefPrefix) || strings.HasPrefix(refName, gitinterface.TagRefPrefix) {
+			refNames = append(refNames, refName)
+		}
+	}
+	sort.Strings(refNames)
+
+	failed := false
+	for _, refName := range refNames {
+		tip, err := gitinterface.NewHash(remoteRefTips[refName])
+		if err != nil {
+			return err
+		}
+
+		trackingRef := trackingRefName(remoteName, refName)
+		if trackingTip, has := trackingRefTips[trackingRef]; has && trackingTip.Equal(tip) {
+			log("skipping verification of", refName, "as", trackingRef, "already points to the fetched tip")
+			continue
+		}
+
+		mustVerify, err := state.IsProtectedReference(refName)
+		if err != nil {
+			return err
+		}
+		if !mustVerify {
+			if _, _, err := rsl.GetLatestReferenceUpdaterEntry(gitRepo, rsl.ForReference(refName)); err == nil {
+				mustVerify = true
+			} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return err
+			}
+		}
+		if !mustVerify {
+			log("skipping verification of", refName, "as it is not recorded in the RSL or protected by policy")
+			continue
+		}
+
+		verificationRef := verificationRefName(refName)
+		if err := gitRepo.SetReference(verificationRef, tip); err != nil {
+			return err
+		}
+
+		log("verifying", refName, "using", verificationRef)
+		verificationErr := repo.VerifyRef(ctx, verificationRef, verifyopts.WithOverrideRefName(refName), verifyopts.WithWarnOnStaleHeartbeat())
+		if err := gitRepo.DeleteReference(verificationRef); err != nil {
+			return err
+		}
+		if verificationErr == nil {
+			continue
+		}
+
+		failed = true
+		msg := fmt.Sprintf("gittuf verification failed for '%s' at '%s': %s", refName, tip.String(), verificationErr.Error())
+		log(msg)
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", msg) //nolint:errcheck
+		if mode == verificationModeBlock {
+			quarantineRef := quarantineRefName(remoteName, refName)
+			if err := gitRepo.SetReference(quarantineRef, tip); err != nil {
+				return err
+			}
+
+			if err := restoreTrackingRef(repo, remoteName, refName, tip, trackingRefTips); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "git-remote-gittuf: fetched tip of '%s' held in '%s'\n", refName, quarantineRef) //nolint:errcheck
+		}
+	}
+
+	if !failed {
+		return nil
+	}
+
+	if mode == verificationModeBlock {
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: remote-tracking refs were not updated, inspect the refs in '%s%s/'\n", quarantineRefPrefix, remoteName) //nolint:errcheck
+		return ErrFetchVerificationFailed
+	}
+
+	fmt.Fprintf(os.Stderr, "git-remote-gittuf: remote-tracking refs were updated, set %s=%s to block updates that fail verification\n", verificationModeConfigKey, verificationModeBlock) //nolint:errcheck
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const (
+	// BaselineAnnotationDataKey is the annotation data key used to identify
+	// an RSL entry as the baseline for the references it records. The value
+	// must be true.
+	BaselineAnnotationDataKey = "baseline"
+
+	baselineVerifierName = "baseline"
+)
+
+// HistoricalCommit describes whether a commit that predates a reference's
+// baseline in the RSL would satisfy the policy.
+type HistoricalCommit struct {
+	ID              gitinterface.Hash
+	Signed          bool
+	SatisfiesPolicy bool
+}
+
+// isBaselineEntry returns true if the entry is a trusted baseline for its
+// reference. A baseline records the state of a reference when gittuf is
+// adopted in an existing repository, and history prior to it cannot be
+// verified. The entry is a baseline if it is the first entry in the RSL for
+// the reference and an annotation that identifies it as a baseline is signed
+// by a root principal.
+func (s *State) isBaselineEntry(ctx context.Context, entry *rsl.ReferenceEntry, annotations []*rsl.AnnotationEntry) (bool, error) {
+	baselineAnnotations := []*rsl.AnnotationEntry{}
+	for _, annotation := range annotations {
+		if !annotation.RefersTo(entry.GetID()) || annotation.Skip {
+			continue
+		}
+
+		isBaseline := false
+		if _, err := annotation.GetData(BaselineAnnotationDataKey, &isBaseline); err != nil {
+			slog.Debug(fmt.Sprintf("Annotation '%s' has invalid baseline data, ignoring...", annotation.GetID().String()))
+			continue
+		}
+		if isBaseline {
+			baselineAnnotations = append(baselineAnnotations, annotation)
+		}
+	}
+	if len(baselineAnnotations) == 0 {
+		return false, nil
+	}
+
+	_, _, err := rsl.GetLatestReferenceUpdaterEntry(s.repository, rsl.ForReference(entry.GetRefName()), rsl.BeforeEntryID(entry.GetID()))
+	if err == nil {
+		slog.Debug(fmt.Sprintf("Entry '%s' is not the first entry for '%s', ignoring baseline annotations...", entry.GetID().String(), entry.GetRefName()))
+		return false, nil
+	}
+	if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return false, err
+	}
+
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return false, err
+	}
+	principals, err := rootMetadata.GetRootPrincipals()
+	if err != nil {
+		return false, err
+	}
+
+	verifier := &SignatureVerifier{
+		repository:     s.repository,
+		signatureCache: s.signatureCache,
+		name:           baselineVerifierName,
+		principals:     principals,
+		threshold:      1,
+	}
+
+	for _, annotation := range baselineAnnotations {
+		if _, err := verifier.Verify(ctx, annotation.GetID(), nil); err != nil {
+			slog.Debug(fmt.Sprintf("Baseline annotation '%s' is not signed by a root principal, ignoring...", annotation.GetID().String()))
+			continue
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// EvaluateHistoryForRef checks each commit reachable from the specified tip
+// against the policy's rules for the reference and the files modified by the
+// commit. It is intended to report on history that predates gittuf's adoption
+// in a repository, and so the RSL is not consulted.
+func (s *State) EvaluateHistoryForRef(ctx context.Context, refName string, tipID gitinterface.Hash) ([]*HistoricalCommit, error) {
+	commitIDs, err := s.repository.GetCommitsBetweenRange(tipID, gitinterface.ZeroHash)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*HistoricalCommit, 0, len(commitIDs))
+	for _, commitID := range commitIDs {
+		signed, err := s.repository.IsCommitSigned(commitID)
+		if err != nil {
+			return nil, err
+		}
+
+		satisfiesPolicy, err := s.commitSatisfiesPolicy(ctx, refName, commitID)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, &HistoricalCommit{ID: commitID, Signed: signed, SatisfiesPolicy: satisfiesPolicy})
+	}
+
+	return results, nil
+}
+
+// commitSatisfiesPolicy returns true if the commit's signature meets the rules
+// protecting the reference and the files modified by the commit.
+func (s *State) commitSatisfiesPolicy(ctx context.Context, refName string, commitID gitinterface.Hash) (bool, error) {
+	if _, _, err := verifyGitObjectAndAttestations(ctx, s, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName), commitID, nil); err != nil {
+		switch {
+		case errors.Is(err, ErrMetadataNotFound):
+			// The policy doesn't have any rules yet
+			return true, nil
+		case errors.Is(err, ErrVerifierConditionsUnmet):
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !s.hasFileRule {
+		return true, nil
+	}
+
+	paths, err := s.repository.GetFilePathsChangedByCommit(commitID)
+	if err != nil {
+		return false, err
+	}
+
+	verifiedUsing := ""
+	for _, path := range paths {
+		verifiedUsing, _, err = verifyGitObjectAndAttestations(ctx, s, fmt.Sprintf("%s:%s", fileRuleScheme, path), commitID, nil, withTrustedVerifier(verifiedUsing))
+		if err != nil {
+			if errors.Is(err, ErrVerifierConditionsUnmet) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	return true, nil
+}
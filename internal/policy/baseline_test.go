@@ -0,0 +1,139 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBaselineEntry(t *testing.T) {
+	refName := "refs/heads/main"
+
+	createBaselineAnnotation := func(t *testing.T, repo *gitinterface.Repository, entryID gitinterface.Hash, signingKeyBytes []byte) *rsl.AnnotationEntry {
+		t.Helper()
+
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entryID}, false, "baseline")
+		annotation.Data = map[string]json.RawMessage{BaselineAnnotationDataKey: json.RawMessage("true")}
+		annotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, signingKeyBytes)
+		return annotation
+	}
+
+	t.Run("baseline signed by root principal", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		isBaseline, err := state.isBaselineEntry(testCtx, entry, nil)
+		assert.Nil(t, err)
+		assert.False(t, isBaseline)
+
+		annotation := createBaselineAnnotation(t, repo, entry.ID, rootKeyBytes)
+
+		isBaseline, err = state.isBaselineEntry(testCtx, entry, []*rsl.AnnotationEntry{annotation})
+		assert.Nil(t, err)
+		assert.True(t, isBaseline)
+	})
+
+	t.Run("baseline not signed by root principal", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		annotation := createBaselineAnnotation(t, repo, entry.ID, gpgKeyBytes)
+
+		isBaseline, err := state.isBaselineEntry(testCtx, entry, []*rsl.AnnotationEntry{annotation})
+		assert.Nil(t, err)
+		assert.False(t, isBaseline)
+	})
+
+	t.Run("baseline is not first entry for reference", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		annotation := createBaselineAnnotation(t, repo, entry.ID, rootKeyBytes)
+
+		isBaseline, err := state.isBaselineEntry(testCtx, entry, []*rsl.AnnotationEntry{annotation})
+		assert.Nil(t, err)
+		assert.False(t, isBaseline)
+	})
+
+	t.Run("verification starts from baseline", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		// History that predates gittuf doesn't meet the file rules
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[1])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		createBaselineAnnotation(t, repo, entry.ID, rootKeyBytes)
+
+		verifier = NewPolicyVerifier(repo)
+		_, err = verifier.VerifyRefFull(testCtx, refName)
+		assert.Nil(t, err)
+
+		// Changes after the baseline are verified as usual
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier = NewPolicyVerifier(repo)
+		_, err = verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
+
+func TestEvaluateHistoryForRef(t *testing.T) {
+	refName := "refs/heads/main"
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	authorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+	unauthorizedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+
+	treeID, err := repo.GetCommitTreeID(unauthorizedCommitIDs[0])
+	require.Nil(t, err)
+	unsignedCommitID, err := repo.Commit(treeID, refName, "Unsigned commit\n", false)
+	require.Nil(t, err)
+
+	results, err := state.EvaluateHistoryForRef(testCtx, refName, unsignedCommitID)
+	require.Nil(t, err)
+	require.Len(t, results, 4)
+
+	resultsByID := map[string]*HistoricalCommit{}
+	for _, result := range results {
+		resultsByID[result.ID.String()] = result
+	}
+
+	for _, commitID := range authorizedCommitIDs {
+		assert.True(t, resultsByID[commitID.String()].Signed)
+		assert.True(t, resultsByID[commitID.String()].SatisfiesPolicy)
+	}
+
+	assert.True(t, resultsByID[unauthorizedCommitIDs[0].String()].Signed)
+	assert.False(t, resultsByID[unauthorizedCommitIDs[0].String()].SatisfiesPolicy)
+
+	assert.False(t, resultsByID[unsignedCommitID.String()].Signed)
+	assert.False(t, resultsByID[unsignedCommitID.String()].SatisfiesPolicy)
+}
@@ -0,0 +1,243 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const (
+	checkpointVerifierName = "checkpoint"
+	gittufRefPrefix        = "refs/gittuf/"
+)
+
+var ErrInvalidCheckpoint = errors.New("RSL checkpoint does not match the state of the RSL preceding it")
+
+// CreateCheckpoint verifies every reference with entries in the RSL and returns
+// a checkpoint entry summarizing the verified state as of the latest entry in
+// the RSL. Verification of each reference starts from the latest trusted
+// checkpoint if one exists. The returned entry is not signed or committed to
+// the RSL.
+func (v *PolicyVerifier) CreateCheckpoint(ctx context.Context) (*rsl.CheckpointEntry, error) {
+	slog.Debug("Identifying latest RSL entries for all references...")
+	entry, err := rsl.GetLatestEntry(v.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		policyEntryID       gitinterface.Hash
+		attestationsEntryID = gitinterface.ZeroHash
+		refEntries          = map[string]rsl.ReferenceUpdaterEntry{}
+	)
+	for {
+		for _, entry := range rsl.GetReferenceUpdaterEntries(entry) {
+			refName := entry.GetRefName()
+			switch {
+			case refName == PolicyRef:
+				if policyEntryID == nil {
+					policyEntryID = entry.GetID()
+				}
+			case refName == attestations.Ref:
+				if attestationsEntryID.IsZero() {
+					attestationsEntryID = entry.GetID()
+				}
+			case strings.HasPrefix(refName, gittufRefPrefix):
+				// Other gittuf refs are not verified
+			default:
+				if _, has := refEntries[refName]; !has {
+					refEntries[refName] = entry
+				}
+			}
+		}
+
+		entry, err = rsl.GetParentForEntry(v.repo, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	if policyEntryID == nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	refNames := make([]string, 0, len(refEntries))
+	for refName := range refEntries {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+
+	refEntryIDs := make(map[string]gitinterface.Hash, len(refEntries))
+	for _, refName := range refNames {
+		slog.Debug(fmt.Sprintf("Verifying '%s' for checkpoint...", refName))
+		firstEntry, err := v.findStartingEntryForRef(ctx, refName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := v.VerifyRelativeForRef(ctx, firstEntry, refEntries[refName], refName); err != nil {
+			return nil, fmt.Errorf("unable to verify '%s': %w", refName, err)
+		}
+
+		refEntryIDs[refName] = refEntries[refName].GetID()
+	}
+
+	return rsl.NewCheckpointEntry(policyEntryID, attestationsEntryID, refEntryIDs), nil
+}
+
+// findLatestTrustedCheckpoint returns the latest checkpoint in the RSL that
+// is trusted by the root of trust applicable at the checkpoint. If the RSL has
+// no trusted checkpoints, nil is returned. The result is reused for subsequent
+// requests.
+func (v *PolicyVerifier) findLatestTrustedCheckpoint(ctx context.Context) (*rsl.CheckpointEntry, error) {
+	if v.checkpointSearched {
+		return v.latestCheckpoint, nil
+	}
+
+	slog.Debug("Searching for latest trusted RSL checkpoint...")
+	entry, err := rsl.GetLatestEntry(v.repo)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			v.checkpointSearched = true
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for {
+		if checkpoint, isCheckpoint := entry.(*rsl.CheckpointEntry); isCheckpoint {
+			err := v.verifyCheckpoint(ctx, checkpoint)
+			if err == nil {
+				slog.Debug(fmt.Sprintf("Found trusted checkpoint '%s'", checkpoint.GetID().String()))
+				v.latestCheckpoint = checkpoint
+				v.checkpointSearched = true
+				return checkpoint, nil
+			}
+			if !errors.Is(err, ErrInvalidCheckpoint) && !errors.Is(err, ErrVerifierConditionsUnmet) {
+				return nil, err
+			}
+
+			slog.Debug(fmt.Sprintf("Checkpoint '%s' is not trusted (%s), skipping...", checkpoint.GetID().String(), err.Error()))
+		}
+
+		entry, err = rsl.GetParentForEntry(v.repo, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				slog.Debug("No trusted checkpoint found")
+				v.checkpointSearched = true
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// verifyCheckpoint checks that the checkpoint records the policy and
+// attestations entries that precede it, that each entry it records for a
+// reference is the latest entry for the reference that precedes it, and that
+// it is signed by a threshold of the root principals in that policy.
+// Signatures in the checkpoint's envelope are counted alongside the signature
+// on the checkpoint entry itself.
+func (v *PolicyVerifier) verifyCheckpoint(ctx context.Context, checkpoint *rsl.CheckpointEntry) error {
+	policyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(PolicyRef), rsl.BeforeEntryID(checkpoint.GetID()))
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return fmt.Errorf("%w: no policy entry precedes checkpoint", ErrInvalidCheckpoint)
+		}
+		return err
+	}
+	if !policyEntry.GetID().Equal(checkpoint.PolicyEntryID) {
+		return fmt.Errorf("%w: policy entry '%s' is not the latest", ErrInvalidCheckpoint, checkpoint.PolicyEntryID.String())
+	}
+
+	attestationsEntryID := gitinterface.ZeroHash
+	attestationsEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(attestations.Ref), rsl.BeforeEntryID(checkpoint.GetID()))
+	if err == nil {
+		attestationsEntryID = attestationsEntry.GetID()
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return err
+	}
+	if !attestationsEntryID.Equal(checkpoint.AttestationsEntryID) {
+		return fmt.Errorf("%w: attestations entry '%s' is not the latest", ErrInvalidCheckpoint, checkpoint.AttestationsEntryID.String())
+	}
+
+	refNames := make([]string, 0, len(checkpoint.RefEntryIDs))
+	for refName := range checkpoint.RefEntryIDs {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+
+	for _, refName := range refNames {
+		refEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(refName), rsl.BeforeEntryID(checkpoint.GetID()))
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return fmt.Errorf("%w: no entry for '%s' precedes checkpoint", ErrInvalidCheckpoint, refName)
+			}
+			return err
+		}
+		if !refEntry.GetID().Equal(checkpoint.RefEntryIDs[refName]) {
+			return fmt.Errorf("%w: entry '%s' is not the latest for '%s'", ErrInvalidCheckpoint, checkpoint.RefEntryIDs[refName].String(), refName)
+		}
+	}
+
+	if checkpoint.Signatures != nil {
+		payload, err := checkpoint.Signatures.DecodeB64Payload()
+		if err != nil {
+			return errors.Join(ErrInvalidCheckpoint, err)
+		}
+
+		summary := &rsl.CheckpointSummary{}
+		if err := json.Unmarshal(payload, summary); err != nil {
+			return errors.Join(ErrInvalidCheckpoint, err)
+		}
+		if !reflect.DeepEqual(summary, checkpoint.GetSummary()) {
+			return fmt.Errorf("%w: signed summary does not match checkpoint", ErrInvalidCheckpoint)
+		}
+	}
+
+	state, err := v.loadState(ctx, policyEntry)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		return err
+	}
+
+	principals, err := rootMetadata.GetRootPrincipals()
+	if err != nil {
+		return err
+	}
+
+	threshold, err := rootMetadata.GetRootThreshold()
+	if err != nil {
+		return err
+	}
+
+	verifier := &SignatureVerifier{
+		repository:     v.repo,
+		signatureCache: state.signatureCache,
+		name:           checkpointVerifierName,
+		principals:     principals,
+		threshold:      threshold,
+	}
+
+	_, err = verifier.Verify(ctx, checkpoint.GetID(), checkpoint.Signatures)
+	return err
+}
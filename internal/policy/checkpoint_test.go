@@ -0,0 +1,183 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCheckpoint(t *testing.T) {
+	t.Run("no policy", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+		if err := rsl.NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.CreateCheckpoint(testCtx)
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+
+	t.Run("successful checkpoint", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		policyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(PolicyRef))
+		require.Nil(t, err)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		checkpoint, err := verifier.CreateCheckpoint(testCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, policyEntry.GetID(), checkpoint.PolicyEntryID)
+		assert.Equal(t, gitinterface.ZeroHash, checkpoint.AttestationsEntryID)
+		assert.Equal(t, map[string]gitinterface.Hash{refName: entryID}, checkpoint.RefEntryIDs)
+	})
+
+	t.Run("policy violation", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.CreateCheckpoint(testCtx)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
+
+func TestVerifyRefFullWithCheckpoint(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// createRepositoryWithViolation returns a repository whose RSL has a
+	// policy violation for refName that is followed by an entry that does not
+	// violate policy by itself, along with a checkpoint that trusts the latter
+	createRepositoryWithViolation := func(t *testing.T) (*gitinterface.Repository, *rsl.CheckpointEntry) {
+		t.Helper()
+
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		policyEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(PolicyRef))
+		require.Nil(t, err)
+
+		return repo, rsl.NewCheckpointEntry(policyEntry.GetID(), gitinterface.ZeroHash, map[string]gitinterface.Hash{refName: entryID})
+	}
+
+	t.Run("checkpoint signed by root", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, rootKeyBytes))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		currentTip, err := verifier.VerifyRefFull(testCtx, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, commitIDs[0], currentTip)
+
+		// Verifying from the first entry ignores the checkpoint
+		_, err = verifier.VerifyRefFromFirstEntry(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("checkpoint with root signature in envelope", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+
+		env, err := dsse.CreateEnvelope(checkpoint.GetSummary())
+		require.Nil(t, err)
+		env, err = dsse.SignEnvelope(testCtx, env, setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes))
+		require.Nil(t, err)
+		checkpoint.Signatures = env
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, gpgKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyRefFull(testCtx, refName)
+		assert.Nil(t, err)
+	})
+
+	t.Run("checkpoint with envelope for different summary", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+
+		env, err := dsse.CreateEnvelope(&rsl.CheckpointSummary{PolicyEntryID: checkpoint.PolicyEntryID.String()})
+		require.Nil(t, err)
+		env, err = dsse.SignEnvelope(testCtx, env, setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes))
+		require.Nil(t, err)
+		checkpoint.Signatures = env
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, gpgKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err = verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("checkpoint not signed by root", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, gpgKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("checkpoint with outdated policy entry", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+		checkpoint.PolicyEntryID = gitinterface.ZeroHash
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, rootKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("checkpoint with outdated ref entry", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+
+		// The checkpoint records the invalid entry rather than the latest
+		// entry for the ref
+		invalidEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(refName), rsl.BeforeEntryID(checkpoint.RefEntryIDs[refName]))
+		require.Nil(t, err)
+		checkpoint.RefEntryIDs[refName] = invalidEntry.GetID()
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, rootKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.verifyCheckpoint(testCtx, checkpoint)
+		assert.ErrorIs(t, err, ErrInvalidCheckpoint)
+
+		_, err = verifier.VerifyRefFull(testCtx, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("checkpoint with ref entry that does not precede it", func(t *testing.T) {
+		repo, checkpoint := createRepositoryWithViolation(t)
+		checkpoint.RefEntryIDs["refs/heads/unknown"] = checkpoint.RefEntryIDs[refName]
+		require.Nil(t, checkpoint.CommitUsingSpecificKey(repo, rootKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.verifyCheckpoint(testCtx, checkpoint)
+		assert.ErrorIs(t, err, ErrInvalidCheckpoint)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+const forgeVerifierName = "forge"
+
+// EntryCreatedByForge returns true if the entry was created and signed by the
+// forge trusted in the root of trust, and the entry's reference is delegated
+// to the forge. The forge records pushes to the references delegated to it on
+// behalf of the pushers, so such entries meet the Git namespace policies for
+// the reference. Entries the forge signs for other references are not treated
+// differently from those signed by any other principal.
+func (s *State) EntryCreatedByForge(ctx context.Context, entry *rsl.ReferenceEntry) (bool, error) {
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return false, err
+	}
+
+	forge := rootMetadata.GetForge()
+	if forge == nil || !forge.Matches(entry.RefName) {
+		return false, nil
+	}
+
+	allPrincipals := rootMetadata.GetPrincipals()
+	principals := []tuf.Principal{}
+	for _, principalID := range forge.GetPrincipalIDs() {
+		principal, has := allPrincipals[principalID]
+		if !has {
+			return false, fmt.Errorf("%w: '%s'", tuf.ErrPrincipalNotFound, principalID)
+		}
+		principals = append(principals, principal)
+	}
+
+	verifier := &SignatureVerifier{
+		repository:     s.repository,
+		signatureCache: s.signatureCache,
+		name:           forgeVerifierName,
+		principals:     principals,
+		threshold:      1,
+	}
+
+	if _, err := verifier.Verify(ctx, entry.GetID(), nil); err != nil {
+		slog.Debug(fmt.Sprintf("Entry '%s' is not signed by the forge, verifying Git namespace policies...", entry.GetID().String()))
+		return false, nil
+	}
+
+	slog.Debug(fmt.Sprintf("Entry '%s' was created by the forge for delegated reference '%s'", entry.GetID().String(), entry.RefName))
+	return true, nil
+}
+
+// VerifyMergeableForForge checks if targetRef, which is delegated to the forge
+// trusted in the root of trust, can be updated to featureID by the forge. As
+// the forge's RSL entry for the update meets the Git namespace policies for the
+// reference, only the file namespace policies are checked, using the commit
+// signatures and any approvals. The forge uses this to verify a push before
+// accepting it.
+func (v *PolicyVerifier) VerifyMergeableForForge(ctx context.Context, targetRef string, featureID gitinterface.Hash) error {
+	_, err := v.verifyMergeableForCommit(ctx, targetRef, featureID, true)
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryCreatedByForge(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("no forge", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		createdByForge, err := state.EntryCreatedByForge(testCtx, entry)
+		assert.Nil(t, err)
+		assert.False(t, createdByForge)
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("entry created by forge", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithForge)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		createdByForge, err := state.EntryCreatedByForge(testCtx, entry)
+		assert.Nil(t, err)
+		assert.True(t, createdByForge)
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("entry created by pusher", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithForge)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		createdByForge, err := state.EntryCreatedByForge(testCtx, entry)
+		assert.Nil(t, err)
+		assert.False(t, createdByForge)
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("entry created by forge for reference not delegated to forge", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithForge)
+		otherRefName := "refs/heads/feature"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, otherRefName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(otherRefName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		createdByForge, err := state.EntryCreatedByForge(testCtx, entry)
+		assert.Nil(t, err)
+		assert.False(t, createdByForge)
+	})
+}
+
+func TestVerifyMergeableForForge(t *testing.T) {
+	refName := "refs/heads/main"
+	featureRefName := "refs/heads/feature"
+
+	t.Run("commit meets file rules", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithForge)
+
+		// We need to change the directory for this test because we `checkout`
+		// for older Git versions, modifying the worktree. This chdir ensures
+		// that the temporary directory is used as the worktree.
+		pwd, err := os.Getwd()
+		require.Nil(t, err)
+		require.Nil(t, os.Chdir(filepath.Join(repo.GetGitDir(), "..")))
+		defer os.Chdir(pwd) //nolint:errcheck
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+
+		// Without the forge, the push must be recorded by an authorized
+		// person
+		_, err = verifier.VerifyMergeableForCommit(testCtx, refName, commitIDs[0])
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		err = verifier.VerifyMergeableForForge(testCtx, refName, commitIDs[0])
+		assert.Nil(t, err)
+	})
+
+	t.Run("commit does not meet file rules", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithForge)
+
+		pwd, err := os.Getwd()
+		require.Nil(t, err)
+		require.Nil(t, os.Chdir(filepath.Join(repo.GetGitDir(), "..")))
+		defer os.Chdir(pwd) //nolint:errcheck
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRefName, 1, gpgUnauthorizedKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		err = verifier.VerifyMergeableForForge(testCtx, refName, commitIDs[0])
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
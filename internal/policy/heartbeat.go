@@ -0,0 +1,100 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+const heartbeatVerifierName = "heartbeat"
+
+var (
+	ErrHeartbeatNotFound = errors.New("no RSL heartbeat entry signed by a trusted principal found")
+	ErrHeartbeatStale    = errors.New("latest RSL heartbeat entry is older than the maximum staleness allowed by the root of trust")
+)
+
+// VerifyHeartbeat checks that the RSL contains a sufficiently recent heartbeat
+// entry if the latest policy requires heartbeats. The newest heartbeat entry
+// signed by a principal trusted for heartbeats must be no older than the
+// maximum staleness declared in the root of trust as of the specified time.
+// This allows detecting when an outdated RSL is being served, such as by a
+// forge that withholds newer entries.
+func (v *PolicyVerifier) VerifyHeartbeat(ctx context.Context, now time.Time) error {
+	slog.Debug("Loading latest policy to check heartbeat requirement...")
+	policyEntry, err := v.searcher.FindLatestPolicyEntry()
+	if err != nil {
+		return err
+	}
+
+	state, err := v.loadState(ctx, policyEntry)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := state.GetRootMetadata(false)
+	if err != nil {
+		return err
+	}
+
+	heartbeat := rootMetadata.GetHeartbeat()
+	if heartbeat == nil {
+		slog.Debug("Policy does not require RSL heartbeats, skipping...")
+		return nil
+	}
+
+	allPrincipals := rootMetadata.GetPrincipals()
+	principals := []tuf.Principal{}
+	for _, principalID := range heartbeat.GetPrincipalIDs() {
+		principal, has := allPrincipals[principalID]
+		if !has {
+			return fmt.Errorf("%w: '%s'", tuf.ErrPrincipalNotFound, principalID)
+		}
+		principals = append(principals, principal)
+	}
+
+	verifier := &SignatureVerifier{
+		repository:     v.repo,
+		signatureCache: state.signatureCache,
+		name:           heartbeatVerifierName,
+		principals:     principals,
+		threshold:      1,
+	}
+
+	slog.Debug("Searching for latest trusted RSL heartbeat entry...")
+	entry, err := rsl.GetLatestEntry(v.repo)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if heartbeatEntry, isHeartbeatEntry := entry.(*rsl.HeartbeatEntry); isHeartbeatEntry {
+			if _, err := verifier.Verify(ctx, heartbeatEntry.GetID(), nil); err == nil {
+				age := now.Sub(heartbeatEntry.Timestamp)
+				if age > heartbeat.GetMaxStaleness() {
+					return fmt.Errorf("%w: heartbeat '%s' was created at %s, maximum staleness is %s", ErrHeartbeatStale, heartbeatEntry.GetID().String(), heartbeatEntry.Timestamp.Format(time.RFC3339), heartbeat.GetMaxStaleness().String())
+				}
+
+				slog.Debug(fmt.Sprintf("Found trusted heartbeat '%s' created at %s", heartbeatEntry.GetID().String(), heartbeatEntry.Timestamp.Format(time.RFC3339)))
+				return nil
+			}
+
+			slog.Debug(fmt.Sprintf("Heartbeat '%s' is not signed by a trusted principal, skipping...", heartbeatEntry.GetID().String()))
+		}
+
+		entry, err = rsl.GetParentForEntry(v.repo, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return ErrHeartbeatNotFound
+			}
+			return err
+		}
+	}
+}
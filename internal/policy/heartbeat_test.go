@@ -0,0 +1,69 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHeartbeat(t *testing.T) {
+	now := time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC)
+
+	t.Run("heartbeat not required", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyHeartbeat(testCtx, now)
+		assert.Nil(t, err)
+	})
+
+	t.Run("heartbeat required", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithHeartbeat)
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyHeartbeat(testCtx, now)
+		assert.ErrorIs(t, err, ErrHeartbeatNotFound)
+
+		// Heartbeat signed by an untrusted key is ignored
+		if err := rsl.NewHeartbeatEntry(now).CommitUsingSpecificKey(repo, gpgUnauthorizedKeyBytes); err != nil {
+			t.Fatal(err)
+		}
+		err = verifier.VerifyHeartbeat(testCtx, now)
+		assert.ErrorIs(t, err, ErrHeartbeatNotFound)
+
+		// Unsigned heartbeat is ignored
+		if err := rsl.NewHeartbeatEntry(now).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		err = verifier.VerifyHeartbeat(testCtx, now)
+		assert.ErrorIs(t, err, ErrHeartbeatNotFound)
+
+		if err := rsl.NewHeartbeatEntry(now.Add(-30*time.Minute)).CommitUsingSpecificKey(repo, gpgKeyBytes); err != nil {
+			t.Fatal(err)
+		}
+		err = verifier.VerifyHeartbeat(testCtx, now)
+		assert.Nil(t, err)
+
+		// The same heartbeat is stale later on
+		err = verifier.VerifyHeartbeat(testCtx, now.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrHeartbeatStale)
+
+		// Newer untrusted heartbeats don't refresh the RSL
+		if err := rsl.NewHeartbeatEntry(now.Add(time.Hour)).CommitUsingSpecificKey(repo, gpgUnauthorizedKeyBytes); err != nil {
+			t.Fatal(err)
+		}
+		err = verifier.VerifyHeartbeat(testCtx, now.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrHeartbeatStale)
+
+		if err := rsl.NewHeartbeatEntry(now.Add(time.Hour)).CommitUsingSpecificKey(repo, gpgKeyBytes); err != nil {
+			t.Fatal(err)
+		}
+		err = verifier.VerifyHeartbeat(testCtx, now.Add(time.Hour))
+		assert.Nil(t, err)
+	})
+}
@@ -11,7 +11,9 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
 
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common/set"
 	policyopts "github.com/gittuf/gittuf/internal/policy/options/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
@@ -68,6 +70,8 @@ type State struct {
 	repository     *gitinterface.Repository
 	loadedEntry    rsl.ReferenceUpdaterEntry
 	verifiersCache map[string][]*SignatureVerifier
+	verifiersMu    sync.Mutex
+	signatureCache *cache.Persistent
 	ruleNames      *set.Set[string]
 	allPrincipals  map[string]tuf.Principal
 	hasFileRule    bool
@@ -410,6 +414,10 @@ func LoadFirstState(ctx context.Context, repo *gitinterface.Repository, opts ...
 // specified path. While walking the delegation graph for the path, signatures
 // for delegated metadata files are verified using the verifier context.
 func (s *State) FindVerifiersForPath(path string) ([]*SignatureVerifier, error) {
+	// The state may be shared by concurrent entry verifications
+	s.verifiersMu.Lock()
+	defer s.verifiersMu.Unlock()
+
 	if s.verifiersCache == nil {
 		slog.Debug("Initializing path cache in policy...")
 		s.verifiersCache = map[string][]*SignatureVerifier{}
@@ -426,9 +434,10 @@ func (s *State) FindVerifiersForPath(path string) ([]*SignatureVerifier, error)
 		// This has to go first so it's prioritized during verification
 		// At least one global rule exists, return an exhaustive verifier
 		verifier := &SignatureVerifier{
-			repository: s.repository,
-			name:       tuf.ExhaustiveVerifierName,
-			principals: []tuf.Principal{}, // we'll add all principals below
+			repository:     s.repository,
+			signatureCache: s.signatureCache,
+			name:           tuf.ExhaustiveVerifierName,
+			principals:     []tuf.Principal{}, // we'll add all principals below
 
 			// threshold doesn't matter since we set verifyExhaustively to true
 			threshold:          1,
@@ -463,6 +472,21 @@ func (s *State) FindVerifiersForPath(path string) ([]*SignatureVerifier, error)
 	return allVerifiers, nil
 }
 
+// IsProtectedReference returns true if the specified Git reference is matched
+// by at least one rule in the policy's delegation graph.
+func (s *State) IsProtectedReference(refName string) (bool, error) {
+	verifiers, err := s.findVerifiersForPathIfProtected(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName))
+	if err != nil {
+		if errors.Is(err, ErrMetadataNotFound) {
+			// No rules exist yet
+			return false, nil
+		}
+		return false, err
+	}
+
+	return len(verifiers) != 0, nil
+}
+
 func (s *State) findVerifiersForPathIfProtected(path string) ([]*SignatureVerifier, error) {
 	if !s.HasTargetsRole(TargetsRoleName) {
 		// No policies exist
@@ -503,10 +527,11 @@ func (s *State) findVerifiersForPathIfProtected(path string) ([]*SignatureVerifi
 
 			if delegation.Matches(path) {
 				verifier := &SignatureVerifier{
-					repository: s.repository,
-					name:       delegation.ID(),
-					principals: make([]tuf.Principal, 0, delegation.GetPrincipalIDs().Len()),
-					threshold:  delegation.GetThreshold(),
+					repository:     s.repository,
+					signatureCache: s.signatureCache,
+					name:           delegation.ID(),
+					principals:     make([]tuf.Principal, 0, delegation.GetPrincipalIDs().Len()),
+					threshold:      delegation.GetThreshold(),
 				}
 				for _, principalID := range delegation.GetPrincipalIDs().Contents() {
 					verifier.principals = append(verifier.principals, allPrincipals[principalID])
@@ -625,10 +650,11 @@ func (s *State) Verify(ctx context.Context) error {
 				}
 
 				verifier := &SignatureVerifier{
-					repository: s.repository,
-					name:       delegation.ID(),
-					principals: principals,
-					threshold:  delegation.GetThreshold(),
+					repository:     s.repository,
+					signatureCache: s.signatureCache,
+					name:           delegation.ID(),
+					principals:     principals,
+					threshold:      delegation.GetThreshold(),
 				}
 
 				if _, err := verifier.Verify(ctx, gitinterface.ZeroHash, env); err != nil {
@@ -1282,9 +1308,10 @@ func (s *State) getRootVerifier() (*SignatureVerifier, error) {
 	}
 
 	return &SignatureVerifier{
-		repository: s.repository,
-		principals: principals,
-		threshold:  threshold,
+		repository:     s.repository,
+		signatureCache: s.signatureCache,
+		principals:     principals,
+		threshold:      threshold,
 	}, nil
 }
 
@@ -1305,9 +1332,10 @@ func (s *State) getTargetsVerifier() (*SignatureVerifier, error) {
 	}
 
 	return &SignatureVerifier{
-		repository: s.repository,
-		principals: principals,
-		threshold:  threshold,
+		repository:     s.repository,
+		signatureCache: s.signatureCache,
+		principals:     principals,
+		threshold:      threshold,
 	}, nil
 }
 
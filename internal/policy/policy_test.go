@@ -580,7 +580,7 @@ func TestStateVerify(t *testing.T) {
 		require.Nil(t, err)
 		networkState.loadedEntry = latestNetworkEntry.(rsl.ReferenceUpdaterEntry)
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, networkRootMetadata.GetPropagationDirectives(), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, networkRootMetadata.GetPropagationDirectives(), nil, false)
 		require.Nil(t, err)
 
 		latestEntry, err := rsl.GetLatestEntry(networkRepository)
@@ -646,7 +646,7 @@ func TestStateVerify(t *testing.T) {
 		require.Nil(t, err)
 		networkState.loadedEntry = latestNetworkEntry.(rsl.ReferenceUpdaterEntry)
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		latestEntry, err := rsl.GetLatestEntry(networkRepository)
@@ -959,6 +959,31 @@ func TestStateFindVerifiersForPath(t *testing.T) {
 	})
 }
 
+func TestStateIsProtectedReference(t *testing.T) {
+	t.Parallel()
+	t.Run("with policy", func(t *testing.T) {
+		t.Parallel()
+		state := createTestStateWithPolicy(t)
+
+		isProtected, err := state.IsProtectedReference("refs/heads/main")
+		assert.Nil(t, err)
+		assert.True(t, isProtected)
+
+		isProtected, err = state.IsProtectedReference("refs/heads/unprotected")
+		assert.Nil(t, err)
+		assert.False(t, isProtected)
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		t.Parallel()
+		state := createTestStateWithOnlyRoot(t)
+
+		isProtected, err := state.IsProtectedReference("refs/heads/main")
+		assert.Nil(t, err)
+		assert.False(t, isProtected)
+	})
+}
+
 func TestStateHasFileRule(t *testing.T) {
 	t.Parallel()
 	t.Run("with file rules", func(t *testing.T) {
@@ -1419,7 +1444,7 @@ func TestReconcileStaging(t *testing.T) {
 
 		// 1. Now, propagate changes from the controller into the network
 		// repository
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		// These should not be equal, as policy has been updated, but *not*
@@ -1518,7 +1543,7 @@ func TestReconcileStaging(t *testing.T) {
 		// 2. Apply the controller's changes and propagate
 		err = Apply(testCtx, controllerRepository, false)
 		require.Nil(t, err)
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		// The network repository's staging ref should not have changed since
@@ -1630,7 +1655,7 @@ func TestReconcileStaging(t *testing.T) {
 
 		// 3. Propagate changes from the controller repository into the network
 		// repository
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		// These should not be equal, as policy has been updated, but *not*
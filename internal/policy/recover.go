@@ -0,0 +1,149 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+)
+
+var (
+	ErrNothingToRecover   = errors.New("reference passes verification, nothing to recover")
+	ErrNoValidStateForRef = errors.New("no valid state found for reference in the RSL")
+)
+
+// RecoveryPlan describes how a reference that fails verification can be
+// restored to its last valid state.
+type RecoveryPlan struct {
+	// RefName is the reference being recovered.
+	RefName string
+
+	// InvalidEntry is the first RSL entry for the reference that failed
+	// verification.
+	InvalidEntry rsl.ReferenceUpdaterEntry
+
+	// VerificationErr is the reason the invalid entry failed verification.
+	VerificationErr error
+
+	// LastGoodEntry is the latest entry for the reference before the invalid
+	// entry that has not been skipped. The reference must be restored to the
+	// state it records. For a multi-reference entry, this is the update to
+	// the reference recorded in it.
+	LastGoodEntry *rsl.ReferenceEntry
+
+	// EntriesToSkip are the entries for the reference starting with the
+	// invalid entry that have not been skipped yet. Verification requires
+	// them all to be skipped by an annotation.
+	EntriesToSkip []rsl.ReferenceUpdaterEntry
+
+	// LatestEntry is the latest entry for the reference when the plan was
+	// created. The plan no longer applies if the reference has a newer entry.
+	LatestEntry rsl.ReferenceUpdaterEntry
+}
+
+// PlanRecovery verifies the target reference and, if verification fails,
+// identifies how the reference can be restored to a valid state. The
+// reference's last good state is determined in the same way as during
+// verification: it is the latest unskipped entry for the reference before the
+// first invalid entry. ErrNothingToRecover is returned if the reference passes
+// verification.
+func (v *PolicyVerifier) PlanRecovery(ctx context.Context, target string) (*RecoveryPlan, error) {
+	_, err := v.VerifyRefFull(ctx, target)
+	if err == nil {
+		return nil, ErrNothingToRecover
+	}
+
+	var invalidEntryErr *InvalidEntryError
+	if !errors.As(err, &invalidEntryErr) {
+		return nil, err
+	}
+	invalidEntry := invalidEntryErr.Entry
+	slog.Debug(fmt.Sprintf("Entry '%s' failed verification: %s", invalidEntry.GetID().String(), invalidEntryErr.Err.Error()))
+
+	slog.Debug("Loading policy applicable at invalid entry...")
+	policyEntry, err := v.searcher.FindPolicyEntryFor(invalidEntry)
+	if err != nil {
+		return nil, err
+	}
+	state, err := v.loadState(ctx, policyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying last valid state...")
+	skipAuthorizer := state.SkipAuthorizer(ctx)
+	var lastGoodEntry *rsl.ReferenceEntry
+	searchBeforeID := invalidEntry.GetID()
+	for lastGoodEntry == nil {
+		entry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(target), rsl.BeforeEntryID(searchBeforeID), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(skipAuthorizer))
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, fmt.Errorf("%w: '%s'", ErrNoValidStateForRef, target)
+			}
+			return nil, err
+		}
+
+		// Entries such as propagation entries don't record a state the
+		// reference can be restored to
+		lastGoodEntry, _ = referenceEntryForRef(entry, target)
+		searchBeforeID = entry.GetID()
+	}
+
+	slog.Debug("Identifying entries that must be skipped...")
+	latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(target))
+	if err != nil {
+		return nil, err
+	}
+	entries, annotations, err := rsl.GetReferenceUpdaterEntriesInRangeForRef(v.repo, invalidEntry.GetID(), latestEntry.GetID(), target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skips are evaluated using the policy applicable at the invalid entry
+	// as verification uses it to check that the invalid entry and the
+	// entries for the reference after it are skipped
+	entriesToSkip := []rsl.ReferenceUpdaterEntry{}
+	for _, entry := range entries {
+		referenceEntry, isForTarget := referenceEntryForRef(entry, target)
+		if !isForTarget {
+			continue
+		}
+
+		skipped, err := state.entrySkippedBy(ctx, referenceEntry, annotations[referenceEntry.GetID().String()])
+		if err != nil {
+			return nil, err
+		}
+		if !skipped {
+			entriesToSkip = append(entriesToSkip, referenceEntry)
+		}
+	}
+
+	return &RecoveryPlan{
+		RefName:         target,
+		InvalidEntry:    invalidEntry,
+		VerificationErr: invalidEntryErr.Err,
+		LastGoodEntry:   lastGoodEntry,
+		EntriesToSkip:   entriesToSkip,
+		LatestEntry:     latestEntry,
+	}, nil
+}
+
+// referenceEntryForRef returns the update to the reference recorded in the
+// entry as a ReferenceEntry. A multi-reference entry's update to the reference
+// shares the entry's ID. The boolean is false if the entry is not a reference
+// or multi-reference entry for the reference.
+func referenceEntryForRef(entry rsl.Entry, refName string) (*rsl.ReferenceEntry, bool) {
+	switch entry := entry.(type) {
+	case *rsl.ReferenceEntry:
+		return entry, entry.GetRefName() == refName
+	case *rsl.MultiReferenceEntry:
+		return entry.GetReferenceEntryForRef(refName)
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRecovery(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("valid reference", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.PlanRecovery(testCtx, refName)
+		assert.ErrorIs(t, err, ErrNothingToRecover)
+	})
+
+	t.Run("invalid entries after valid entry", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		validEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		validEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, validEntry, gpgKeyBytes)
+
+		// Each invalid entry records a different tree so that it isn't
+		// considered a fix
+		invalidEntryIDs := []gitinterface.Hash{}
+		for i := 0; i < 2; i++ {
+			commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, i+2, gpgUnauthorizedKeyBytes)
+			entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+			invalidEntryIDs = append(invalidEntryIDs, common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes))
+		}
+
+		verifier := NewPolicyVerifier(repo)
+		plan, err := verifier.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		assert.Equal(t, refName, plan.RefName)
+		assert.Equal(t, invalidEntryIDs[0], plan.InvalidEntry.GetID())
+		assert.ErrorIs(t, plan.VerificationErr, ErrVerificationFailed)
+		assert.Equal(t, validEntryID, plan.LastGoodEntry.GetID())
+		require.Len(t, plan.EntriesToSkip, 2)
+		assert.Equal(t, invalidEntryIDs[0], plan.EntriesToSkip[0].GetID())
+		assert.Equal(t, invalidEntryIDs[1], plan.EntriesToSkip[1].GetID())
+
+		// Entries that are already skipped don't need to be skipped again
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntryIDs[0]}, true, "invalid entry")
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+
+		plan, err = verifier.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		assert.Equal(t, invalidEntryIDs[0], plan.InvalidEntry.GetID())
+		require.Len(t, plan.EntriesToSkip, 1)
+		assert.Equal(t, invalidEntryIDs[1], plan.EntriesToSkip[0].GetID())
+	})
+
+	t.Run("skips evaluated using policy applicable at invalid entry", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		validEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, validEntry, gpgKeyBytes)
+
+		invalidEntryIDs := []gitinterface.Hash{}
+		for i := 0; i < 2; i++ {
+			commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, i+2, gpgUnauthorizedKeyBytes)
+			entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+			invalidEntryIDs = append(invalidEntryIDs, common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes))
+		}
+
+		// The policy applicable at the invalid entries does not restrict
+		// who may skip entries
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntryIDs[0]}, true, "invalid entry")
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgUnauthorizedKeyBytes)
+
+		// The latest policy only authorizes gpgKeyBytes to skip entries
+		newState := createTestStateWithSkipAuthorization(t)
+		newState.repository = repo
+		require.Nil(t, newState.Commit(repo, "Add skip authorization", true, false))
+		require.Nil(t, Apply(testCtx, repo, false))
+
+		verifier := NewPolicyVerifier(repo)
+		plan, err := verifier.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		assert.Equal(t, invalidEntryIDs[0], plan.InvalidEntry.GetID())
+		require.Len(t, plan.EntriesToSkip, 1)
+		assert.Equal(t, invalidEntryIDs[1], plan.EntriesToSkip[0].GetID())
+	})
+
+	t.Run("invalid multi-reference entry after valid multi-reference entry", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		featureRef := "refs/heads/feature"
+
+		mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRef, 1, gpgKeyBytes)
+		validEntry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			refName:    mainCommitIDs[0],
+			featureRef: featureCommitIDs[0],
+		})
+		require.Nil(t, validEntry.CommitUsingSpecificKey(repo, gpgKeyBytes))
+		validEntryID, err := repo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		mainCommitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgUnauthorizedKeyBytes)
+		featureCommitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, featureRef, 1, gpgKeyBytes)
+		invalidEntry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			refName:    mainCommitIDs[1],
+			featureRef: featureCommitIDs[0],
+		})
+		require.Nil(t, invalidEntry.CommitUsingSpecificKey(repo, gpgUnauthorizedKeyBytes))
+		invalidEntryID, err := repo.GetReference(rsl.Ref)
+		require.Nil(t, err)
+
+		verifier := NewPolicyVerifier(repo)
+		plan, err := verifier.PlanRecovery(testCtx, refName)
+		require.Nil(t, err)
+		assert.Equal(t, invalidEntryID, plan.InvalidEntry.GetID())
+		assert.Equal(t, validEntryID, plan.LastGoodEntry.GetID())
+		assert.Equal(t, refName, plan.LastGoodEntry.GetRefName())
+		assert.Equal(t, validEntry.TargetIDs[refName], plan.LastGoodEntry.GetTargetID())
+		require.Len(t, plan.EntriesToSkip, 1)
+		assert.Equal(t, invalidEntryID, plan.EntriesToSkip[0].GetID())
+
+		// Skipping the invalid entry makes the reference verify again
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntryID}, true, "invalid entry")
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		require.Nil(t, repo.SetReference(refName, plan.LastGoodEntry.GetTargetID()))
+		entry := rsl.NewReferenceEntry(refName, plan.LastGoodEntry.GetTargetID())
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		_, err = verifier.PlanRecovery(testCtx, refName)
+		assert.ErrorIs(t, err, ErrNothingToRecover)
+	})
+
+	t.Run("no valid state", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		_, err := verifier.PlanRecovery(testCtx, refName)
+		assert.ErrorIs(t, err, ErrNoValidStateForRef)
+	})
+}
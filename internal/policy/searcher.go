@@ -22,6 +22,7 @@ type searcher interface {
 	FindPolicyEntryFor(rsl.Entry) (rsl.ReferenceUpdaterEntry, error)
 	FindPolicyEntriesInRange(rsl.Entry, rsl.Entry) ([]rsl.ReferenceUpdaterEntry, error)
 	FindAttestationsEntryFor(rsl.Entry) (rsl.ReferenceUpdaterEntry, error)
+	FindAttestationsEntriesInRange(rsl.Entry, rsl.Entry) ([]rsl.ReferenceUpdaterEntry, error)
 	FindLatestAttestationsEntry() (rsl.ReferenceUpdaterEntry, error)
 }
 
@@ -128,6 +129,26 @@ func (r *regularSearcher) FindAttestationsEntryFor(entry rsl.Entry) (rsl.Referen
 	return attestationsEntry, nil
 }
 
+// FindAttestationsEntriesInRange returns all attestations RSL entries in the
+// specified range. firstEntry and lastEntry are included if they are for the
+// attestations ref.
+func (r *regularSearcher) FindAttestationsEntriesInRange(firstEntry, lastEntry rsl.Entry) ([]rsl.ReferenceUpdaterEntry, error) {
+	allEntries, _, err := rsl.GetReferenceUpdaterEntriesInRangeForRef(r.repo, firstEntry.GetID(), lastEntry.GetID(), attestations.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// The range includes entries for other gittuf references
+	allAttestationsEntries := []rsl.ReferenceUpdaterEntry{}
+	for _, entry := range allEntries {
+		if entry.GetRefName() == attestations.Ref {
+			allAttestationsEntries = append(allAttestationsEntries, entry)
+		}
+	}
+
+	return allAttestationsEntries, nil
+}
+
 // FindLatestAttestationsEntry returns the latest RSL entry for the attestations
 // reference.
 func (r *regularSearcher) FindLatestAttestationsEntry() (rsl.ReferenceUpdaterEntry, error) {
@@ -294,6 +315,40 @@ func (c *cacheSearcher) FindAttestationsEntryFor(entry rsl.Entry) (rsl.Reference
 	return attestationsEntry, nil
 }
 
+// FindAttestationsEntriesInRange returns all attestations RSL entries in the
+// specified range. firstEntry and lastEntry are included if they are for the
+// attestations ref.
+func (c *cacheSearcher) FindAttestationsEntriesInRange(firstEntry, lastEntry rsl.Entry) ([]rsl.ReferenceUpdaterEntry, error) {
+	if c.persistentCache == nil {
+		return c.searcher.FindAttestationsEntriesInRange(firstEntry, lastEntry)
+	}
+
+	if lastEntry.GetNumber() == 0 || firstEntry.GetNumber() == 0 {
+		// first or last entry doesn't have a number
+		return c.searcher.FindAttestationsEntriesInRange(firstEntry, lastEntry)
+	}
+
+	if firstEntry, isReferenceUpdaterEntry := firstEntry.(rsl.ReferenceUpdaterEntry); isReferenceUpdaterEntry && firstEntry.GetRefName() == attestations.Ref {
+		slog.Debug("Requested first entry is an attestations entry, inserting into cache...")
+		c.persistentCache.InsertAttestationEntryNumber(firstEntry.GetNumber(), firstEntry.GetID())
+	}
+	if lastEntry, isReferenceUpdaterEntry := lastEntry.(rsl.ReferenceUpdaterEntry); isReferenceUpdaterEntry && lastEntry.GetRefName() == attestations.Ref {
+		slog.Debug("Requested last entry is an attestations entry, inserting into cache...")
+		c.persistentCache.InsertAttestationEntryNumber(lastEntry.GetNumber(), lastEntry.GetID())
+	}
+
+	entries := []rsl.ReferenceUpdaterEntry{}
+	for _, index := range c.persistentCache.FindAttestationsEntriesInRange(firstEntry.GetNumber(), lastEntry.GetNumber()) {
+		entry, err := loadRSLReferenceUpdaterEntry(c.repo, index.GetEntryID())
+		if err != nil {
+			return c.searcher.FindAttestationsEntriesInRange(firstEntry, lastEntry)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
 func (c *cacheSearcher) FindLatestAttestationsEntry() (rsl.ReferenceUpdaterEntry, error) {
 	if c.persistentCache == nil {
 		return c.searcher.FindLatestAttestationsEntry()
@@ -332,3 +387,21 @@ func loadRSLReferenceUpdaterEntry(repo *gitinterface.Repository, entryID gitinte
 
 	return entry, nil
 }
+
+// loadRSLReferenceUpdaterEntryForRef loads the entry with the specified ID as a
+// reference updater entry for refName. If the entry is a multi-reference entry,
+// its update to refName is returned.
+func loadRSLReferenceUpdaterEntryForRef(repo *gitinterface.Repository, entryID gitinterface.Hash, refName string) (rsl.ReferenceUpdaterEntry, error) {
+	entryT, err := rsl.GetEntry(repo, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range rsl.GetReferenceUpdaterEntries(entryT) {
+		if entry.GetRefName() == refName {
+			return entry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: entry '%s' is not for '%s'", rsl.ErrRSLEntryDoesNotMatchRef, entryID.String(), refName)
+}
@@ -204,6 +204,48 @@ func TestRegularSearcher(t *testing.T) {
 		assert.Equal(t, entry.GetID(), attestationsEntry.GetID())
 	})
 
+	t.Run("attestations in range", func(t *testing.T) {
+		t.Parallel()
+
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+		firstEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedAttestationsEntries := []rsl.ReferenceUpdaterEntry{}
+		for _, refName := range []string{attestations.Ref, "refs/heads/main", PolicyRef, attestations.Ref} {
+			if err := rsl.NewReferenceEntry(refName, gitinterface.ZeroHash).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+
+			if refName == attestations.Ref {
+				entry, err := rsl.GetLatestEntry(repo)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedAttestationsEntries = append(expectedAttestationsEntries, entry.(*rsl.ReferenceEntry))
+			}
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		searcher := newRegularSearcher(repo)
+
+		attestationsEntries, err := searcher.FindAttestationsEntriesInRange(firstEntry, latestEntry)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAttestationsEntries, attestationsEntries)
+
+		// Range excluding the latest attestations entry
+		attestationsEntries, err = searcher.FindAttestationsEntriesInRange(firstEntry, expectedAttestationsEntries[0])
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAttestationsEntries[:1], attestationsEntries)
+	})
+
 	t.Run("attestations do not exist", func(t *testing.T) {
 		t.Parallel()
 
@@ -571,6 +613,56 @@ func TestCacheSearcher(t *testing.T) {
 		assert.Equal(t, entry.GetID(), attestationsEntry.GetID())
 	})
 
+	t.Run("attestations in range", func(t *testing.T) {
+		t.Parallel()
+
+		repo, _ := createTestRepository(t, createTestStateWithOnlyRoot)
+
+		firstEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedAttestationsEntries := []rsl.ReferenceUpdaterEntry{}
+		for _, refName := range []string{attestations.Ref, "refs/heads/main", PolicyRef, attestations.Ref} {
+			if err := rsl.NewReferenceEntry(refName, gitinterface.ZeroHash).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+
+			if refName == attestations.Ref {
+				entry, err := rsl.GetLatestEntry(repo)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedAttestationsEntries = append(expectedAttestationsEntries, entry.(*rsl.ReferenceEntry))
+			}
+		}
+
+		if err := cache.PopulatePersistentCache(repo); err != nil {
+			t.Fatal(err)
+		}
+		persistentCache, err := cache.LoadPersistentCache(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		searcher := newCacheSearcher(repo, persistentCache)
+
+		attestationsEntries, err := searcher.FindAttestationsEntriesInRange(firstEntry, latestEntry)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAttestationsEntries, attestationsEntries)
+
+		// Range excluding the latest attestations entry
+		attestationsEntries, err = searcher.FindAttestationsEntriesInRange(firstEntry, expectedAttestationsEntries[0])
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAttestationsEntries[:1], attestationsEntries)
+	})
+
 	t.Run("attestations do not exist", func(t *testing.T) {
 		t.Parallel()
 
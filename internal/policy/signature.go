@@ -5,11 +5,15 @@ package policy
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
 
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
@@ -20,10 +24,12 @@ import (
 	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
 )
 
 type SignatureVerifier struct {
 	repository         *gitinterface.Repository
+	signatureCache     *cache.Persistent // signatureCache is optional, verification results are memoized if set
 	name               string
 	principals         []tuf.Principal
 	threshold          int
@@ -78,7 +84,7 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 			keys := principal.Keys()
 
 			for _, key := range keys {
-				err := v.repository.VerifySignature(ctx, gitObjectID, key)
+				err := v.verifyGitSignature(ctx, gitObjectID, key)
 				if err == nil {
 					// Signature verification succeeded
 					slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' successfully used to verify signature of Git object '%s', counting '%s' towards threshold...", key.KeyID, principal.ID(), gitObjectID.String(), principal.ID()))
@@ -117,6 +123,15 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 	if env != nil {
 		// Second, verify signatures on the envelope
 
+		envelopeDigest := ""
+		if v.signatureCache != nil {
+			digest, err := getEnvelopeDigest(env)
+			if err != nil {
+				return nil, err
+			}
+			envelopeDigest = digest
+		}
+
 		// We have to verify the envelope independently for each principal
 		// trusted in the verifier as a principal may have multiple keys
 		// associated with them.
@@ -128,9 +143,30 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 				continue
 			}
 
-			principalVerifiers := []sslibdsse.Verifier{}
-
 			keys := principal.Keys()
+
+			if v.signatureCache != nil {
+				cachedKeyIDs := []string{}
+				for _, key := range keys {
+					if usedKeyIDs.Has(key.KeyID) {
+						continue
+					}
+					if verified, has := v.signatureCache.GetEnvelopeSignatureResult(envelopeDigest, key); has && verified {
+						cachedKeyIDs = append(cachedKeyIDs, key.KeyID)
+					}
+				}
+
+				if len(cachedKeyIDs) != 0 {
+					for _, keyID := range cachedKeyIDs {
+						slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' previously used to verify signature of attestation, counting '%s' towards threshold...", keyID, principal.ID(), principal.ID()))
+						usedKeyIDs.Add(keyID)
+					}
+					usedPrincipalIDs.Add(principal.ID())
+					continue
+				}
+			}
+
+			principalVerifiers := []sslibdsse.Verifier{}
 			for _, key := range keys {
 				if usedKeyIDs.Has(key.KeyID) {
 					// this key has been encountered before, possibly because
@@ -202,6 +238,14 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 				slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' successfully used to verify signature of attestation, counting '%s' towards threshold...", key.KeyID, principal.ID(), principal.ID()))
 				usedKeyIDs.Add(key.KeyID)
 				usedPrincipalIDs.Add(principal.ID())
+
+				if v.signatureCache != nil {
+					for _, principalKey := range keys {
+						if principalKey.KeyID == key.KeyID {
+							v.signatureCache.SetEnvelopeSignatureResult(envelopeDigest, principalKey, true)
+						}
+					}
+				}
 			}
 		}
 	}
@@ -215,3 +259,57 @@ func (v *SignatureVerifier) Verify(ctx context.Context, gitObjectID gitinterface
 	// principals that were used
 	return usedPrincipalIDs, ErrVerifierConditionsUnmet
 }
+
+// verifyPushCertificate checks if the push certificate is signed by one of the
+// principals trusted in the verifier who is not already in usedPrincipalIDs. The
+// ID of the principal who signed the certificate is returned, or an empty
+// string if none of the principals signed it. The caller must ensure the
+// certificate records the reference update under verification.
+func (v *SignatureVerifier) verifyPushCertificate(ctx context.Context, certificate *gitinterface.PushCertificate, usedPrincipalIDs *set.Set[string]) (string, error) {
+	slog.Debug("Verifying signature of push certificate...")
+	for _, principal := range v.principals {
+		if usedPrincipalIDs.Has(principal.ID()) {
+			slog.Debug(fmt.Sprintf("Principal '%s' has already been counted towards the threshold, skipping...", principal.ID()))
+			continue
+		}
+
+		for _, key := range principal.Keys() {
+			err := v.repository.VerifyPushCertificate(ctx, certificate, key)
+			if err == nil {
+				slog.Debug(fmt.Sprintf("Public key '%s' belonging to principal '%s' successfully used to verify signature of push certificate, counting '%s' towards threshold...", key.KeyID, principal.ID(), principal.ID()))
+				return principal.ID(), nil
+			}
+			if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
+				continue
+			}
+			if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+				return "", err
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// verifyGitSignature verifies the signature of the Git object using the key.
+// If the verifier has a signature cache, a previously recorded result is used
+// when available, and new results are recorded.
+func (v *SignatureVerifier) verifyGitSignature(ctx context.Context, gitObjectID gitinterface.Hash, key *signerverifier.SSLibKey) error {
+	if v.signatureCache == nil {
+		return v.repository.VerifySignature(ctx, gitObjectID, key)
+	}
+
+	return v.signatureCache.VerifyGitSignature(ctx, v.repository, gitObjectID, key)
+}
+
+// getEnvelopeDigest returns the hex-encoded SHA-256 digest of the envelope,
+// used to identify it in the signature cache.
+func getEnvelopeDigest(env *sslibdsse.Envelope) (string, error) {
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(envBytes)
+	return hex.EncodeToString(digest[:]), nil
+}
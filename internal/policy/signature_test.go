@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
@@ -169,3 +170,68 @@ func TestSignatureVerifier(t *testing.T) {
 		}
 	}
 }
+
+func TestSignatureVerifierWithSignatureCache(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tmpDir, false)
+
+	gpgKeyR, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey := tufv01.NewKeyFromSSLibKey(gpgKeyR)
+
+	rootSigner := setupSSHKeysForSigning(t, rootKeyBytes, rootPubKeyBytes)
+	rootPubKeyR := rootSigner.MetadataKey()
+	rootPubKey := tufv01.NewKeyFromSSLibKey(rootPubKeyR)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, gpgKeyBytes)
+	commitID := commitIDs[0]
+
+	attestation, err := dsse.CreateEnvelope(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestation, err = dsse.SignEnvelope(testCtx, attestation, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatureCache := &cache.Persistent{}
+	verifier := &SignatureVerifier{
+		repository:     repo,
+		signatureCache: signatureCache,
+		principals:     []tuf.Principal{gpgKey, rootPubKey},
+		threshold:      2,
+	}
+
+	principalIDs, err := verifier.Verify(testCtx, commitID, attestation)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, principalIDs.Len())
+
+	// Both results are recorded
+	verified, has := signatureCache.GetGitSignatureResult(commitID, gpgKeyR)
+	assert.True(t, has)
+	assert.True(t, verified)
+
+	envelopeDigest, err := getEnvelopeDigest(attestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, has = signatureCache.GetEnvelopeSignatureResult(envelopeDigest, rootPubKeyR)
+	assert.True(t, has)
+	assert.True(t, verified)
+
+	// Cached results are used on subsequent verifications
+	principalIDs, err = verifier.Verify(testCtx, commitID, attestation)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, principalIDs.Len())
+
+	// A recorded failure is reported as an incorrect key
+	signatureCache.SetGitSignatureResult(commitID, gpgKeyR, false)
+	verifier.threshold = 1
+	verifier.principals = []tuf.Principal{gpgKey}
+	_, err = verifier.Verify(testCtx, commitID, nil)
+	assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+}
@@ -0,0 +1,94 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+const skipAuthorizationVerifierName = "skip-authorization"
+
+// entrySkippedBy returns true if the entry has been skipped by the annotations
+// as permitted by the policy. When the root of trust declares skip
+// authorizations for the entry's reference, the entry is only considered
+// skipped when a threshold of the principals trusted by one of the skip
+// authorizations have signed annotations that skip the entry. Other skip
+// annotations are ignored. When no skip authorization applies to the
+// reference, any annotation that skips the entry is honored.
+func (s *State) entrySkippedBy(ctx context.Context, entry *rsl.ReferenceEntry, annotations []*rsl.AnnotationEntry) (bool, error) {
+	if !entry.SkippedBy(annotations) {
+		return false, nil
+	}
+
+	rootMetadata, err := s.GetRootMetadata(false)
+	if err != nil {
+		return false, err
+	}
+
+	skipAuthorizations := []tuf.SkipAuthorization{}
+	for _, skipAuthorization := range rootMetadata.GetSkipAuthorizations() {
+		if skipAuthorization.Matches(entry.RefName) {
+			skipAuthorizations = append(skipAuthorizations, skipAuthorization)
+		}
+	}
+	if len(skipAuthorizations) == 0 {
+		return true, nil
+	}
+
+	allPrincipals := rootMetadata.GetPrincipals()
+	for _, skipAuthorization := range skipAuthorizations {
+		principals := []tuf.Principal{}
+		for _, principalID := range skipAuthorization.GetPrincipalIDs() {
+			principal, has := allPrincipals[principalID]
+			if !has {
+				return false, fmt.Errorf("%w: '%s'", tuf.ErrPrincipalNotFound, principalID)
+			}
+			principals = append(principals, principal)
+		}
+
+		verifier := &SignatureVerifier{
+			repository:     s.repository,
+			signatureCache: s.signatureCache,
+			name:           skipAuthorizationVerifierName,
+			principals:     principals,
+			threshold:      1,
+		}
+
+		skippedBy := set.NewSet[string]()
+		for _, annotation := range annotations {
+			if !annotation.RefersTo(entry.GetID()) || !annotation.Skip {
+				continue
+			}
+
+			principalIDs, err := verifier.Verify(ctx, annotation.GetID(), nil)
+			if err != nil {
+				slog.Debug(fmt.Sprintf("Annotation '%s' is not signed by a principal trusted by skip authorization '%s', ignoring...", annotation.GetID().String(), skipAuthorization.GetName()))
+				continue
+			}
+			skippedBy.Extend(principalIDs)
+		}
+
+		if skippedBy.Len() >= skipAuthorization.GetThreshold() {
+			slog.Debug(fmt.Sprintf("Entry '%s' has been skipped as permitted by skip authorization '%s'", entry.GetID().String(), skipAuthorization.GetName()))
+			return true, nil
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Entry '%s' has not been skipped by a threshold of authorized principals, ignoring skip annotations...", entry.GetID().String()))
+	return false, nil
+}
+
+// SkipAuthorizer returns an rsl.SkipAuthorizer that only honors skip
+// annotations permitted by the policy.
+func (s *State) SkipAuthorizer(ctx context.Context) rsl.SkipAuthorizer {
+	return func(entry *rsl.ReferenceEntry, annotations []*rsl.AnnotationEntry) (bool, error) {
+		return s.entrySkippedBy(ctx, entry, annotations)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntrySkippedBy(t *testing.T) {
+	t.Run("no skip authorizations", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		skipped, err := state.entrySkippedBy(testCtx, entry, nil)
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+
+		// Any skip annotation is honored
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entry.ID}, true, "invalid entry")
+		annotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgUnauthorizedKeyBytes)
+
+		skipped, err = state.entrySkippedBy(testCtx, entry, []*rsl.AnnotationEntry{annotation})
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+	})
+
+	t.Run("with skip authorization", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithSkipAuthorization)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		// Skip annotation by an unauthorized principal is ignored
+		unauthorizedAnnotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entry.ID}, true, "invalid entry")
+		unauthorizedAnnotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, unauthorizedAnnotation, gpgUnauthorizedKeyBytes)
+
+		skipped, err := state.entrySkippedBy(testCtx, entry, []*rsl.AnnotationEntry{unauthorizedAnnotation})
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+
+		// Annotation by an authorized principal that doesn't skip the entry
+		// is ignored
+		nonSkipAnnotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entry.ID}, false, "valid entry")
+		nonSkipAnnotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, nonSkipAnnotation, gpgKeyBytes)
+
+		skipped, err = state.entrySkippedBy(testCtx, entry, []*rsl.AnnotationEntry{unauthorizedAnnotation, nonSkipAnnotation})
+		assert.Nil(t, err)
+		assert.False(t, skipped)
+
+		// Skip annotation by an authorized principal is honored
+		authorizedAnnotation := rsl.NewAnnotationEntry([]gitinterface.Hash{entry.ID}, true, "invalid entry")
+		authorizedAnnotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, authorizedAnnotation, gpgKeyBytes)
+
+		skipped, err = state.entrySkippedBy(testCtx, entry, []*rsl.AnnotationEntry{unauthorizedAnnotation, authorizedAnnotation})
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+
+		// Skip authorizations only apply to matching references
+		otherRefName := "refs/heads/feature"
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, otherRefName, 1, gpgKeyBytes)
+		otherEntry := rsl.NewReferenceEntry(otherRefName, commitIDs[0])
+		otherEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, otherEntry, gpgKeyBytes)
+
+		otherAnnotation := rsl.NewAnnotationEntry([]gitinterface.Hash{otherEntry.ID}, true, "invalid entry")
+		otherAnnotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, otherAnnotation, gpgUnauthorizedKeyBytes)
+
+		skipped, err = state.entrySkippedBy(testCtx, otherEntry, []*rsl.AnnotationEntry{otherAnnotation})
+		assert.Nil(t, err)
+		assert.True(t, skipped)
+	})
+
+	t.Run("verification ignores unauthorized skips", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithSkipAuthorization)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		firstEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+		validCommitID := commitIDs[0]
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		invalidEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		invalidEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, invalidEntry, gpgUnauthorizedKeyBytes)
+
+		// Skip the invalid entry without authorization and fix the state
+		if err := repo.SetReference(refName, validCommitID); err != nil {
+			t.Fatal(err)
+		}
+		annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntry.ID}, true, "invalid entry")
+		annotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, validCommitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier := NewPolicyVerifier(repo)
+		err := verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// The unauthorized skip is also ignored when finding the latest entry
+		// for the reference
+		state, err := LoadCurrentState(testCtx, repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(refName), rsl.BeforeEntryID(entry.ID), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(state.SkipAuthorizer(testCtx)))
+		assert.Nil(t, err)
+		assert.Equal(t, invalidEntry.ID, latestEntry.GetID())
+
+		// Skip the invalid entry with authorization
+		annotation = rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntry.ID}, true, "invalid entry")
+		annotation.ID = common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, validCommitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		verifier = NewPolicyVerifier(repo)
+		err = verifier.VerifyRelativeForRef(testCtx, firstEntry, entry, refName)
+		assert.Nil(t, err)
+	})
+}
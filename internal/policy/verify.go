@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/attestations/authorizations"
@@ -41,6 +43,26 @@ var (
 	ErrMetadataRollbackDetected                          = errors.New("gittuf policy metadata rollback detected")
 )
 
+// InvalidEntryError is returned when verification of a reference fails and the
+// reference is not restored to a valid state. It identifies the first RSL
+// entry for the reference that failed verification.
+type InvalidEntryError struct {
+	Entry rsl.ReferenceUpdaterEntry
+	Err   error
+}
+
+func (e *InvalidEntryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidEntryError) Unwrap() error {
+	return e.Err
+}
+
+// verificationWorkers is the maximum number of RSL entries that are verified
+// concurrently.
+var verificationWorkers = runtime.NumCPU()
+
 // PolicyVerifier implements various gittuf verification workflows.
 type PolicyVerifier struct { //nolint:revive
 	// We want to call this PolicyVerifier to avoid any confusion with
@@ -51,13 +73,32 @@ type PolicyVerifier struct { //nolint:revive
 
 	persistentCacheEnabled bool
 	persistentCache        *cache.Persistent
+
+	// policyStates and attestationsStates hold states that have already been
+	// loaded by the verifier, keyed by the ID of the corresponding RSL entry.
+	// They allow a single verifier to be reused across several refs without
+	// reloading and reverifying the same policy and attestations.
+	policyStates       map[string]*State
+	attestationsStates map[string]*attestations.Attestations
+
+	// latestCheckpoint holds the latest trusted RSL checkpoint, if any, once
+	// checkpointSearched is set.
+	latestCheckpoint   *rsl.CheckpointEntry
+	checkpointSearched bool
+
+	// multiReferenceResults holds the outcome of verifying the updates in
+	// multi-reference entries so that they're verified once across refs.
+	multiReferenceResults *multiReferenceResults
 }
 
 func NewPolicyVerifier(repo *gitinterface.Repository) *PolicyVerifier {
 	searcher := newSearcher(repo)
 	verifier := &PolicyVerifier{
-		repo:     repo,
-		searcher: searcher,
+		repo:                  repo,
+		searcher:              searcher,
+		policyStates:          map[string]*State{},
+		attestationsStates:    map[string]*attestations.Attestations{},
+		multiReferenceResults: newMultiReferenceResults(),
 	}
 
 	if searcher, isCacheSearcher := searcher.(*cacheSearcher); isCacheSearcher {
@@ -82,36 +123,40 @@ func (v *PolicyVerifier) VerifyRef(ctx context.Context, target string) (gitinter
 	return latestEntry.GetTargetID(), v.VerifyRelativeForRef(ctx, latestEntry, latestEntry, target)
 }
 
-// VerifyRefFull verifies the entire RSL for the target ref from the first
-// entry. The expected Git ID for the ref in the latest RSL entry is returned if
-// the policy verification is successful.
+// VerifyRefFull verifies the RSL for the target ref. Verification starts from
+// the last entry for the ref verified previously if the persistent cache is
+// enabled, or from the ref's entry in the latest trusted checkpoint. Otherwise,
+// verification starts from the first entry for the ref. The expected Git ID for
+// the ref in the latest RSL entry is returned if the policy verification is
+// successful.
 func (v *PolicyVerifier) VerifyRefFull(ctx context.Context, target string) (gitinterface.Hash, error) {
+	slog.Debug(fmt.Sprintf("Identifying starting RSL entry for '%s'...", target))
+	firstEntry, err := v.findStartingEntryForRef(ctx, target)
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	// Find latest entry for target
+	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", target))
+	latestEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(target))
+	if err != nil {
+		return gitinterface.ZeroHash, err
+	}
+
+	slog.Debug("Verifying all entries...")
+	return latestEntry.GetTargetID(), v.VerifyRelativeForRef(ctx, firstEntry, latestEntry, target)
+}
+
+// VerifyRefFromFirstEntry verifies the entire RSL for the target ref from the
+// first entry, ignoring the persistent cache and any checkpoints. The expected
+// Git ID for the ref in the latest RSL entry is returned if the policy
+// verification is successful.
+func (v *PolicyVerifier) VerifyRefFromFirstEntry(ctx context.Context, target string) (gitinterface.Hash, error) {
 	// Trace RSL back to the start
 	slog.Debug(fmt.Sprintf("Identifying first RSL entry for '%s'...", target))
-	var (
-		firstEntry rsl.ReferenceUpdaterEntry
-		err        error
-	)
-	switch v.persistentCacheEnabled {
-	case true:
-		slog.Debug("Cache is enabled, checking for last verified entry...")
-		entryNumber, entryID := v.persistentCache.GetLastVerifiedEntryForRef(target)
-		if entryNumber != 0 {
-			firstEntry, err = loadRSLReferenceUpdaterEntry(v.repo, entryID)
-			if err != nil {
-				return gitinterface.ZeroHash, err
-			}
-
-			// break because we've loaded the entry and don't need to fallthrough
-			break
-		}
-		slog.Debug("Cache doesn't have last verified entry for ref...")
-		fallthrough
-	case false:
-		firstEntry, _, err = rsl.GetFirstReferenceUpdaterEntryForRef(v.repo, target)
-		if err != nil {
-			return gitinterface.ZeroHash, err
-		}
+	firstEntry, _, err := rsl.GetFirstReferenceUpdaterEntryForRef(v.repo, target)
+	if err != nil {
+		return gitinterface.ZeroHash, err
 	}
 
 	// Find latest entry for target
@@ -136,8 +181,18 @@ func (v *PolicyVerifier) VerifyRefFromEntry(ctx context.Context, target string,
 		return gitinterface.ZeroHash, err
 	}
 
-	fromEntry, isRefEntry := fromEntryT.(*rsl.ReferenceEntry)
-	if !isRefEntry {
+	var fromEntry *rsl.ReferenceEntry
+	switch entry := fromEntryT.(type) {
+	case *rsl.ReferenceEntry:
+		fromEntry = entry
+	case *rsl.MultiReferenceEntry:
+		// Any of the entry's references identifies it as the starting point
+		fromEntry = entry.GetReferenceEntries()[0]
+		if entry, has := entry.GetReferenceEntryForRef(target); has {
+			fromEntry = entry
+		}
+	}
+	if fromEntry == nil {
 		// TODO: we should instead find the latest reference entry
 		// before the entryID and use that
 		return gitinterface.ZeroHash, fmt.Errorf("starting entry is not an RSL reference entry")
@@ -172,9 +227,16 @@ func (v *PolicyVerifier) VerifyMergeable(ctx context.Context, targetRef, feature
 		return false, ErrCannotVerifyMergeableForTagRef
 	}
 
+	// Load latest policy
+	slog.Debug("Loading latest policy...")
+	currentPolicy, err := v.loadLatestState(ctx)
+	if err != nil {
+		return false, err
+	}
+
 	var fromID gitinterface.Hash
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", targetRef))
-	targetEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(targetRef), rsl.IsUnskipped())
+	targetEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(targetRef), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(currentPolicy.SkipAuthorizer(ctx)))
 	switch {
 	case err == nil:
 		fromID = targetEntry.GetTargetID()
@@ -185,12 +247,12 @@ func (v *PolicyVerifier) VerifyMergeable(ctx context.Context, targetRef, feature
 	}
 
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", featureRef))
-	featureEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(featureRef), rsl.IsUnskipped())
+	featureEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(featureRef), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(currentPolicy.SkipAuthorizer(ctx)))
 	if err != nil {
 		return false, err
 	}
 
-	return v.verifyMergeable(ctx, targetRef, fromID, featureEntry.GetTargetID())
+	return v.verifyMergeable(ctx, currentPolicy, targetRef, fromID, featureEntry.GetTargetID(), false)
 }
 
 // VerifyMergeableForCommit checks if the targetRef can be updated to reflect
@@ -208,13 +270,24 @@ func (v *PolicyVerifier) VerifyMergeable(ctx context.Context, targetRef, feature
 // person for the rule, i.e., an authorized person must sign the merge's RSL
 // entry
 func (v *PolicyVerifier) VerifyMergeableForCommit(ctx context.Context, targetRef string, featureID gitinterface.Hash) (bool, error) {
+	return v.verifyMergeableForCommit(ctx, targetRef, featureID, false)
+}
+
+func (v *PolicyVerifier) verifyMergeableForCommit(ctx context.Context, targetRef string, featureID gitinterface.Hash, delegatedToForge bool) (bool, error) {
 	if strings.HasPrefix(targetRef, gitinterface.TagRefPrefix) {
 		return false, ErrCannotVerifyMergeableForTagRef
 	}
 
+	// Load latest policy
+	slog.Debug("Loading latest policy...")
+	currentPolicy, err := v.loadLatestState(ctx)
+	if err != nil {
+		return false, err
+	}
+
 	var fromID gitinterface.Hash
 	slog.Debug(fmt.Sprintf("Identifying latest RSL entry for '%s'...", targetRef))
-	targetEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(targetRef), rsl.IsUnskipped())
+	targetEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(targetRef), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(currentPolicy.SkipAuthorizer(ctx)))
 	switch {
 	case err == nil:
 		fromID = targetEntry.GetTargetID()
@@ -224,32 +297,21 @@ func (v *PolicyVerifier) VerifyMergeableForCommit(ctx context.Context, targetRef
 		return false, err
 	}
 
-	return v.verifyMergeable(ctx, targetRef, fromID, featureID)
+	return v.verifyMergeable(ctx, currentPolicy, targetRef, fromID, featureID, delegatedToForge)
 }
 
-func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string, fromID, featureID gitinterface.Hash) (bool, error) {
+// verifyMergeable checks if targetRef can be updated from fromID to featureID.
+// If delegatedToForge is set, the Git namespace policies are not checked as
+// the forge's RSL entry for the update meets them, see
+// State.EntryCreatedByForge.
+func (v *PolicyVerifier) verifyMergeable(ctx context.Context, currentPolicy *State, targetRef string, fromID, featureID gitinterface.Hash, delegatedToForge bool) (bool, error) {
 	// We're specifically focused on commit merges here, this doesn't apply to
 	// tags
 	mergeTreeID, err := v.repo.GetMergeTree(fromID, featureID)
 	if err != nil {
 		return false, err
 	}
-	var (
-		currentPolicy       *State
-		currentAttestations *attestations.Attestations
-	)
-
-	// Load latest policy
-	slog.Debug("Loading latest policy...")
-	initialPolicyEntry, err := v.searcher.FindLatestPolicyEntry()
-	if err != nil {
-		return false, err
-	}
-	state, err := LoadState(ctx, v.repo, initialPolicyEntry)
-	if err != nil {
-		return false, err
-	}
-	currentPolicy = state
+	var currentAttestations *attestations.Attestations
 
 	// Load latest attestations
 	slog.Debug("Loading latest attestations...")
@@ -271,9 +333,12 @@ func (v *PolicyVerifier) verifyMergeable(ctx context.Context, targetRef string,
 		return false, err
 	}
 
-	_, rslEntrySignatureNeededForThreshold, err := verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, targetRef), gitinterface.ZeroHash, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withVerifyMergeable())
-	if err != nil {
-		return false, fmt.Errorf("not enough approvals to meet Git namespace policies, %w", ErrVerificationFailed)
+	rslEntrySignatureNeededForThreshold := false
+	if !delegatedToForge {
+		_, rslEntrySignatureNeededForThreshold, err = verifyGitObjectAndAttestations(ctx, currentPolicy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, targetRef), gitinterface.ZeroHash, authorizationAttestation, withApproverPrincipalIDs(approverIDs), withVerifyMergeable())
+		if err != nil {
+			return false, fmt.Errorf("not enough approvals to meet Git namespace policies, %w", ErrVerificationFailed)
+		}
 	}
 
 	if !currentPolicy.hasFileRule {
@@ -468,7 +533,7 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 	slog.Debug(fmt.Sprintf("Loading policy applicable at first entry '%s'...", firstEntry.GetID().String()))
 	initialPolicyEntry, err := v.searcher.FindPolicyEntryFor(firstEntry)
 	if err == nil {
-		state, err := LoadState(ctx, v.repo, initialPolicyEntry)
+		state, err := v.loadState(ctx, initialPolicyEntry)
 		if err != nil {
 			return err
 		}
@@ -483,7 +548,7 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 	slog.Debug(fmt.Sprintf("Loading attestations applicable at first entry '%s'...", firstEntry.GetID().String()))
 	initialAttestationsEntry, err := v.searcher.FindAttestationsEntryFor(firstEntry)
 	if err == nil {
-		attestationsState, err := attestations.LoadAttestationsForEntry(v.repo, initialAttestationsEntry)
+		attestationsState, err := v.loadAttestations(initialAttestationsEntry)
 		if err != nil {
 			return err
 		}
@@ -503,9 +568,18 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 	}
 	// require len(entries) != 0
 
+	slog.Debug("Identifying policy segments in range...")
+	segmentBoundaries, err := v.findSegmentBoundaries(firstEntry, lastEntry)
+	if err != nil {
+		return err
+	}
+
 	// Verify each entry, looking for a fix when an invalid entry is encountered
 	var invalidEntry rsl.ReferenceUpdaterEntry
 	var verificationErr error
+	// segmentResults holds the outcome of entries verified ahead of time in
+	// the current policy segment, keyed by entry ID
+	segmentResults := map[string]error{}
 	for len(entries) != 0 {
 		// invariant invalidEntry == nil || inRecoveryMode() == true
 		if invalidEntry == nil {
@@ -536,6 +610,7 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 					if err != nil {
 						return err
 					}
+					v.useSignatureCache(newPolicy)
 					// require newPolicy != nil
 
 					if currentPolicy != nil {
@@ -553,6 +628,8 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 					}
 
 					currentPolicy = newPolicy
+					// Results verified ahead of time used the previous policy
+					clear(segmentResults)
 
 					if v.persistentCacheEnabled {
 						v.persistentCache.InsertPolicyEntryNumber(entry.GetNumber(), entry.GetID())
@@ -563,12 +640,13 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 
 				slog.Debug("Checking if entry is for attestations reference...")
 				if entry.GetRefName() == attestations.Ref {
-					newAttestationsState, err := attestations.LoadAttestationsForEntry(v.repo, entry)
+					newAttestationsState, err := v.loadAttestations(entry)
 					if err != nil {
 						return err
 					}
 
 					currentAttestations = newAttestationsState
+					clear(segmentResults)
 
 					if v.persistentCacheEnabled {
 						v.persistentCache.InsertAttestationEntryNumber(entry.GetNumber(), entry.GetID())
@@ -581,12 +659,37 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 				if currentPolicy == nil {
 					return ErrPolicyNotFound
 				}
-				if err := verifyEntry(ctx, v.repo, currentPolicy, currentAttestations, entry); err != nil {
+
+				slog.Debug("Checking if entry is a trusted baseline...")
+				isBaseline, err := currentPolicy.isBaselineEntry(ctx, entry, annotations[entry.GetID().String()])
+				if err != nil {
+					return err
+				}
+				if isBaseline {
+					// History prior to the baseline predates the adoption of
+					// gittuf and cannot be verified
+					slog.Debug(fmt.Sprintf("Entry '%s' is a trusted baseline for '%s', proceeding...", entry.GetID().String(), entry.GetRefName()))
+					if v.persistentCacheEnabled {
+						v.persistentCache.SetLastVerifiedEntryForRef(entry.GetRefName(), entry.GetNumber(), entry.GetID())
+					}
+					continue
+				}
+
+				entryErr, verified := segmentResults[entry.GetID().String()]
+				if !verified {
+					segmentResults = verifySegment(ctx, v.repo, currentPolicy, currentAttestations, entry, entries, segmentBoundaries, v.multiReferenceResults)
+					entryErr = segmentResults[entry.GetID().String()]
+				}
+				if err := entryErr; err != nil {
 					slog.Debug(fmt.Sprintf("Violation found: %s", err.Error()))
 					slog.Debug("Checking if entry has been revoked...")
 					// If the invalid entry is never marked as skipped, we return err
-					if !entry.SkippedBy(annotations[entry.GetID().String()]) {
-						return err
+					skipped, skipErr := currentPolicy.entrySkippedBy(ctx, entry, annotations[entry.GetID().String()])
+					if skipErr != nil {
+						return skipErr
+					}
+					if !skipped {
+						return &InvalidEntryError{Entry: entry, Err: err}
 					}
 
 					// The invalid entry's been marked as skipped but we still need
@@ -597,7 +700,7 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 
 					if len(entries) == 0 {
 						// Fix entry does not exist after revoking annotation
-						return verificationErr
+						return &InvalidEntryError{Entry: invalidEntry, Err: verificationErr}
 					}
 				} else if v.persistentCacheEnabled {
 					// Verification has passed, add to cache
@@ -626,13 +729,17 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 
 		// 1. What's the last good state?
 		slog.Debug("Identifying last valid state...")
-		lastGoodEntry, lastGoodEntryAnnotations, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(invalidEntry.GetRefName()), rsl.BeforeEntryID(invalidEntry.GetID()), rsl.IsUnskipped(), rsl.IsReferenceEntry())
+		lastGoodEntry, lastGoodEntryAnnotations, err := rsl.GetLatestReferenceUpdaterEntry(v.repo, rsl.ForReference(invalidEntry.GetRefName()), rsl.BeforeEntryID(invalidEntry.GetID()), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(currentPolicy.SkipAuthorizer(ctx)), rsl.IsReferenceEntry())
 		if err != nil {
 			return err
 		}
 		slog.Debug("Verifying identified last valid entry has not been revoked...")
-		if lastGoodEntry.(*rsl.ReferenceEntry).SkippedBy(lastGoodEntryAnnotations) {
-			// this type assertion is fine because we use the rsl.IsReferenceEntry opt
+		// this type assertion is fine because we use the rsl.IsReferenceEntry opt
+		lastGoodEntrySkipped, err := currentPolicy.entrySkippedBy(ctx, lastGoodEntry.(*rsl.ReferenceEntry), lastGoodEntryAnnotations)
+		if err != nil {
+			return err
+		}
+		if lastGoodEntrySkipped {
 			return ErrLastGoodEntryIsSkipped
 		}
 		// require lastGoodEntry != nil
@@ -640,10 +747,14 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 		// TODO: what if the very first entry for a ref is a violation?
 
 		// gittuf requires the fix to point to a commit that is tree-same as the
-		// last good state
-		lastGoodTreeID, err := v.repo.GetCommitTreeID(lastGoodEntry.GetTargetID())
-		if err != nil {
-			return err
+		// last good state; if the last good entry deleted the ref, the fix must
+		// delete it as well
+		lastGoodTreeID := v.repo.ZeroHash()
+		if !lastGoodEntry.GetTargetID().IsZero() {
+			lastGoodTreeID, err = v.repo.GetCommitTreeID(lastGoodEntry.GetTargetID())
+			if err != nil {
+				return err
+			}
 		}
 
 		// 2. What entries do we have in the current verification set for the
@@ -676,9 +787,12 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 				continue
 
 			case *rsl.ReferenceEntry:
-				newCommitTreeID, err := v.repo.GetCommitTreeID(newEntry.GetTargetID())
-				if err != nil {
-					return err
+				newCommitTreeID := v.repo.ZeroHash()
+				if !newEntry.IsDeletion() {
+					newCommitTreeID, err = v.repo.GetCommitTreeID(newEntry.GetTargetID())
+					if err != nil {
+						return err
+					}
 				}
 
 				slog.Debug("Checking if entry is tree-same with last valid state...")
@@ -689,7 +803,11 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 					// If it has been skipped, it's not actually a fix and we need
 					// to keep looking
 					slog.Debug("Verifying potential fix entry has not been revoked...")
-					if !newEntry.SkippedBy(annotations[newEntry.ID.String()]) {
+					skipped, err := currentPolicy.entrySkippedBy(ctx, newEntry, annotations[newEntry.ID.String()])
+					if err != nil {
+						return err
+					}
+					if !skipped {
 						slog.Debug("Fix entry found, proceeding with regular verification workflow...")
 						fixed = true
 						fixEntry = newEntry
@@ -703,7 +821,11 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 				// newEntry is not tree-same / commit-same, so it is automatically
 				// invalid, check that it's been marked as revoked
 				slog.Debug("Checking non-fix entry has been revoked as well...")
-				if !newEntry.SkippedBy(annotations[newEntry.ID.String()]) {
+				skipped, err := currentPolicy.entrySkippedBy(ctx, newEntry, annotations[newEntry.ID.String()])
+				if err != nil {
+					return err
+				}
+				if !skipped {
 					invalidIntermediateEntries = append(invalidIntermediateEntries, newEntry)
 				}
 			}
@@ -711,13 +833,13 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 
 		if !fixed {
 			// If we haven't found a fix, return the original error
-			return verificationErr
+			return &InvalidEntryError{Entry: invalidEntry, Err: verificationErr}
 		}
 
 		if len(invalidIntermediateEntries) != 0 {
 			// We may have found a fix but if an invalid intermediate entry
 			// wasn't skipped, return error
-			return ErrInvalidEntryNotSkipped
+			return &InvalidEntryError{Entry: invalidEntry, Err: ErrInvalidEntryNotSkipped}
 		}
 
 		// Reset these trackers to continue verification with rest of the queue
@@ -735,6 +857,171 @@ func (v *PolicyVerifier) VerifyRelativeForRef(ctx context.Context, firstEntry, l
 	return nil
 }
 
+// findStartingEntryForRef identifies the entry for the target ref that
+// verification must start from. The last verified entry recorded in the
+// persistent cache is preferred, followed by the ref's entry in the latest
+// trusted checkpoint, and finally the first entry for the ref in the RSL.
+func (v *PolicyVerifier) findStartingEntryForRef(ctx context.Context, target string) (rsl.ReferenceUpdaterEntry, error) {
+	if v.persistentCacheEnabled {
+		slog.Debug("Cache is enabled, checking for last verified entry...")
+		entryNumber, entryID := v.persistentCache.GetLastVerifiedEntryForRef(target)
+		if entryNumber != 0 {
+			return loadRSLReferenceUpdaterEntryForRef(v.repo, entryID, target)
+		}
+		slog.Debug("Cache doesn't have last verified entry for ref...")
+	}
+
+	checkpoint, err := v.findLatestTrustedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil {
+		if entryID, has := checkpoint.RefEntryIDs[target]; has {
+			slog.Debug(fmt.Sprintf("Starting verification from entry '%s' recorded in checkpoint '%s'...", entryID.String(), checkpoint.GetID().String()))
+			refEntry, err := loadRSLReferenceUpdaterEntryForRef(v.repo, entryID, target)
+			if err != nil {
+				if errors.Is(err, rsl.ErrRSLEntryDoesNotMatchRef) {
+					return nil, errors.Join(ErrInvalidCheckpoint, err)
+				}
+				return nil, err
+			}
+			return refEntry, nil
+		}
+		slog.Debug("Checkpoint doesn't have entry for ref...")
+	}
+
+	firstEntry, _, err := rsl.GetFirstReferenceUpdaterEntryForRef(v.repo, target)
+	return firstEntry, err
+}
+
+// findSegmentBoundaries uses the searcher to identify the policy and
+// attestations entries between firstEntry and lastEntry. Entries after each of
+// them may be subject to a different policy or set of attestations, so they
+// split the range into segments that are verified independently. The IDs of
+// the boundary entries are returned.
+func (v *PolicyVerifier) findSegmentBoundaries(firstEntry, lastEntry rsl.ReferenceUpdaterEntry) (map[string]bool, error) {
+	policyEntries, err := v.searcher.FindPolicyEntriesInRange(firstEntry, lastEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	attestationsEntries, err := v.searcher.FindAttestationsEntriesInRange(firstEntry, lastEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	boundaries := map[string]bool{}
+	for _, entry := range append(policyEntries, attestationsEntries...) {
+		boundaries[entry.GetID().String()] = true
+	}
+
+	return boundaries, nil
+}
+
+// verifySegment verifies entry and the reference entries that follow it in the
+// queue concurrently, using up to verificationWorkers workers. The segment ends
+// at the next boundary entry identified by findSegmentBoundaries. The result of
+// each entry's verification is returned keyed by the entry's ID, so that the
+// caller can process them in RSL order. The updates recorded in
+// multi-reference entries are verified once and reused using
+// multiReferenceResults.
+func verifySegment(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, queue []rsl.ReferenceUpdaterEntry, boundaries map[string]bool, multiReferenceResults *multiReferenceResults) map[string]error {
+	segment := []*rsl.ReferenceEntry{entry}
+	for _, queuedEntry := range queue {
+		if boundaries[queuedEntry.GetID().String()] {
+			break
+		}
+
+		queuedEntry, isReferenceEntry := queuedEntry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || queuedEntry.GetRefName() == PolicyStagingRef {
+			// Propagation entries and policy staging entries are not
+			// verified
+			continue
+		}
+
+		segment = append(segment, queuedEntry)
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying %d entries in current policy segment...", len(segment)))
+
+	results := make([]error, len(segment))
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+	for range min(verificationWorkers, len(segment)) {
+		wg.Go(func() {
+			for index := range indices {
+				results[index] = verifyEntryUnit(ctx, repo, policy, attestationsState, segment[index], multiReferenceResults)
+			}
+		})
+	}
+	for index := range segment {
+		indices <- index
+	}
+	close(indices)
+	wg.Wait()
+
+	segmentResults := make(map[string]error, len(segment))
+	for index, segmentEntry := range segment {
+		segmentResults[segmentEntry.GetID().String()] = results[index]
+	}
+
+	return segmentResults
+}
+
+// loadState returns the verified policy state for the specified entry. States
+// are loaded using LoadState the first time they're requested and reused for
+// subsequent requests.
+func (v *PolicyVerifier) loadState(ctx context.Context, entry rsl.ReferenceUpdaterEntry) (*State, error) {
+	if state, has := v.policyStates[entry.GetID().String()]; has {
+		slog.Debug(fmt.Sprintf("Reusing previously loaded policy for entry '%s'...", entry.GetID().String()))
+		return state, nil
+	}
+
+	state, err := LoadState(ctx, v.repo, entry)
+	if err != nil {
+		return nil, err
+	}
+	v.useSignatureCache(state)
+
+	v.policyStates[entry.GetID().String()] = state
+	return state, nil
+}
+
+// useSignatureCache configures the state to record signature verification
+// results in the persistent cache and reuse them, if the cache is enabled.
+func (v *PolicyVerifier) useSignatureCache(state *State) {
+	if v.persistentCacheEnabled {
+		state.signatureCache = v.persistentCache
+	}
+}
+
+// loadLatestState loads the latest policy recorded in the RSL.
+func (v *PolicyVerifier) loadLatestState(ctx context.Context) (*State, error) {
+	policyEntry, err := v.searcher.FindLatestPolicyEntry()
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadState(ctx, v.repo, policyEntry)
+}
+
+// loadAttestations returns the attestations state for the specified entry,
+// reusing a previously loaded state if available.
+func (v *PolicyVerifier) loadAttestations(entry rsl.ReferenceUpdaterEntry) (*attestations.Attestations, error) {
+	if attestationsState, has := v.attestationsStates[entry.GetID().String()]; has {
+		slog.Debug(fmt.Sprintf("Reusing previously loaded attestations for entry '%s'...", entry.GetID().String()))
+		return attestationsState, nil
+	}
+
+	attestationsState, err := attestations.LoadAttestationsForEntry(v.repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	v.attestationsStates[entry.GetID().String()] = attestationsState
+	return attestationsState, nil
+}
+
 func (s *StateMetadata) VerifyNewStateMetadata(_ context.Context, newStateMetadata *StateMetadata) error {
 	// Check new state's root version number is >= current state's root version number
 	currentRootMetadata, err := s.GetRootMetadata(false)
@@ -842,6 +1129,85 @@ func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 	return nil
 }
 
+// verifyEntryUnit verifies entry along with the updates to other references
+// recorded in the same RSL entry. The updates in a multi-reference entry are
+// recorded atomically, so the entry is only valid for any of its references if
+// the updates to all of them meet policy. The updates are verified the first
+// time any of the entry's references is verified, and the results are recorded
+// in multiReferenceResults for its other references.
+func verifyEntryUnit(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry, multiReferenceResults *multiReferenceResults) error {
+	referenceResults, verified := multiReferenceResults.get(entry.GetID())
+	if !verified {
+		rslEntry, err := rsl.GetEntry(repo, entry.GetID())
+		if err != nil {
+			return err
+		}
+
+		multiReferenceEntry, isMultiReferenceEntry := rslEntry.(*rsl.MultiReferenceEntry)
+		if !isMultiReferenceEntry {
+			return verifyEntry(ctx, repo, policy, attestationsState, entry)
+		}
+
+		slog.Debug(fmt.Sprintf("Entry '%s' updates multiple references, verifying all updates...", entry.GetID().String()))
+		for _, referenceEntry := range multiReferenceEntry.GetReferenceEntries() {
+			referenceResults = append(referenceResults, referenceResult{
+				refName: referenceEntry.RefName,
+				err:     verifyEntry(ctx, repo, policy, attestationsState, referenceEntry),
+			})
+		}
+		multiReferenceResults.set(entry.GetID(), referenceResults)
+	}
+
+	for _, result := range referenceResults {
+		if result.refName == entry.RefName && result.err != nil {
+			return result.err
+		}
+	}
+	for _, result := range referenceResults {
+		if result.err != nil {
+			return fmt.Errorf("verifying update to '%s' recorded in the same entry failed: %w", result.refName, result.err)
+		}
+	}
+
+	return nil
+}
+
+// referenceResult is the outcome of verifying the update to a single reference
+// recorded in a multi-reference entry.
+type referenceResult struct {
+	refName string
+	err     error
+}
+
+// multiReferenceResults records the outcome of verifying each update in
+// multi-reference entries, keyed by the entry's ID. A multi-reference entry is
+// expanded into an entry per reference, and the entry must be verified as a
+// unit for each of them. The policy applicable to an entry is determined by its
+// position in the RSL, so the results can be reused for all of its references.
+type multiReferenceResults struct {
+	mu      sync.Mutex
+	results map[string][]referenceResult
+}
+
+func newMultiReferenceResults() *multiReferenceResults {
+	return &multiReferenceResults{results: map[string][]referenceResult{}}
+}
+
+func (m *multiReferenceResults) get(entryID gitinterface.Hash) ([]referenceResult, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results, has := m.results[entryID.String()]
+	return results, has
+}
+
+func (m *multiReferenceResults) set(entryID gitinterface.Hash, results []referenceResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.results[entryID.String()] = results
+}
+
 // verifyEntry is a helper to verify an entry's signature using the specified
 // policy. The specified policy is used for the RSL entry itself. However, for
 // commit signatures, verifyEntry checks when the commit was first introduced
@@ -853,6 +1219,11 @@ func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *Sta
 		return nil
 	}
 
+	if entry.IsDeletion() {
+		slog.Debug("Entry records deletion of reference, using deletion verification workflow...")
+		return verifyDeletionEntry(ctx, repo, policy, attestationsState, entry)
+	}
+
 	if strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix) {
 		slog.Debug("Entry is for a Git tag, using tag verification workflow...")
 		return verifyTagEntry(ctx, repo, policy, attestationsState, entry)
@@ -866,9 +1237,22 @@ func verifyEntry(ctx context.Context, repo *gitinterface.Repository, policy *Sta
 		return err
 	}
 
-	// Verify Git namespace policies using the RSL entry and attestations
-	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.ID, authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs)); err != nil {
-		return fmt.Errorf("verifying Git namespace policies failed, %w", ErrVerificationFailed)
+	createdByForge, err := policy.EntryCreatedByForge(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	// Verify Git namespace policies using the RSL entry and attestations,
+	// unless the entry was created by the forge the reference is delegated to
+	if !createdByForge {
+		pushCertificate, err := getPushCertificate(repo, policy, attestationsState, entry)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.ID, authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withPushCertificate(pushCertificate)); err != nil {
+			return fmt.Errorf("verifying Git namespace policies failed, %w", ErrVerificationFailed)
+		}
 	}
 
 	// Check if policy has file rules at all for efficiency
@@ -928,13 +1312,57 @@ func verifyTagEntry(ctx context.Context, repo *gitinterface.Repository, policy *
 		return err
 	}
 
-	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.GetID(), authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withTagObjectID(entry.TargetID)); err != nil {
+	createdByForge, err := policy.EntryCreatedByForge(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if createdByForge {
+		return nil
+	}
+
+	pushCertificate, err := getPushCertificate(repo, policy, attestationsState, entry)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.GetID(), authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withTagObjectID(entry.TargetID), withPushCertificate(pushCertificate)); err != nil {
 		return fmt.Errorf("verifying tag entry failed, %w: %w", ErrVerificationFailed, err)
 	}
 
 	return nil
 }
 
+// verifyDeletionEntry verifies an RSL entry that records the deletion of a
+// reference. A reference may be deleted by the same set of principals who may
+// update it, so only the Git namespace policies are verified. As there are no
+// new commits, file namespace policies do not apply.
+func verifyDeletionEntry(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+	slog.Debug("Searching for applicable reference authorizations and code reviews...")
+	authorizationAttestation, approverKeyIDs, err := getApproverAttestationAndKeyIDs(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return err
+	}
+
+	createdByForge, err := policy.EntryCreatedByForge(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if createdByForge {
+		return nil
+	}
+
+	pushCertificate, err := getPushCertificate(repo, policy, attestationsState, entry)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := verifyGitObjectAndAttestations(ctx, policy, fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName), entry.ID, authorizationAttestation, withApproverPrincipalIDs(approverKeyIDs), withPushCertificate(pushCertificate)); err != nil {
+		return fmt.Errorf("verifying deletion of reference failed, %w", ErrVerificationFailed)
+	}
+
+	return nil
+}
+
 func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, *set.Set[string], error) {
 	if attestationsState == nil {
 		return nil, nil, nil
@@ -963,11 +1391,15 @@ func getApproverAttestationAndKeyIDs(ctx context.Context, repo *gitinterface.Rep
 		toID  gitinterface.Hash
 		isTag bool
 	)
-	if strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix) {
+	switch {
+	case entry.IsDeletion():
+		// The reference is deleted, so the approval is for the zero hash
+		toID, err = repo.ZeroHash(), nil
+	case strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix):
 		isTag = true
 
 		toID, err = repo.GetTagTarget(entry.TargetID)
-	} else {
+	default:
 		toID, err = repo.GetCommitTreeID(entry.TargetID)
 	}
 	if err != nil {
@@ -1022,10 +1454,11 @@ func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinter
 			if githubApprovalAttestation != nil {
 				slog.Debug("GitHub pull request approval found, verifying attestation signature...")
 				approvalVerifier := &SignatureVerifier{
-					repository: policy.repository,
-					name:       appName,
-					principals: appPrincipals,
-					threshold:  appEntry.GetThreshold(),
+					repository:     policy.repository,
+					signatureCache: policy.signatureCache,
+					name:           appName,
+					principals:     appPrincipals,
+					threshold:      appEntry.GetThreshold(),
 				}
 				_, err := approvalVerifier.Verify(ctx, nil, githubApprovalAttestation)
 				if err != nil {
@@ -1059,6 +1492,52 @@ func getApproverAttestationAndKeyIDsForIndex(ctx context.Context, repo *gitinter
 	return authorizationAttestation, approverIdentities, nil
 }
 
+// getPushCertificate returns the push certificate that records the update made
+// in the entry, if one exists in the attestations. As with reference
+// authorizations, the update is identified using the target of the prior RSL
+// entry for the same reference. The certificate must have been issued for a
+// push to this repository, identified by the repository location in the
+// policy's root metadata, so that certificates for the same update pushed to
+// a fork or mirror cannot be replayed. Otherwise, the certificate is ignored.
+// Certificate nonces are issued by the receiving server and cannot be checked
+// by gittuf.
+func getPushCertificate(repo *gitinterface.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*gitinterface.PushCertificate, error) {
+	if attestationsState == nil {
+		return nil, nil
+	}
+
+	fromID := repo.ZeroHash()
+	priorRefEntry, _, err := rsl.GetLatestReferenceUpdaterEntry(repo, rsl.ForReference(entry.RefName), rsl.BeforeEntryID(entry.ID))
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+	} else {
+		fromID = priorRefEntry.GetTargetID()
+	}
+
+	slog.Debug(fmt.Sprintf("Finding push certificate for '%s' from '%s' to '%s'...", entry.RefName, fromID.String(), entry.TargetID.String()))
+	pushCertificate, err := attestationsState.GetPushCertificateFor(repo, entry.RefName, fromID.String(), entry.TargetID.String())
+	if err != nil {
+		if !errors.Is(err, attestations.ErrPushCertificateNotFound) {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	rootMetadata, err := policy.GetRootMetadata(false)
+	if err != nil {
+		return nil, err
+	}
+	if !pushCertificate.IsForRepository(rootMetadata.GetRepositoryLocation()) {
+		slog.Debug(fmt.Sprintf("Push certificate for '%s' was issued for '%s' and not this repository, ignoring...", entry.RefName, pushCertificate.Pushee))
+		return nil, nil
+	}
+
+	return pushCertificate, nil
+}
+
 // getCommits identifies the commits introduced to the entry's ref since the
 // last RSL entry for the same ref. These commits are then verified for file
 // policies.
@@ -1088,6 +1567,7 @@ type verifyGitObjectAndAttestationsOptions struct {
 	verifyMergeable      bool
 	trustedVerifier      string
 	tagObjectID          gitinterface.Hash
+	pushCertificate      *gitinterface.PushCertificate
 }
 
 type verifyGitObjectAndAttestationsOption func(o *verifyGitObjectAndAttestationsOptions)
@@ -1127,6 +1607,16 @@ func withTagObjectID(objID gitinterface.Hash) verifyGitObjectAndAttestationsOpti
 	}
 }
 
+// withPushCertificate is used to set a push certificate that records the
+// reference update under verification. The principal who signed the certificate
+// is counted towards the threshold, which allows an RSL entry created by
+// someone else, such as a server, to be verified.
+func withPushCertificate(certificate *gitinterface.PushCertificate) verifyGitObjectAndAttestationsOption {
+	return func(o *verifyGitObjectAndAttestationsOptions) {
+		o.pushCertificate = certificate
+	}
+}
+
 func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target string, gitID gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, opts ...verifyGitObjectAndAttestationsOption) (string, bool, error) {
 	options := &verifyGitObjectAndAttestationsOptions{tagObjectID: gitinterface.ZeroHash}
 	for _, fn := range opts {
@@ -1157,7 +1647,7 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 			appNames = append(appNames, appName)
 		}
 	}
-	verifiedUsing, acceptedPrincipalIDs, rslSignatureNeededForThreshold, err := verifyGitObjectAndAttestationsUsingVerifiers(ctx, verifiers, gitID, authorizationAttestation, appNames, options.approverPrincipalIDs, options.verifyMergeable)
+	verifiedUsing, acceptedPrincipalIDs, rslSignatureNeededForThreshold, err := verifyGitObjectAndAttestationsUsingVerifiers(ctx, verifiers, gitID, authorizationAttestation, options.pushCertificate, appNames, options.approverPrincipalIDs, options.verifyMergeable)
 	if err != nil {
 		return "", false, err
 	}
@@ -1169,9 +1659,12 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 			// explicitly not looking at the attestation
 			// that applies to the _push_
 			// thus, we also set threshold to 1
-			verifier.threshold = 1
+			// the verifier is copied as it may be cached in the policy
+			// state and shared with concurrent verifications
+			tagVerifier := *verifier
+			tagVerifier.threshold = 1
 
-			_, err := verifier.Verify(ctx, options.tagObjectID, nil)
+			_, err := tagVerifier.Verify(ctx, options.tagObjectID, nil)
 			if err == nil {
 				// Signature verification succeeded
 				tagObjVerified = true
@@ -1258,13 +1751,28 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 					return "", false, err
 				}
 
-				currentEntryRef, isReferenceEntry := currentEntry.(*rsl.ReferenceEntry)
-				if !isReferenceEntry {
+				var currentEntryRef *rsl.ReferenceEntry
+				switch currentEntry := currentEntry.(type) {
+				case *rsl.ReferenceEntry:
+					currentEntryRef = currentEntry
+				case *rsl.MultiReferenceEntry:
+					// The rule matched target, so it identifies the
+					// reference whose update we must check
+					currentEntryRef, _ = currentEntry.GetReferenceEntryForRef(strings.TrimPrefix(target, gitReferenceRuleScheme+":"))
+				}
+				if currentEntryRef == nil {
 					slog.Debug(fmt.Sprintf("Expected '%s' to be RSL reference entry, aborting verification of block force pushes global rule...", gitID.String()))
 					return "", false, rsl.ErrInvalidRSLEntry
 				}
 
-				previousEntryRef, _, err := rsl.GetLatestReferenceUpdaterEntry(policy.repository, rsl.BeforeEntryID(currentEntry.GetID()), rsl.ForReference(currentEntryRef.RefName), rsl.IsUnskipped())
+				if currentEntryRef.IsDeletion() {
+					// Deleting a reference discards its history, just like a
+					// force push
+					slog.Debug(fmt.Sprintf("Entry '%s' deletes reference '%s', which is disallowed by block force pushes global rule", currentEntryRef.GetID().String(), currentEntryRef.RefName))
+					return "", false, ErrVerifierConditionsUnmet
+				}
+
+				previousEntryRef, _, err := rsl.GetLatestReferenceUpdaterEntry(policy.repository, rsl.BeforeEntryID(currentEntry.GetID()), rsl.ForReference(currentEntryRef.RefName), rsl.IsUnskipped(), rsl.WithSkipAuthorizer(policy.SkipAuthorizer(ctx)))
 				if err != nil {
 					if errors.Is(err, rsl.ErrRSLEntryNotFound) {
 						slog.Debug(fmt.Sprintf("Entry '%s' is the first one for reference '%s', cannot check if it's a force push", currentEntryRef.GetID().String(), currentEntryRef.RefName))
@@ -1274,6 +1782,13 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 					return "", false, err
 				}
 
+				if previousEntryRef.GetTargetID().IsZero() {
+					// The reference was deleted, so the current entry
+					// recreates it
+					slog.Debug(fmt.Sprintf("Entry '%s' recreates deleted reference '%s', cannot check if it's a force push", currentEntryRef.GetID().String(), currentEntryRef.RefName))
+					break
+				}
+
 				knows, err := policy.repository.KnowsCommit(currentEntryRef.TargetID, previousEntryRef.GetTargetID())
 				if err != nil {
 					return "", false, err
@@ -1295,7 +1810,7 @@ func verifyGitObjectAndAttestations(ctx context.Context, policy *State, target s
 	return verifiedUsing, rslSignatureNeededForThreshold, nil
 }
 
-func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers []*SignatureVerifier, gitID gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, appNames []string, approverIDs *set.Set[string], verifyMergeable bool) (string, *set.Set[string], bool, error) {
+func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers []*SignatureVerifier, gitID gitinterface.Hash, authorizationAttestation *sslibdsse.Envelope, pushCertificate *gitinterface.PushCertificate, appNames []string, approverIDs *set.Set[string], verifyMergeable bool) (string, *set.Set[string], bool, error) {
 	if len(verifiers) == 0 {
 		return "", nil, false, ErrNoVerifiers
 	}
@@ -1318,6 +1833,17 @@ func verifyGitObjectAndAttestationsUsingVerifiers(ctx context.Context, verifiers
 			return "", nil, false, err
 		}
 
+		if pushCertificate != nil {
+			slog.Debug("Using signer of push certificate...")
+			principalID, err := verifier.verifyPushCertificate(ctx, pushCertificate, usedPrincipalIDs)
+			if err != nil {
+				return "", nil, false, err
+			}
+			if principalID != "" {
+				usedPrincipalIDs.Add(principalID)
+			}
+		}
+
 		if approverIDs != nil {
 			slog.Debug("Using approvers from code review tool attestations...")
 			// Unify the principalIDs we've already used with that listed in
@@ -114,6 +114,62 @@ func TestVerifyRefFromEntry(t *testing.T) {
 	assert.Equal(t, commitIDs[1], currentTip)
 }
 
+func TestVerifyRefWithMultiReferenceEntry(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	t.Run("all updates meet policy", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, mainRef, 1, gpgKeyBytes)
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRef, 1, gpgKeyBytes)
+		entry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			mainRef:    mainCommitIDs[0],
+			featureRef: featureCommitIDs[0],
+		})
+		require.Nil(t, entry.CommitUsingSpecificKey(repo, gpgKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+
+		currentTip, err := verifier.VerifyRefFull(testCtx, mainRef)
+		assert.Nil(t, err)
+		assert.Equal(t, mainCommitIDs[0], currentTip)
+
+		currentTip, err = verifier.VerifyRefFull(testCtx, featureRef)
+		assert.Nil(t, err)
+		assert.Equal(t, featureCommitIDs[0], currentTip)
+
+		currentTip, err = verifier.VerifyRef(testCtx, featureRef)
+		assert.Nil(t, err)
+		assert.Equal(t, featureCommitIDs[0], currentTip)
+	})
+
+	t.Run("one update violates policy", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, mainRef, 1, gpgUnauthorizedKeyBytes)
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRef, 1, gpgKeyBytes)
+		entry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			mainRef:    mainCommitIDs[0],
+			featureRef: featureCommitIDs[0],
+		})
+		require.Nil(t, entry.CommitUsingSpecificKey(repo, gpgUnauthorizedKeyBytes))
+
+		verifier := NewPolicyVerifier(repo)
+
+		_, err := verifier.VerifyRefFull(testCtx, mainRef)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// The update to feature doesn't violate policy by itself, but it was
+		// recorded atomically with the update to main
+		_, err = verifier.VerifyRefFull(testCtx, featureRef)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		_, err = verifier.VerifyRef(testCtx, featureRef)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
+
 func TestVerifyRelativeForRefUsingPersons(t *testing.T) {
 	t.Run("no recovery", func(t *testing.T) {
 		repo, _ := createTestRepository(t, createTestStateWithPolicyUsingPersons)
@@ -2039,7 +2095,7 @@ func TestVerifyNetwork(t *testing.T) {
 		networkRootMetadata, err := networkState.GetRootMetadata(false)
 		require.Nil(t, err)
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		verifier := NewPolicyVerifier(controllerRepository)
@@ -2122,7 +2178,7 @@ func TestVerifyNetwork(t *testing.T) {
 		networkRootMetadata, err := networkState.GetRootMetadata(false)
 		require.Nil(t, err)
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		newRootKey := tufv01.NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
@@ -2913,6 +2969,152 @@ func TestVerifyRelativeForRef(t *testing.T) {
 	})
 }
 
+func TestVerifySegment(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	entries := []*rsl.ReferenceEntry{}
+	for _, signer := range [][]byte{gpgKeyBytes, gpgKeyBytes, gpgUnauthorizedKeyBytes, gpgKeyBytes} {
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, signer)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, signer)
+		entry.ID = entryID
+		entries = append(entries, entry)
+	}
+
+	// Verify each entry sequentially to compare against
+	sequentialResults := map[string]error{}
+	for _, entry := range entries {
+		sequentialResults[entry.GetID().String()] = verifyEntryUnit(testCtx, repo, state, nil, entry, newMultiReferenceResults())
+	}
+	require.ErrorIs(t, sequentialResults[entries[2].GetID().String()], ErrVerificationFailed)
+
+	setWorkers := func(t *testing.T, workers int) {
+		t.Helper()
+
+		originalWorkers := verificationWorkers
+		t.Cleanup(func() {
+			verificationWorkers = originalWorkers
+		})
+		verificationWorkers = workers
+	}
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("whole queue is a segment, %d workers", workers), func(t *testing.T) {
+			setWorkers(t, workers)
+
+			queue := []rsl.ReferenceUpdaterEntry{entries[1], entries[2], entries[3]}
+
+			results := verifySegment(testCtx, repo, state, nil, entries[0], queue, map[string]bool{}, newMultiReferenceResults())
+			assert.Equal(t, sequentialResults, results)
+		})
+
+		t.Run(fmt.Sprintf("segment ends at boundary, %d workers", workers), func(t *testing.T) {
+			setWorkers(t, workers)
+
+			attestationsEntry := rsl.NewReferenceEntry(attestations.Ref, gitinterface.ZeroHash)
+			queue := []rsl.ReferenceUpdaterEntry{entries[1], attestationsEntry, entries[2], entries[3]}
+
+			results := verifySegment(testCtx, repo, state, nil, entries[0], queue, map[string]bool{attestationsEntry.GetID().String(): true}, newMultiReferenceResults())
+			assert.Equal(t, map[string]error{
+				entries[0].GetID().String(): sequentialResults[entries[0].GetID().String()],
+				entries[1].GetID().String(): sequentialResults[entries[1].GetID().String()],
+			}, results)
+		})
+
+		t.Run(fmt.Sprintf("range verification reports first invalid entry, %d workers", workers), func(t *testing.T) {
+			setWorkers(t, workers)
+
+			err := NewPolicyVerifier(repo).VerifyRelativeForRef(testCtx, entries[0], entries[3], refName)
+			var invalidEntryErr *InvalidEntryError
+			require.ErrorAs(t, err, &invalidEntryErr)
+			assert.Equal(t, entries[2].GetID(), invalidEntryErr.Entry.GetID())
+			assert.Equal(t, sequentialResults[entries[2].GetID().String()], invalidEntryErr.Err)
+		})
+
+		t.Run(fmt.Sprintf("recovery mode, %d workers", workers), func(t *testing.T) {
+			setWorkers(t, workers)
+
+			repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+			commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+			firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+			firstEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+			validCommitID := commitIDs[0]
+
+			commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+			invalidEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+			invalidEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, invalidEntry, gpgUnauthorizedKeyBytes)
+
+			// Fix using the known-good commit, followed by more valid entries
+			// in the same segment
+			require.Nil(t, repo.SetReference(refName, validCommitID))
+			fixEntry := rsl.NewReferenceEntry(refName, validCommitID)
+			fixEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, fixEntry, gpgKeyBytes)
+
+			commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+			lastEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+			lastEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, lastEntry, gpgKeyBytes)
+
+			// Without the invalid entry being skipped, it is reported
+			err := NewPolicyVerifier(repo).VerifyRelativeForRef(testCtx, firstEntry, lastEntry, refName)
+			var invalidEntryErr *InvalidEntryError
+			require.ErrorAs(t, err, &invalidEntryErr)
+			assert.Equal(t, invalidEntry.GetID(), invalidEntryErr.Entry.GetID())
+
+			annotation := rsl.NewAnnotationEntry([]gitinterface.Hash{invalidEntry.GetID()}, true, "invalid entry")
+			common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+
+			err = NewPolicyVerifier(repo).VerifyRelativeForRef(testCtx, firstEntry, lastEntry, refName)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestVerifyEntryUnit(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+	mainCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, mainRef, 1, gpgUnauthorizedKeyBytes)
+	featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, featureRef, 1, gpgKeyBytes)
+	entry := rsl.NewMultiReferenceEntry(map[string]gitinterface.Hash{
+		mainRef:    mainCommitIDs[0],
+		featureRef: featureCommitIDs[0],
+	})
+	require.Nil(t, entry.CommitUsingSpecificKey(repo, gpgUnauthorizedKeyBytes))
+
+	latestEntry, err := rsl.GetLatestEntry(repo)
+	require.Nil(t, err)
+	entry = latestEntry.(*rsl.MultiReferenceEntry)
+	mainEntry, _ := entry.GetReferenceEntryForRef(mainRef)
+	featureEntry, _ := entry.GetReferenceEntryForRef(featureRef)
+
+	t.Run("all updates are verified as a unit", func(t *testing.T) {
+		results := newMultiReferenceResults()
+
+		err := verifyEntryUnit(testCtx, repo, state, nil, mainEntry, results)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		referenceResults, verified := results.get(entry.GetID())
+		assert.True(t, verified)
+		assert.Len(t, referenceResults, 2)
+
+		err = verifyEntryUnit(testCtx, repo, state, nil, featureEntry, results)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+		assert.ErrorContains(t, err, fmt.Sprintf("verifying update to '%s' recorded in the same entry failed", mainRef))
+	})
+
+	t.Run("recorded results are reused", func(t *testing.T) {
+		results := newMultiReferenceResults()
+		results.set(entry.GetID(), []referenceResult{{refName: featureRef}, {refName: mainRef}})
+
+		err := verifyEntryUnit(testCtx, repo, state, nil, mainEntry, results)
+		assert.Nil(t, err)
+	})
+}
+
 func TestVerifyEntry(t *testing.T) {
 	refName := "refs/heads/main"
 
@@ -3472,6 +3674,149 @@ func TestVerifyEntry(t *testing.T) {
 		assert.ErrorIs(t, err, ErrVerificationFailed)
 	})
 
+	t.Run("successful verification using push certificate", func(t *testing.T) {
+		repo, state := createTestRepository(t, withTestRepositoryLocation(createTestStateWithPolicy))
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		// The authorized principal signs a push certificate for the change
+		pushCertificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[0]}}, gpgKeyBytes)
+		if err := currentAttestations.SetPushCertificate(repo, pushCertificate); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add push certificate", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The RSL entry is created by someone else, such as a server
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+
+		// Without the push certificate, the entry is not verified
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("unsuccessful verification using push certificate", func(t *testing.T) {
+		repo, state := createTestRepository(t, withTestRepositoryLocation(createTestStateWithPolicy))
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		// The push certificate is signed by an unauthorized key
+		unauthorizedPushCertificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[0]}}, gpgUnauthorizedKeyBytes)
+		if err := currentAttestations.SetPushCertificate(repo, unauthorizedPushCertificate); err != nil {
+			t.Fatal(err)
+		}
+
+		// The push certificate is signed by the authorized key but records a
+		// different update
+		otherPushCertificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[1]}}, gpgKeyBytes)
+		if err := currentAttestations.SetPushCertificate(repo, otherPushCertificate); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := currentAttestations.Commit(repo, "Add push certificates", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
+	t.Run("successful verification with higher threshold using push certificate", func(t *testing.T) {
+		repo, state := createTestRepository(t, withTestRepositoryLocation(createTestStateWithThresholdPolicy))
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		pushCertificate := gitinterface.CreateTestPushCertificate(t, []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[0]}}, targets1KeyBytes)
+		if err := currentAttestations.SetPushCertificate(repo, pushCertificate); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add push certificate", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The RSL entry's signature and the push certificate together meet the
+		// threshold
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("push certificate for another repository does not count toward threshold", func(t *testing.T) {
+		repo, state := createTestRepository(t, withTestRepositoryLocation(createTestStateWithThresholdPolicy))
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		// The push certificate records the same update but was issued for
+		// a push to a fork
+		pushCertificate := gitinterface.CreateTestPushCertificateForPushee(t, "https://example.com/fork", []*gitinterface.PushCertificateUpdate{{RefName: refName, OldID: gitinterface.ZeroHash, NewID: commitIDs[0]}}, targets1KeyBytes)
+		if err := currentAttestations.SetPushCertificate(repo, pushCertificate); err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.Commit(repo, "Add push certificate", true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err = attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
 	t.Run("successful verification with global threshold constraint", func(t *testing.T) {
 		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintThreshold)
 
@@ -3671,6 +4016,74 @@ func TestVerifyEntry(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("verify deletion entry", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		// Delete ref using unauthorized key
+		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
+			t.Fatal(err)
+		}
+
+		entry = rsl.NewReferenceEntry(refName, gitinterface.ZeroHash)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+
+		// Delete ref using authorized key
+		entry = rsl.NewReferenceEntry(refName, gitinterface.ZeroHash)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		// Recreate ref with unrelated history, which is verified as a new ref
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("verify block force pushes rule for deletion entry", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithGlobalConstraintBlockForcePushes)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		// Deleting a ref is disallowed like a force push
+		if err := repo.SetReference(refName, gitinterface.ZeroHash); err != nil {
+			t.Fatal(err)
+		}
+
+		entry = rsl.NewReferenceEntry(refName, gitinterface.ZeroHash)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrVerificationFailed)
+	})
+
 	t.Run("verify global rules applied from controller repository", func(t *testing.T) {
 		controllerRepositoryLocation := t.TempDir()
 		networkRepositoryLocation := t.TempDir()
@@ -3729,7 +4142,7 @@ func TestVerifyEntry(t *testing.T) {
 		err = Apply(testCtx, networkRepository, false)
 		require.Nil(t, err)
 
-		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), false)
+		err = rsl.PropagateChangesFromUpstreamRepository(networkRepository, controllerRepository, getPropagationDirectivesForNetworkRepository(t, networkRootMetadata), nil, false)
 		require.Nil(t, err)
 
 		networkState, err = LoadCurrentState(testCtx, networkRepository, PolicyRef)
@@ -0,0 +1,185 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var (
+	ErrEntryNumberOutOfSequence    = errors.New("RSL entry number does not follow parent entry's number")
+	ErrAnnotationForUnknownEntry   = errors.New("RSL annotation refers to an entry that does not precede it")
+	ErrImplausiblePropagationEntry = errors.New("RSL propagation entry does not reference a plausible upstream entry")
+	ErrCheckpointForUnknownEntry   = errors.New("RSL checkpoint refers to an entry that does not precede it")
+)
+
+// IntegrityIssue describes a structural problem with a specific RSL entry.
+type IntegrityIssue struct {
+	EntryID gitinterface.Hash
+	Err     error
+}
+
+func (i *IntegrityIssue) Error() string {
+	return fmt.Sprintf("entry '%s': %s", i.EntryID.String(), i.Err.Error())
+}
+
+func (i *IntegrityIssue) Unwrap() error {
+	return i.Err
+}
+
+// IntegrityReport contains the results of checking the structure of the RSL.
+type IntegrityReport struct {
+	// Entries contains the entries that could be parsed, ordered from the
+	// first entry in the RSL to the latest.
+	Entries []Entry
+
+	// Issues contains the problems found. Issues identified by the same check
+	// are ordered by the position of the affected entry in the RSL.
+	Issues []*IntegrityIssue
+}
+
+// CheckIntegrity inspects the structure of the RSL without consulting the
+// repository's policy. It walks the RSL's commits directly rather than using
+// the entry helpers so that a malformed entry is reported instead of stopping
+// the check. The following properties are checked:
+//
+//   - every entry has a single parent and can be parsed
+//   - entry numbers increase monotonically with no gaps
+//   - annotations only refer to entries that precede them
+//   - propagation entries reference an upstream entry that is not itself an
+//     entry in this RSL
+//   - checkpoints only refer to entries that precede them
+func CheckIntegrity(repo *gitinterface.Repository) (*IntegrityReport, error) {
+	tipID, err := repo.GetReference(Ref)
+	if err != nil {
+		if errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return nil, ErrRSLEntryNotFound
+		}
+		return nil, err
+	}
+
+	report := &IntegrityReport{Entries: []Entry{}, Issues: []*IntegrityIssue{}}
+
+	// Walk from the tip to the first entry, following the first parent if
+	// the RSL has branched
+	entryIDs := []gitinterface.Hash{}
+	branchedEntryIDs := set.NewSet[string]()
+	for currentID := tipID; currentID != nil; {
+		entryIDs = append(entryIDs, currentID)
+
+		parentIDs, err := repo.GetCommitParentIDs(currentID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(parentIDs) {
+		case 0:
+			currentID = nil
+		case 1:
+			currentID = parentIDs[0]
+		default:
+			branchedEntryIDs.Add(currentID.String())
+			currentID = parentIDs[0]
+		}
+	}
+	slices.Reverse(entryIDs)
+
+	seenEntryIDs := set.NewSet[string]()
+	var parentEntry Entry
+	for index, entryID := range entryIDs {
+		if branchedEntryIDs.Has(entryID.String()) {
+			report.addIssue(entryID, ErrRSLBranchDetected)
+		}
+
+		commitMessage, err := repo.GetCommitMessage(entryID)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := parseRSLEntryText(entryID, commitMessage)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidRSLEntry) {
+				err = fmt.Errorf("%w: %w", ErrInvalidRSLEntry, err)
+			}
+			report.addIssue(entryID, err)
+			seenEntryIDs.Add(entryID.String())
+			// We can't check the next entry's number against this one
+			parentEntry = nil
+			continue
+		}
+
+		if index == 0 && entry.GetNumber() > 1 {
+			// The first entry is either unnumbered or numbered 1
+			report.addIssue(entryID, ErrEntryNumberOutOfSequence)
+		} else if !isNumberInSequence(parentEntry, entry) {
+			report.addIssue(entryID, ErrEntryNumberOutOfSequence)
+		}
+
+		switch entry := entry.(type) {
+		case *AnnotationEntry:
+			for _, annotatedEntryID := range entry.RSLEntryIDs {
+				if !seenEntryIDs.Has(annotatedEntryID.String()) {
+					report.addIssue(entryID, fmt.Errorf("%w: '%s'", ErrAnnotationForUnknownEntry, annotatedEntryID.String()))
+				}
+			}
+
+		case *PropagationEntry:
+			if entry.UpstreamRepository == "" || entry.UpstreamEntryID.IsZero() || entry.TargetID.IsZero() || seenEntryIDs.Has(entry.UpstreamEntryID.String()) {
+				report.addIssue(entryID, ErrImplausiblePropagationEntry)
+			}
+
+		case *CheckpointEntry:
+			referencedEntryIDs := []gitinterface.Hash{entry.PolicyEntryID}
+			if !entry.AttestationsEntryID.IsZero() {
+				referencedEntryIDs = append(referencedEntryIDs, entry.AttestationsEntryID)
+			}
+			refNames := make([]string, 0, len(entry.RefEntryIDs))
+			for refName := range entry.RefEntryIDs {
+				refNames = append(refNames, refName)
+			}
+			slices.Sort(refNames)
+			for _, refName := range refNames {
+				referencedEntryIDs = append(referencedEntryIDs, entry.RefEntryIDs[refName])
+			}
+
+			for _, referencedEntryID := range referencedEntryIDs {
+				if !seenEntryIDs.Has(referencedEntryID.String()) {
+					report.addIssue(entryID, fmt.Errorf("%w: '%s'", ErrCheckpointForUnknownEntry, referencedEntryID.String()))
+				}
+			}
+		}
+
+		report.Entries = append(report.Entries, entry)
+		seenEntryIDs.Add(entryID.String())
+		parentEntry = entry
+	}
+
+	return report, nil
+}
+
+func (r *IntegrityReport) addIssue(entryID gitinterface.Hash, err error) {
+	r.Issues = append(r.Issues, &IntegrityIssue{EntryID: entryID, Err: err})
+}
+
+// isNumberInSequence checks the entry's number against its parent using the
+// same rules as GetParentForEntry. The parent is nil for the first entry or
+// when the parent could not be parsed, in which case the entry's number cannot
+// be checked against it.
+func isNumberInSequence(parentEntry, entry Entry) bool {
+	if parentEntry == nil {
+		return true
+	}
+
+	switch entry.GetNumber() {
+	case 0, 1:
+		return parentEntry.GetNumber() == 0
+	default:
+		return parentEntry.GetNumber() == entry.GetNumber()-1
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	t.Run("no RSL", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		_, err := CheckIntegrity(repo)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+
+	t.Run("valid RSL", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		require.Nil(t, NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false))
+		entry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+		require.Nil(t, NewAnnotationEntry([]gitinterface.Hash{entry.GetID()}, true, "skip").Commit(repo, false))
+		upstreamEntryID, err := gitinterface.NewHash("abcdef0123456789abcdef0123456789abcdef01")
+		require.Nil(t, err)
+		require.Nil(t, NewPropagationEntry("refs/heads/main", upstreamEntryID, "https://example.com/upstream", upstreamEntryID).Commit(repo, false))
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		assert.Len(t, report.Entries, 3)
+		assert.Empty(t, report.Issues)
+	})
+
+	t.Run("hand edited RSL", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		emptyTreeID, err := repo.EmptyTree()
+		require.Nil(t, err)
+		unknownEntryID, err := gitinterface.NewHash("abcdef0123456789abcdef0123456789abcdef01")
+		require.Nil(t, err)
+
+		require.Nil(t, NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false))
+		firstEntry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+
+		// Entry number skips ahead
+		gapEntryID, err := repo.Commit(emptyTreeID, Ref, fmt.Sprintf("%s\n\n%s: refs/heads/main\n%s: %s\n%s: 3", ReferenceEntryHeader, RefKey, TargetIDKey, gitinterface.ZeroHash.String(), NumberKey), false)
+		require.Nil(t, err)
+
+		// Annotation for an entry that is not in the RSL
+		annotationEntryID, err := repo.Commit(emptyTreeID, Ref, fmt.Sprintf("%s\n\n%s: %s\n%s: true\n%s: 4\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, unknownEntryID.String(), SkipKey, NumberKey, BeginMessage, "c2tpcA==", EndMessage), false)
+		require.Nil(t, err)
+
+		// Propagation entry that propagates an entry from this RSL
+		propagationEntryID, err := repo.Commit(emptyTreeID, Ref, fmt.Sprintf("%s\n\n%s: refs/heads/main\n%s: %s\n%s: https://example.com/upstream\n%s: %s\n%s: 5", PropagationEntryHeader, RefKey, TargetIDKey, unknownEntryID.String(), UpstreamRepositoryKey, UpstreamEntryIDKey, firstEntry.GetID().String(), NumberKey), false)
+		require.Nil(t, err)
+
+		// Entry that cannot be parsed
+		malformedEntryID, err := repo.Commit(emptyTreeID, Ref, "Not an RSL entry\n", false)
+		require.Nil(t, err)
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		assert.Len(t, report.Entries, 4)
+		require.Len(t, report.Issues, 4)
+
+		assert.Equal(t, gapEntryID, report.Issues[0].EntryID)
+		assert.ErrorIs(t, report.Issues[0], ErrEntryNumberOutOfSequence)
+		assert.Equal(t, annotationEntryID, report.Issues[1].EntryID)
+		assert.ErrorIs(t, report.Issues[1], ErrAnnotationForUnknownEntry)
+		assert.Equal(t, propagationEntryID, report.Issues[2].EntryID)
+		assert.ErrorIs(t, report.Issues[2], ErrImplausiblePropagationEntry)
+		assert.Equal(t, malformedEntryID, report.Issues[3].EntryID)
+		assert.ErrorIs(t, report.Issues[3], ErrInvalidRSLEntry)
+	})
+
+	t.Run("checkpoint for unknown entry", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		unknownEntryID, err := gitinterface.NewHash("abcdef0123456789abcdef0123456789abcdef01")
+		require.Nil(t, err)
+
+		require.Nil(t, NewReferenceEntry("refs/gittuf/policy", gitinterface.ZeroHash).Commit(repo, false))
+		policyEntry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+
+		require.Nil(t, NewCheckpointEntry(policyEntry.GetID(), gitinterface.ZeroHash, map[string]gitinterface.Hash{"refs/heads/main": unknownEntryID}).Commit(repo, false))
+		checkpointEntry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		require.Len(t, report.Issues, 1)
+		assert.Equal(t, checkpointEntry.GetID(), report.Issues[0].EntryID)
+		assert.ErrorIs(t, report.Issues[0], ErrCheckpointForUnknownEntry)
+	})
+}
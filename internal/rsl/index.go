@@ -0,0 +1,590 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
+
+var ErrCannotIndexRSL = errors.New("current RSL entries are not numbered, cannot index RSL")
+
+// Index is a local index of the RSL that allows looking up entries without
+// walking back the RSL. It maps each reference to the entries that updated it,
+// each commit to the first entry that recorded it, and each entry to the
+// annotations that refer to it. The index is updated incrementally to reflect
+// new entries in the RSL. If the RSL diverges from the index, for example
+// because it was overwritten by a fetch, the index is rebuilt.
+type Index struct {
+	contents indexContents
+
+	// numbers maps entry IDs to their numbers, it is derived from the
+	// contents' entry IDs.
+	numbers map[string]uint64
+
+	mu sync.Mutex
+}
+
+type indexContents struct {
+	// EntryIDs is the list of IDs of the entries in the RSL, ordered by each
+	// entry's Number. The ID of the entry with number n is at index n-1.
+	EntryIDs []string `json:"entryIDs"`
+
+	// RefEntries maps each reference to the ordered list of numbers of the
+	// entries that updated it.
+	RefEntries map[string][]uint64 `json:"refEntries"`
+
+	// CommitEntries maps each commit to the number of the first entry whose
+	// target is the commit or a descendant of the commit.
+	CommitEntries map[string]uint64 `json:"commitEntries"`
+
+	// Annotations maps each entry's number to the ordered list of numbers of
+	// the annotations that refer to it.
+	Annotations map[uint64][]uint64 `json:"annotations"`
+}
+
+// NewIndex returns an empty index. The index must be updated using Update to
+// reflect the RSL.
+func NewIndex() *Index {
+	index := &Index{}
+	index.reset()
+	return index
+}
+
+// Update brings the index up to date with the repository's RSL, indexing only
+// the entries that were added since the index was last updated.
+func (i *Index) Update(repo *gitinterface.Repository) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		if errors.Is(err, ErrRSLEntryNotFound) {
+			i.reset()
+			return nil
+		}
+		return err
+	}
+
+	newEntries := []Entry{}
+	for {
+		number := iterator.GetNumber()
+		if number == 0 {
+			i.reset()
+			return ErrCannotIndexRSL
+		}
+
+		indexed := uint64(len(i.contents.EntryIDs))
+		if number <= indexed {
+			if number == indexed && i.contents.EntryIDs[number-1] == iterator.GetID().String() {
+				// The rest of the RSL has already been indexed
+				break
+			}
+
+			// The RSL does not match the index, so we must rebuild it
+			slog.Debug("RSL has diverged from index, rebuilding index...")
+			i.reset()
+		}
+
+		newEntries = append(newEntries, iterator)
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return err
+		}
+	}
+
+	// Index new entries in order of occurrence
+	for idx := len(newEntries) - 1; idx >= 0; idx-- {
+		if err := i.add(repo, newEntries[idx]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i *Index) MarshalJSON() ([]byte, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return json.Marshal(i.contents)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Index) UnmarshalJSON(data []byte) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.reset()
+	if err := json.Unmarshal(data, &i.contents); err != nil {
+		return err
+	}
+
+	// Ensure maps are usable even if they were omitted
+	if i.contents.RefEntries == nil {
+		i.contents.RefEntries = map[string][]uint64{}
+	}
+	if i.contents.CommitEntries == nil {
+		i.contents.CommitEntries = map[string]uint64{}
+	}
+	if i.contents.Annotations == nil {
+		i.contents.Annotations = map[uint64][]uint64{}
+	}
+	i.numbers = nil
+	i.getNumbers()
+
+	return nil
+}
+
+// add indexes the specified entry, which must immediately follow the last
+// indexed entry.
+func (i *Index) add(repo *gitinterface.Repository, entry Entry) error {
+	number := entry.GetNumber()
+	if number != uint64(len(i.contents.EntryIDs))+1 {
+		return fmt.Errorf("%w: unexpected number %d for entry '%s'", ErrInvalidRSLEntry, number, entry.GetID().String())
+	}
+
+	// Identify the prior target of each reference before modifying the index
+	// so that an error leaves the index in a consistent state
+	entries := GetReferenceUpdaterEntries(entry)
+	priorTargetIDs := make([]gitinterface.Hash, 0, len(entries))
+	for _, refEntry := range entries {
+		priorTargetID := gitinterface.ZeroHash
+		if numbers := i.contents.RefEntries[refEntry.GetRefName()]; len(numbers) != 0 {
+			priorEntry, err := loadEntry(repo, i.contents.EntryIDs[numbers[len(numbers)-1]-1])
+			if err != nil {
+				return err
+			}
+			priorRefEntry, err := findEntryForRef(priorEntry, refEntry.GetRefName())
+			if err != nil {
+				return err
+			}
+			priorTargetID = priorRefEntry.GetTargetID()
+		}
+		priorTargetIDs = append(priorTargetIDs, priorTargetID)
+	}
+
+	entryID := entry.GetID().String()
+	i.contents.EntryIDs = append(i.contents.EntryIDs, entryID)
+	i.getNumbers()[entryID] = number
+
+	if annotation, isAnnotation := entry.(*AnnotationEntry); isAnnotation {
+		for _, annotatedID := range annotation.RSLEntryIDs {
+			annotatedNumber, has := i.getNumbers()[annotatedID.String()]
+			if !has {
+				continue
+			}
+			i.contents.Annotations[annotatedNumber] = append(i.contents.Annotations[annotatedNumber], number)
+		}
+	}
+
+	for idx, refEntry := range entries {
+		refName := refEntry.GetRefName()
+		i.contents.RefEntries[refName] = append(i.contents.RefEntries[refName], number)
+
+		if strings.HasPrefix(refName, gittufNamespacePrefix) || refEntry.GetTargetID().IsZero() {
+			continue
+		}
+
+		commitIDs, err := repo.GetCommitsBetweenRange(refEntry.GetTargetID(), priorTargetIDs[idx])
+		if err != nil {
+			// The target may not be a commit, for example, if it's a tag
+			slog.Debug(fmt.Sprintf("Unable to index commits for entry '%s' and ref '%s': %v", entryID, refName, err))
+			continue
+		}
+
+		for _, commitID := range commitIDs {
+			if _, has := i.contents.CommitEntries[commitID.String()]; !has {
+				i.contents.CommitEntries[commitID.String()] = number
+			}
+		}
+	}
+
+	return nil
+}
+
+// The lookup methods below lock the index only to read its contents. Entries
+// are loaded from the repository after the lock is released so that concurrent
+// lookups are not serialized on Git reads.
+
+// getEntryID returns the ID of the entry with the specified number.
+func (i *Index) getEntryID(number uint64) (string, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if number == 0 || number > uint64(len(i.contents.EntryIDs)) {
+		return "", false
+	}
+
+	return i.contents.EntryIDs[number-1], true
+}
+
+// getEntry returns the entry with the specified number.
+func (i *Index) getEntry(repo *gitinterface.Repository, number uint64) (Entry, error) {
+	entryID, has := i.getEntryID(number)
+	if !has {
+		return nil, ErrRSLEntryNotFound
+	}
+
+	return loadEntry(repo, entryID)
+}
+
+// getEntryForRef returns the reference updater entry for the specified ref
+// recorded in the entry with the specified number.
+func (i *Index) getEntryForRef(repo *gitinterface.Repository, number uint64, refName string) (ReferenceUpdaterEntry, error) {
+	entry, err := i.getEntry(repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return findEntryForRef(entry, refName)
+}
+
+// getRefEntries returns the ordered list of numbers of the entries that
+// updated the specified ref.
+func (i *Index) getRefEntries(refName string) []uint64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return slices.Clone(i.contents.RefEntries[refName])
+}
+
+// getRelevantEntriesInRange returns the ordered list of numbers of the entries
+// in the specified range that updated the specified ref or the relevant refs
+// in the gittuf namespace.
+func (i *Index) getRelevantEntriesInRange(refName string, firstNumber, lastNumber uint64) []uint64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	relevantNumbers := map[uint64]bool{}
+	for indexedRefName, numbers := range i.contents.RefEntries {
+		if indexedRefName != refName && !isRelevantGittufRef(indexedRefName) {
+			continue
+		}
+
+		position, _ := slices.BinarySearch(numbers, firstNumber)
+		for _, number := range numbers[position:] {
+			if number > lastNumber {
+				break
+			}
+			relevantNumbers[number] = true
+		}
+	}
+
+	numbers := make([]uint64, 0, len(relevantNumbers))
+	for number := range relevantNumbers {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+
+	return numbers
+}
+
+// getCommitEntry returns the number of the first entry that recorded the
+// specified commit.
+func (i *Index) getCommitEntry(commitID gitinterface.Hash) (uint64, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	number, has := i.contents.CommitEntries[commitID.String()]
+	return number, has
+}
+
+// getAnnotations returns the annotations that refer to the entry with the
+// specified number in order of occurrence.
+func (i *Index) getAnnotations(repo *gitinterface.Repository, number uint64) ([]*AnnotationEntry, error) {
+	i.mu.Lock()
+	annotationNumbers := slices.Clone(i.contents.Annotations[number])
+	i.mu.Unlock()
+
+	if len(annotationNumbers) == 0 {
+		return nil, nil
+	}
+
+	annotations := make([]*AnnotationEntry, 0, len(annotationNumbers))
+	for _, annotationNumber := range annotationNumbers {
+		entry, err := i.getEntry(repo, annotationNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		annotation, isAnnotation := entry.(*AnnotationEntry)
+		if !isAnnotation {
+			return nil, ErrInvalidRSLEntry
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, nil
+}
+
+// getNumber returns the number of the entry with the specified ID, if it has
+// been indexed.
+func (i *Index) getNumber(entryID gitinterface.Hash) (uint64, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	number, has := i.getNumbers()[entryID.String()]
+	return number, has
+}
+
+func (i *Index) getNumbers() map[string]uint64 {
+	if i.numbers == nil {
+		i.numbers = make(map[string]uint64, len(i.contents.EntryIDs))
+		for idx, entryID := range i.contents.EntryIDs {
+			i.numbers[entryID] = uint64(idx) + 1
+		}
+	}
+
+	return i.numbers
+}
+
+// loadEntry loads the entry with the specified ID from the repository.
+func loadEntry(repo *gitinterface.Repository, entryID string) (Entry, error) {
+	entryHash, err := gitinterface.NewHash(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetEntry(repo, entryHash)
+}
+
+// findEntryForRef returns the reference updater entry for the specified ref
+// recorded in the specified entry.
+func findEntryForRef(entry Entry, refName string) (ReferenceUpdaterEntry, error) {
+	for _, refEntry := range GetReferenceUpdaterEntries(entry) {
+		if refEntry.GetRefName() == refName {
+			return refEntry, nil
+		}
+	}
+
+	return nil, ErrRSLEntryDoesNotMatchRef
+}
+
+func (i *Index) reset() {
+	i.contents = indexContents{
+		EntryIDs:      []string{},
+		RefEntries:    map[string][]uint64{},
+		CommitEntries: map[string]uint64{},
+		Annotations:   map[uint64][]uint64{},
+	}
+	i.numbers = map[string]uint64{}
+}
+
+// indexes tracks the index to use for each repository, keyed by the
+// repository's Git directory.
+var (
+	indexes      = map[string]*Index{}
+	indexesMutex sync.RWMutex
+)
+
+// UseIndex sets the index to be used for lookups in the specified repository's
+// RSL. If index is nil, lookups walk the RSL.
+func UseIndex(repo *gitinterface.Repository, index *Index) {
+	indexesMutex.Lock()
+	defer indexesMutex.Unlock()
+
+	if index == nil {
+		delete(indexes, repo.GetGitDir())
+		return
+	}
+	indexes[repo.GetGitDir()] = index
+}
+
+// withIndex invokes fn with the index set for the repository after updating
+// it. The index is not locked while fn is invoked, fn must only access the
+// index's contents using its lookup methods, which lock the index as needed.
+// If no index is set or the index cannot be used, fn is not invoked and false
+// is returned, in which case the caller must walk the RSL.
+func withIndex(repo *gitinterface.Repository, fn func(*Index)) bool {
+	indexesMutex.RLock()
+	index, has := indexes[repo.GetGitDir()]
+	indexesMutex.RUnlock()
+	if !has {
+		return false
+	}
+
+	if err := index.Update(repo); err != nil {
+		slog.Debug(fmt.Sprintf("Unable to update RSL index, walking RSL instead: %v", err))
+		return false
+	}
+
+	fn(index)
+	return true
+}
+
+// getLatestReferenceUpdaterEntryFromIndex implements
+// GetLatestReferenceUpdaterEntry using the index for options that set the
+// reference. It returns false if the index cannot be used for the options, in
+// which case the caller must walk the RSL.
+func getLatestReferenceUpdaterEntryFromIndex(repo *gitinterface.Repository, index *Index, latestEntry Entry, options *GetLatestReferenceUpdaterEntryOptions) (ReferenceUpdaterEntry, []*AnnotationEntry, bool, error) {
+	start := latestEntry.GetNumber()
+	if number, has := index.getNumber(latestEntry.GetID()); !has || number != start {
+		return nil, nil, false, nil
+	}
+
+	switch {
+	case !options.BeforeEntryID.IsZero():
+		number, has := index.getNumber(options.BeforeEntryID)
+		if !has {
+			return nil, nil, false, nil
+		}
+		if number < options.UntilEntryNumber {
+			return nil, nil, true, ErrInvalidGetLatestReferenceUpdaterEntryOptions
+		}
+		start = number - 1
+	case options.BeforeEntryNumber != 0:
+		if options.BeforeEntryNumber > start {
+			return nil, nil, false, nil
+		}
+		start = options.BeforeEntryNumber - 1
+	}
+
+	numbers := index.getRefEntries(options.Reference)
+	position, found := slices.BinarySearch(numbers, start)
+	if found {
+		position++
+	}
+
+	for idx := position - 1; idx >= 0; idx-- {
+		number := numbers[idx]
+		// The entry we start searching from is always considered, matching
+		// the behavior when walking the RSL
+		if number != start && number < options.UntilEntryNumber {
+			break
+		}
+
+		entry, err := index.getEntryForRef(repo, number, options.Reference)
+		if err != nil {
+			return nil, nil, true, err
+		}
+
+		annotations, err := index.getAnnotations(repo, number)
+		if err != nil {
+			return nil, nil, true, err
+		}
+
+		matches, err := options.matches(entry, annotations)
+		if err != nil {
+			return nil, nil, true, err
+		}
+		if matches {
+			return entry, reverseAnnotations(annotations), true, nil
+		}
+	}
+
+	return nil, nil, true, ErrRSLEntryNotFound
+}
+
+// getFirstReferenceUpdaterEntryForCommitFromIndex implements
+// GetFirstReferenceUpdaterEntryForCommit using the index.
+func getFirstReferenceUpdaterEntryForCommitFromIndex(repo *gitinterface.Repository, index *Index, commitID gitinterface.Hash) (ReferenceUpdaterEntry, []*AnnotationEntry, error) {
+	number, has := index.getCommitEntry(commitID)
+	if !has {
+		return nil, nil, ErrNoRecordOfCommit
+	}
+
+	entry, err := index.getEntry(repo, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := []ReferenceUpdaterEntry{}
+	for _, refEntry := range GetReferenceUpdaterEntries(entry) {
+		if !strings.HasPrefix(refEntry.GetRefName(), gittufNamespacePrefix) && !refEntry.GetTargetID().IsZero() {
+			candidates = append(candidates, refEntry)
+		}
+	}
+
+	var firstEntry ReferenceUpdaterEntry
+	if len(candidates) == 1 {
+		firstEntry = candidates[0]
+	} else {
+		// A multi-reference entry may record the commit for only some of
+		// its references
+		for _, candidate := range candidates {
+			knowsCommit, err := repo.KnowsCommit(candidate.GetTargetID(), commitID)
+			if err == nil && knowsCommit {
+				firstEntry = candidate
+				break
+			}
+		}
+	}
+	if firstEntry == nil {
+		return nil, nil, ErrNoRecordOfCommit
+	}
+
+	annotations, err := index.getAnnotations(repo, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return firstEntry, reverseAnnotations(annotations), nil
+}
+
+// getReferenceUpdaterEntriesInRangeForRefFromIndex implements
+// GetReferenceUpdaterEntriesInRangeForRef using the index. It returns false if
+// the range is not in the index, in which case the caller must walk the RSL.
+func getReferenceUpdaterEntriesInRangeForRefFromIndex(repo *gitinterface.Repository, index *Index, firstID, lastID gitinterface.Hash, refName string) ([]ReferenceUpdaterEntry, map[string][]*AnnotationEntry, bool, error) {
+	firstNumber, has := index.getNumber(firstID)
+	if !has {
+		return nil, nil, false, nil
+	}
+	lastNumber, has := index.getNumber(lastID)
+	if !has || lastNumber < firstNumber {
+		return nil, nil, false, nil
+	}
+
+	// Identify the entries in the range for the ref and the gittuf namespace
+	numbers := index.getRelevantEntriesInRange(refName, firstNumber, lastNumber)
+
+	allEntries := make([]ReferenceUpdaterEntry, 0, len(numbers))
+	annotationMap := map[string][]*AnnotationEntry{}
+	for _, number := range numbers {
+		entry, err := index.getEntry(repo, number)
+		if err != nil {
+			return nil, nil, true, err
+		}
+
+		for _, refEntry := range GetReferenceUpdaterEntries(entry) {
+			if refEntry.GetRefName() == refName || isRelevantGittufRef(refEntry.GetRefName()) {
+				allEntries = append(allEntries, refEntry)
+			}
+		}
+
+		annotations, err := index.getAnnotations(repo, number)
+		if err != nil {
+			return nil, nil, true, err
+		}
+		if len(annotations) != 0 {
+			annotationMap[entry.GetID().String()] = annotations
+		}
+	}
+
+	return allEntries, annotationMap, true, nil
+}
+
+// reverseAnnotations returns the annotations in reverse order, matching the
+// order in which they are encountered when walking back the RSL.
+func reverseAnnotations(annotations []*AnnotationEntry) []*AnnotationEntry {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	reversed := slices.Clone(annotations)
+	slices.Reverse(reversed)
+	return reversed
+}
@@ -0,0 +1,267 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+	policyRef := "refs/gittuf/policy"
+
+	createTestRSL := func(t *testing.T) (*gitinterface.Repository, []gitinterface.Hash) {
+		t.Helper()
+
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		treeBuilder := gitinterface.NewTreeBuilder(repo)
+		emptyTreeHash, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.Nil(t, err)
+
+		commitIDs := []gitinterface.Hash{}
+		for i := 0; i < 2; i++ {
+			commitID, err := repo.Commit(emptyTreeHash, mainRef, "Test commit", false)
+			require.Nil(t, err)
+			commitIDs = append(commitIDs, commitID)
+		}
+		require.Nil(t, NewReferenceEntry(policyRef, gitinterface.ZeroHash).Commit(repo, false))
+		require.Nil(t, NewReferenceEntry(mainRef, commitIDs[1]).Commit(repo, false))
+
+		require.Nil(t, repo.SetReference(featureRef, commitIDs[1]))
+		featureCommitID, err := repo.Commit(emptyTreeHash, featureRef, "Feature commit", false)
+		require.Nil(t, err)
+		commitIDs = append(commitIDs, featureCommitID)
+		require.Nil(t, NewReferenceEntry(featureRef, featureCommitID).Commit(repo, false))
+
+		latestEntry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+		require.Nil(t, NewAnnotationEntry([]gitinterface.Hash{latestEntry.GetID()}, true, annotationMessage).Commit(repo, false))
+
+		mainCommitID, err := repo.Commit(emptyTreeHash, mainRef, "Test commit", false)
+		require.Nil(t, err)
+		commitIDs = append(commitIDs, mainCommitID)
+		require.Nil(t, NewMultiReferenceEntry(map[string]gitinterface.Hash{mainRef: mainCommitID, featureRef: featureCommitID}).Commit(repo, false))
+		require.Nil(t, NewReferenceEntry(mainRef, mainCommitID).Commit(repo, false))
+
+		return repo, commitIDs
+	}
+
+	// assertLookupsMatch checks that lookups return the same results with and
+	// without the index
+	assertLookupsMatch := func(t *testing.T, repo *gitinterface.Repository, index *Index, commitIDs []gitinterface.Hash) {
+		t.Helper()
+
+		type result struct {
+			entry       ReferenceUpdaterEntry
+			annotations []*AnnotationEntry
+			err         error
+		}
+
+		firstEntry, _, err := GetFirstEntry(repo)
+		require.Nil(t, err)
+		latestEntry, err := GetLatestEntry(repo)
+		require.Nil(t, err)
+
+		lookups := map[string]func() result{
+			"latest for main": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(mainRef))
+				return result{entry, annotations, err}
+			},
+			"latest unskipped for feature": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(featureRef), IsUnskipped())
+				return result{entry, annotations, err}
+			},
+			"latest for feature before number": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(featureRef), BeforeEntryNumber(6))
+				return result{entry, annotations, err}
+			},
+			"latest for main before ID": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(mainRef), BeforeEntryID(latestEntry.GetID()))
+				return result{entry, annotations, err}
+			},
+			"latest for policy until number": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(policyRef), UntilEntryNumber(2))
+				return result{entry, annotations, err}
+			},
+			"latest for unknown ref": func() result {
+				entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference("refs/heads/unknown"))
+				return result{entry, annotations, err}
+			},
+			"first for feature": func() result {
+				entry, annotations, err := GetFirstReferenceUpdaterEntryForRef(repo, featureRef)
+				return result{entry, annotations, err}
+			},
+			"first for unknown ref": func() result {
+				entry, annotations, err := GetFirstReferenceUpdaterEntryForRef(repo, "refs/heads/unknown")
+				return result{entry, annotations, err}
+			},
+		}
+		for _, commitID := range commitIDs {
+			lookups["first for commit "+commitID.String()] = func() result {
+				entry, annotations, err := GetFirstReferenceUpdaterEntryForCommit(repo, commitID)
+				return result{entry, annotations, err}
+			}
+		}
+
+		expectedResults := map[string]result{}
+		for name, lookup := range lookups {
+			expectedResults[name] = lookup()
+		}
+		expectedEntries, expectedAnnotations, err := GetReferenceUpdaterEntriesInRangeForRef(repo, firstEntry.GetID(), latestEntry.GetID(), featureRef)
+		require.Nil(t, err)
+
+		UseIndex(repo, index)
+		defer UseIndex(repo, nil)
+
+		for name, lookup := range lookups {
+			assert.Equal(t, expectedResults[name], lookup(), name)
+		}
+		entries, annotations, err := GetReferenceUpdaterEntriesInRangeForRef(repo, firstEntry.GetID(), latestEntry.GetID(), featureRef)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEntries, entries)
+		assert.Equal(t, expectedAnnotations, annotations)
+	}
+
+	t.Run("lookups match walking the RSL", func(t *testing.T) {
+		repo, commitIDs := createTestRSL(t)
+
+		index := NewIndex()
+		require.Nil(t, index.Update(repo))
+
+		assert.Len(t, index.contents.EntryIDs, 6)
+		assert.Equal(t, []uint64{2, 5, 6}, index.contents.RefEntries[mainRef])
+		assert.Equal(t, []uint64{3, 5}, index.contents.RefEntries[featureRef])
+		assert.Equal(t, []uint64{1}, index.contents.RefEntries[policyRef])
+		assert.Equal(t, map[uint64][]uint64{3: {4}}, index.contents.Annotations)
+		assert.Equal(t, uint64(2), index.contents.CommitEntries[commitIDs[0].String()])
+		assert.Equal(t, uint64(3), index.contents.CommitEntries[commitIDs[2].String()])
+		assert.Equal(t, uint64(5), index.contents.CommitEntries[commitIDs[3].String()])
+
+		assertLookupsMatch(t, repo, index, commitIDs[:2])
+	})
+
+	t.Run("incremental update", func(t *testing.T) {
+		repo, commitIDs := createTestRSL(t)
+
+		index := NewIndex()
+		require.Nil(t, index.Update(repo))
+
+		require.Nil(t, NewReferenceEntry(featureRef, commitIDs[3]).Commit(repo, false))
+		require.Nil(t, index.Update(repo))
+
+		assert.Len(t, index.contents.EntryIDs, 7)
+		assert.Equal(t, []uint64{3, 5, 7}, index.contents.RefEntries[featureRef])
+
+		// The index is updated transparently during lookups
+		UseIndex(repo, index)
+		require.Nil(t, NewReferenceEntry(policyRef, gitinterface.ZeroHash).Commit(repo, false))
+		entry, _, err := GetLatestReferenceUpdaterEntry(repo, ForReference(policyRef))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(8), entry.GetNumber())
+		assert.Equal(t, []uint64{1, 8}, index.contents.RefEntries[policyRef])
+		UseIndex(repo, nil)
+
+		assertLookupsMatch(t, repo, index, commitIDs[:2])
+	})
+
+	t.Run("rebuild when RSL diverges", func(t *testing.T) {
+		repo, commitIDs := createTestRSL(t)
+
+		index := NewIndex()
+		require.Nil(t, index.Update(repo))
+
+		// Rewind the RSL and record a different entry
+		firstEntry, _, err := GetFirstEntry(repo)
+		require.Nil(t, err)
+		require.Nil(t, repo.SetReference(Ref, firstEntry.GetID()))
+		require.Nil(t, NewReferenceEntry(featureRef, commitIDs[2]).Commit(repo, false))
+
+		require.Nil(t, index.Update(repo))
+		assert.Len(t, index.contents.EntryIDs, 2)
+		assert.Equal(t, []uint64{2}, index.contents.RefEntries[featureRef])
+		assert.Empty(t, index.contents.RefEntries[mainRef])
+		assert.Empty(t, index.contents.Annotations)
+
+		assertLookupsMatch(t, repo, index, commitIDs)
+	})
+
+	t.Run("commits recorded for other refs", func(t *testing.T) {
+		repo, commitIDs := createTestRSL(t)
+
+		UseIndex(repo, NewIndex())
+		defer UseIndex(repo, nil)
+
+		// The feature commit was first recorded for the feature ref even
+		// though the latest entry for main does not record it
+		entry, annotations, err := GetFirstReferenceUpdaterEntryForCommit(repo, commitIDs[2])
+		assert.Nil(t, err)
+		assert.Equal(t, featureRef, entry.GetRefName())
+		assert.Equal(t, uint64(3), entry.GetNumber())
+		assertAnnotationsReferToEntry(t, entry, annotations)
+
+		// The main commit was first recorded for main in the
+		// multi-reference entry
+		entry, annotations, err = GetFirstReferenceUpdaterEntryForCommit(repo, commitIDs[3])
+		assert.Nil(t, err)
+		assert.Equal(t, mainRef, entry.GetRefName())
+		assert.Equal(t, uint64(5), entry.GetNumber())
+		assert.Nil(t, annotations)
+	})
+
+	t.Run("concurrent lookups", func(t *testing.T) {
+		repo, commitIDs := createTestRSL(t)
+
+		UseIndex(repo, NewIndex())
+		defer UseIndex(repo, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				entry, _, err := GetLatestReferenceUpdaterEntry(repo, ForReference(featureRef), IsUnskipped())
+				assert.Nil(t, err)
+				assert.Equal(t, uint64(5), entry.GetNumber())
+
+				entry, _, err = GetFirstReferenceUpdaterEntryForCommit(repo, commitIDs[2])
+				assert.Nil(t, err)
+				assert.Equal(t, uint64(3), entry.GetNumber())
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("marshal and unmarshal", func(t *testing.T) {
+		repo, _ := createTestRSL(t)
+
+		index := NewIndex()
+		require.Nil(t, index.Update(repo))
+
+		contents, err := json.Marshal(index)
+		require.Nil(t, err)
+
+		loadedIndex := &Index{}
+		require.Nil(t, json.Unmarshal(contents, loadedIndex))
+		assert.Equal(t, index, loadedIndex)
+	})
+
+	t.Run("empty RSL", func(t *testing.T) {
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		index := NewIndex()
+		assert.Nil(t, index.Update(repo))
+		assert.Empty(t, index.contents.EntryIDs)
+	})
+}
@@ -3,7 +3,11 @@
 
 package rsl
 
-import "github.com/gittuf/gittuf/pkg/gitinterface"
+import (
+	"strings"
+
+	"github.com/gittuf/gittuf/pkg/gitinterface"
+)
 
 type GetLatestReferenceUpdaterEntryOptions struct {
 	Reference string
@@ -14,7 +18,8 @@ type GetLatestReferenceUpdaterEntryOptions struct {
 	UntilEntryID     gitinterface.Hash
 	UntilEntryNumber uint64
 
-	Unskipped bool
+	Unskipped      bool
+	SkipAuthorizer SkipAuthorizer
 
 	NonGittuf bool
 
@@ -24,6 +29,12 @@ type GetLatestReferenceUpdaterEntryOptions struct {
 
 type GetLatestReferenceUpdaterEntryOption func(*GetLatestReferenceUpdaterEntryOptions)
 
+// SkipAuthorizer determines if a reference entry has been skipped by the
+// specified annotations. It is used to ignore skip annotations that are not
+// authorized to skip the entry. The annotations may include annotations that
+// do not refer to the entry.
+type SkipAuthorizer func(entry *ReferenceEntry, annotations []*AnnotationEntry) (bool, error)
+
 // ForReference indicates that the reference entry returned must be for a
 // specific Git reference.
 func ForReference(reference string) GetLatestReferenceUpdaterEntryOption {
@@ -80,6 +91,15 @@ func IsUnskipped() GetLatestReferenceUpdaterEntryOption {
 	}
 }
 
+// WithSkipAuthorizer uses the specified authorizer to determine if a reference
+// entry has been skipped when used in combination with IsUnskipped. By default,
+// any annotation that marks the entry as skipped is honored.
+func WithSkipAuthorizer(authorizer SkipAuthorizer) GetLatestReferenceUpdaterEntryOption {
+	return func(o *GetLatestReferenceUpdaterEntryOptions) {
+		o.SkipAuthorizer = authorizer
+	}
+}
+
 // ForNonGittufReference ensures that the returned reference entry is not for a
 // gittuf-specific reference.
 func ForNonGittufReference() GetLatestReferenceUpdaterEntryOption {
@@ -104,3 +124,48 @@ func IsPropagationEntryForRepository(repositoryLocation string) GetLatestReferen
 		o.IsPropagationEntryForRepository = repositoryLocation
 	}
 }
+
+// matches returns true if the entry satisfies the entry specific conditions in
+// the options. The annotations must include every annotation that refers to
+// the entry.
+func (o *GetLatestReferenceUpdaterEntryOptions) matches(entry ReferenceUpdaterEntry, annotations []*AnnotationEntry) (bool, error) {
+	if o.Reference != "" && entry.GetRefName() != o.Reference {
+		return false, nil
+	}
+
+	if o.IsReferenceEntry {
+		if _, isReferenceEntry := entry.(*ReferenceEntry); !isReferenceEntry {
+			return false, nil
+		}
+	}
+
+	// Only reference entry can be skipped
+	if referenceEntry, isReferenceEntry := entry.(*ReferenceEntry); isReferenceEntry && o.Unskipped {
+		// SkippedBy ensures only the applicable annotations that refer to
+		// the entry are used
+		skipped := referenceEntry.SkippedBy(annotations)
+		if skipped && o.SkipAuthorizer != nil {
+			var err error
+			skipped, err = o.SkipAuthorizer(referenceEntry, annotations)
+			if err != nil {
+				return false, err
+			}
+		}
+		if skipped {
+			return false, nil
+		}
+	}
+
+	if o.IsPropagationEntryForRepository != "" {
+		propagationEntry, isPropagationEntry := entry.(*PropagationEntry)
+		if !isPropagationEntry || propagationEntry.UpstreamRepository != o.IsPropagationEntryForRepository {
+			return false, nil
+		}
+	}
+
+	if o.NonGittuf && strings.HasPrefix(entry.GetRefName(), gittufNamespacePrefix) {
+		return false, nil
+	}
+
+	return true, nil
+}
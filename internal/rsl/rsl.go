@@ -4,13 +4,17 @@
 package rsl
 
 import (
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
 )
@@ -29,11 +33,23 @@ const (
 	EndMessage                 = "-----END MESSAGE-----"
 	EntryIDKey                 = "entryID"
 	SkipKey                    = "skip"
+	DataKey                    = "data"
 
 	PropagationEntryHeader = "RSL Propagation Entry"
 	UpstreamRepositoryKey  = "upstreamRepository"
 	UpstreamEntryIDKey     = "upstreamEntryID"
 
+	HeartbeatEntryHeader = "RSL Heartbeat Entry"
+	TimestampKey         = "timestamp"
+
+	CheckpointEntryHeader         = "RSL Checkpoint Entry"
+	CheckpointSignaturesBlockType = "SIGNATURES"
+	BeginSignatures               = "-----BEGIN SIGNATURES-----"
+	PolicyEntryIDKey              = "policyEntryID"
+	AttestationsEntryIDKey        = "attestationsEntryID"
+
+	MultiReferenceEntryHeader = "RSL Multi-Reference Entry"
+
 	remoteTrackerRef       = "refs/remotes/%s/gittuf/reference-state-log"
 	gittufNamespacePrefix  = "refs/gittuf/"
 	gittufPolicyStagingRef = "refs/gittuf/policy-staging"
@@ -48,6 +64,7 @@ var (
 	ErrInvalidGetLatestReferenceUpdaterEntryOptions = errors.New("invalid options presented for getting latest reference updater entry (are both before or until conditions set or is the before number less than the until number?)")
 	ErrCannotUseEntryNumberFilter                   = errors.New("current RSL entries are not numbered, cannot use number range options")
 	ErrInvalidUntilEntryNumberCondition             = errors.New("cannot meet until entry number condition")
+	ErrInvalidMultiReferenceEntry                   = errors.New("multi-reference entry must record one or more references, none of which may be in the gittuf namespace")
 )
 
 // RemoteTrackerRef returns the remote tracking ref for the specified remote
@@ -107,6 +124,12 @@ func (e *ReferenceEntry) GetTargetID() gitinterface.Hash {
 	return e.TargetID
 }
 
+// IsDeletion returns true if the entry records the deletion of RefName, i.e.,
+// its TargetID is the zero hash.
+func (e *ReferenceEntry) IsDeletion() bool {
+	return e.TargetID.IsZero()
+}
+
 // Commit creates a commit object in the RSL for the ReferenceEntry. The
 // function looks up the latest committed entry in the RSL and increments the
 // number in the new entry. If a parent entry does not exist or the parent
@@ -228,6 +251,12 @@ type AnnotationEntry struct {
 	// Message contains any messages or notes added by a user for the annotation.
 	Message string
 
+	// Data contains optional structured information for the annotation, such
+	// as incident IDs or references to attestations. Each value is JSON
+	// encoded. As the data is recorded in the annotation, it is signed along
+	// with the rest of the entry.
+	Data map[string]json.RawMessage
+
 	// Number contains a strictly increasing number that hints at entry ordering.
 	Number uint64
 }
@@ -322,6 +351,21 @@ func (a *AnnotationEntry) RefersTo(entryID gitinterface.Hash) bool {
 	return false
 }
 
+// GetData decodes the annotation's data for the specified key into target. It
+// returns false if the annotation does not have data for the key.
+func (a *AnnotationEntry) GetData(key string, target any) (bool, error) {
+	value, has := a.Data[key]
+	if !has {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(value, target); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
 func (a *AnnotationEntry) setEntryNumber(repo *gitinterface.Repository) error {
 	latestEntry, err := GetLatestEntry(repo)
 	if err == nil {
@@ -358,6 +402,16 @@ func (a *AnnotationEntry) createCommitMessage(includeNumber bool) (string, error
 		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, a.Number))
 	}
 
+	if len(a.Data) != 0 {
+		// The data is encoded as compact JSON so that it is recorded on a
+		// single line
+		data, err := json.Marshal(a.Data)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", DataKey, string(data)))
+	}
+
 	if len(a.Message) != 0 {
 		var message strings.Builder
 		messageBlock := pem.Block{
@@ -521,7 +575,473 @@ func (e *PropagationEntry) createCommitMessage(includeNumber bool) (string, erro
 	if includeNumber && e.Number > 0 {
 		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, e.Number))
 	}
-	return strings.Join(lines, "\n"), nil
+	return strings.Join(lines, "\n"), nil
+}
+
+// HeartbeatEntry is a type of RSL record that attests to the RSL being current
+// as of its timestamp. Heartbeats are created periodically by the principals
+// declared in the root of trust so that a client can detect when it is served
+// an outdated RSL, such as by a forge that withholds newer entries. It
+// implements the Entry interface.
+type HeartbeatEntry struct {
+	// ID contains the Git hash for the commit corresponding to the entry.
+	ID gitinterface.Hash
+
+	// Timestamp records when the heartbeat was created.
+	Timestamp time.Time
+
+	// Number contains a strictly increasing number that hints at entry ordering.
+	Number uint64
+}
+
+// NewHeartbeatEntry returns a HeartbeatEntry object for the specified time.
+// The timestamp is recorded in UTC with a precision of one second.
+func NewHeartbeatEntry(timestamp time.Time) *HeartbeatEntry {
+	return &HeartbeatEntry{Timestamp: timestamp.UTC().Truncate(time.Second)}
+}
+
+func (e *HeartbeatEntry) GetID() gitinterface.Hash {
+	return e.ID
+}
+
+// Commit creates a commit object in the RSL for the HeartbeatEntry. The
+// function looks up the latest committed entry in the RSL and increments the
+// number in the new entry. If a parent entry does not exist or the parent
+// entry's number is 0 (unset), the current entry's number is set to 1. The
+// numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *HeartbeatEntry) Commit(repo *gitinterface.Repository, sign bool) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, _ := e.createCommitMessage(true) // we have an error return for annotations, always nil here
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.Commit(emptyTreeID, Ref, message, sign)
+	return err
+}
+
+// CommitUsingSpecificKey creates a commit object in the RSL for the
+// HeartbeatEntry. The commit is signed using the provided PEM encoded SSH or
+// GPG private key. This is only intended for use in gittuf's developer mode or
+// in tests. The function looks up the latest committed entry in the RSL and
+// increments the number in the new entry. If a parent entry does not exist or
+// the parent entry's number is 0 (unset), the current entry's number is set to
+// 1. The numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *HeartbeatEntry) CommitUsingSpecificKey(repo *gitinterface.Repository, signingKeyBytes []byte) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, _ := e.createCommitMessage(true) // we have an error return for annotations, always nil here
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CommitUsingSpecificKey(emptyTreeID, Ref, message, signingKeyBytes)
+	return err
+}
+
+func (e *HeartbeatEntry) GetNumber() uint64 {
+	return e.Number
+}
+
+func (e *HeartbeatEntry) setEntryNumber(repo *gitinterface.Repository) error {
+	latestEntry, err := GetLatestEntry(repo)
+	if err == nil {
+		e.Number = latestEntry.GetNumber() + 1
+	} else {
+		if errors.Is(err, ErrRSLEntryNotFound) {
+			// First entry
+			e.Number = 1
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *HeartbeatEntry) createCommitMessage(includeNumber bool) (string, error) {
+	lines := []string{
+		HeartbeatEntryHeader,
+		"",
+		fmt.Sprintf("%s: %s", TimestampKey, e.Timestamp.UTC().Format(time.RFC3339)),
+	}
+	if includeNumber && e.Number > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, e.Number))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// CheckpointEntry is a type of RSL record that summarizes the verified state of
+// the repository at its position in the RSL. It records the latest entry for
+// every reference along with the policy and attestations entries applicable at
+// that point. A checkpoint trusted by a threshold of root principals allows
+// verification to start from it rather than from the first entry in the RSL.
+// It implements the Entry interface.
+type CheckpointEntry struct {
+	// ID contains the Git hash for the commit corresponding to the entry.
+	ID gitinterface.Hash
+
+	// PolicyEntryID is the ID of the latest policy entry preceding the
+	// checkpoint.
+	PolicyEntryID gitinterface.Hash
+
+	// AttestationsEntryID is the ID of the latest attestations entry preceding
+	// the checkpoint. It is the zero hash if the RSL has no attestations
+	// entries.
+	AttestationsEntryID gitinterface.Hash
+
+	// RefEntryIDs maps each reference to its latest entry preceding the
+	// checkpoint.
+	RefEntryIDs map[string]gitinterface.Hash
+
+	// Signatures is a DSSE envelope containing the checkpoint's summary. Its
+	// signatures are counted alongside the commit's signature towards the
+	// root of trust's threshold. It may be nil.
+	Signatures *sslibdsse.Envelope
+
+	// Number contains a strictly increasing number that hints at entry ordering.
+	Number uint64
+}
+
+// CheckpointSummary is the statement that principals sign to approve a
+// checkpoint.
+type CheckpointSummary struct {
+	PolicyEntryID       string            `json:"policyEntryID"`
+	AttestationsEntryID string            `json:"attestationsEntryID"`
+	RefEntryIDs         map[string]string `json:"refEntryIDs"`
+}
+
+// NewCheckpointEntry returns a CheckpointEntry object for the specified state.
+func NewCheckpointEntry(policyEntryID, attestationsEntryID gitinterface.Hash, refEntryIDs map[string]gitinterface.Hash) *CheckpointEntry {
+	return &CheckpointEntry{
+		PolicyEntryID:       policyEntryID,
+		AttestationsEntryID: attestationsEntryID,
+		RefEntryIDs:         refEntryIDs,
+	}
+}
+
+func (e *CheckpointEntry) GetID() gitinterface.Hash {
+	return e.ID
+}
+
+// GetSummary returns the summary of the state recorded in the checkpoint.
+func (e *CheckpointEntry) GetSummary() *CheckpointSummary {
+	summary := &CheckpointSummary{
+		PolicyEntryID:       e.PolicyEntryID.String(),
+		AttestationsEntryID: e.AttestationsEntryID.String(),
+		RefEntryIDs:         make(map[string]string, len(e.RefEntryIDs)),
+	}
+	for refName, entryID := range e.RefEntryIDs {
+		summary.RefEntryIDs[refName] = entryID.String()
+	}
+	return summary
+}
+
+// Commit creates a commit object in the RSL for the CheckpointEntry. The
+// function looks up the latest committed entry in the RSL and increments the
+// number in the new entry. If a parent entry does not exist or the parent
+// entry's number is 0 (unset), the current entry's number is set to 1. The
+// numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *CheckpointEntry) Commit(repo *gitinterface.Repository, sign bool) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage(true)
+	if err != nil {
+		return err
+	}
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.Commit(emptyTreeID, Ref, message, sign)
+	return err
+}
+
+// CommitUsingSpecificKey creates a commit object in the RSL for the
+// CheckpointEntry. The commit is signed using the provided PEM encoded SSH or
+// GPG private key. This is only intended for use in gittuf's developer mode or
+// in tests. The function looks up the latest committed entry in the RSL and
+// increments the number in the new entry. If a parent entry does not exist or
+// the parent entry's number is 0 (unset), the current entry's number is set to
+// 1. The numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *CheckpointEntry) CommitUsingSpecificKey(repo *gitinterface.Repository, signingKeyBytes []byte) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage(true)
+	if err != nil {
+		return err
+	}
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CommitUsingSpecificKey(emptyTreeID, Ref, message, signingKeyBytes)
+	return err
+}
+
+func (e *CheckpointEntry) GetNumber() uint64 {
+	return e.Number
+}
+
+func (e *CheckpointEntry) setEntryNumber(repo *gitinterface.Repository) error {
+	latestEntry, err := GetLatestEntry(repo)
+	if err == nil {
+		e.Number = latestEntry.GetNumber() + 1
+	} else {
+		if errors.Is(err, ErrRSLEntryNotFound) {
+			// First entry
+			e.Number = 1
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *CheckpointEntry) createCommitMessage(includeNumber bool) (string, error) {
+	lines := []string{
+		CheckpointEntryHeader,
+		"",
+		fmt.Sprintf("%s: %s", PolicyEntryIDKey, e.PolicyEntryID.String()),
+		fmt.Sprintf("%s: %s", AttestationsEntryIDKey, e.AttestationsEntryID.String()),
+	}
+
+	refNames := make([]string, 0, len(e.RefEntryIDs))
+	for refName := range e.RefEntryIDs {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+	for _, refName := range refNames {
+		lines = append(lines, fmt.Sprintf("%s: %s", RefKey, refName))
+		lines = append(lines, fmt.Sprintf("%s: %s", EntryIDKey, e.RefEntryIDs[refName].String()))
+	}
+
+	if includeNumber && e.Number > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, e.Number))
+	}
+
+	if e.Signatures != nil {
+		envelopeBytes, err := json.Marshal(e.Signatures)
+		if err != nil {
+			return "", err
+		}
+
+		var signatures strings.Builder
+		signaturesBlock := pem.Block{
+			Type:  CheckpointSignaturesBlockType,
+			Bytes: envelopeBytes,
+		}
+		if err := pem.Encode(&signatures, &signaturesBlock); err != nil {
+			return "", err
+		}
+		lines = append(lines, strings.TrimSpace(signatures.String()))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// MultiReferenceEntry is a record of the states of several references in the
+// RSL. The references are updated atomically, so the entry is treated as a
+// single unit during verification. Each reference's update is exposed as a
+// ReferenceEntry that shares the multi-reference entry's ID and number. It
+// implements the Entry interface.
+type MultiReferenceEntry struct {
+	// ID contains the Git hash for the commit corresponding to the entry.
+	ID gitinterface.Hash
+
+	// TargetIDs maps each Git reference the entry is for to the Git hash for
+	// the object expected at it.
+	TargetIDs map[string]gitinterface.Hash
+
+	// Number contains a strictly increasing number that hints at entry ordering.
+	Number uint64
+}
+
+// NewMultiReferenceEntry returns a MultiReferenceEntry object for the specified
+// reference states.
+func NewMultiReferenceEntry(targetIDs map[string]gitinterface.Hash) *MultiReferenceEntry {
+	return &MultiReferenceEntry{TargetIDs: targetIDs}
+}
+
+func (e *MultiReferenceEntry) GetID() gitinterface.Hash {
+	return e.ID
+}
+
+// GetRefNames returns the sorted names of the references recorded in the entry.
+func (e *MultiReferenceEntry) GetRefNames() []string {
+	refNames := make([]string, 0, len(e.TargetIDs))
+	for refName := range e.TargetIDs {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+	return refNames
+}
+
+// GetReferenceEntries returns the update to each reference recorded in the
+// entry as a ReferenceEntry, sorted by reference name.
+func (e *MultiReferenceEntry) GetReferenceEntries() []*ReferenceEntry {
+	entries := make([]*ReferenceEntry, 0, len(e.TargetIDs))
+	for _, refName := range e.GetRefNames() {
+		entry, _ := e.GetReferenceEntryForRef(refName)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// GetReferenceEntryForRef returns the update to the specified reference
+// recorded in the entry as a ReferenceEntry. The boolean is false if the entry
+// does not record the reference.
+func (e *MultiReferenceEntry) GetReferenceEntryForRef(refName string) (*ReferenceEntry, bool) {
+	targetID, has := e.TargetIDs[refName]
+	if !has {
+		return nil, false
+	}
+
+	return &ReferenceEntry{ID: e.ID, RefName: refName, TargetID: targetID, Number: e.Number}, true
+}
+
+// Commit creates a commit object in the RSL for the MultiReferenceEntry. The
+// function looks up the latest committed entry in the RSL and increments the
+// number in the new entry. If a parent entry does not exist or the parent
+// entry's number is 0 (unset), the current entry's number is set to 1. The
+// numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *MultiReferenceEntry) Commit(repo *gitinterface.Repository, sign bool) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage(true)
+	if err != nil {
+		return err
+	}
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.Commit(emptyTreeID, Ref, message, sign)
+	return err
+}
+
+// CommitUsingSpecificKey creates a commit object in the RSL for the
+// MultiReferenceEntry. The commit is signed using the provided PEM encoded SSH
+// or GPG private key. This is only intended for use in gittuf's developer mode
+// or in tests. The function looks up the latest committed entry in the RSL and
+// increments the number in the new entry. If a parent entry does not exist or
+// the parent entry's number is 0 (unset), the current entry's number is set to
+// 1. The numbering starts from 1 as 0 is used to signal the lack of numbering.
+func (e *MultiReferenceEntry) CommitUsingSpecificKey(repo *gitinterface.Repository, signingKeyBytes []byte) error {
+	if err := e.setEntryNumber(repo); err != nil {
+		return err
+	}
+
+	message, err := e.createCommitMessage(true)
+	if err != nil {
+		return err
+	}
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CommitUsingSpecificKey(emptyTreeID, Ref, message, signingKeyBytes)
+	return err
+}
+
+func (e *MultiReferenceEntry) GetNumber() uint64 {
+	return e.Number
+}
+
+// SkippedBy returns true if any of the annotations mark the entry as
+// to-be-skipped. Skipping the entry skips the updates to all of its
+// references.
+func (e *MultiReferenceEntry) SkippedBy(annotations []*AnnotationEntry) bool {
+	for _, annotation := range annotations {
+		if annotation.RefersTo(e.ID) && annotation.Skip {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *MultiReferenceEntry) setEntryNumber(repo *gitinterface.Repository) error {
+	latestEntry, err := GetLatestEntry(repo)
+	if err == nil {
+		e.Number = latestEntry.GetNumber() + 1
+	} else {
+		if errors.Is(err, ErrRSLEntryNotFound) {
+			// First entry
+			e.Number = 1
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *MultiReferenceEntry) createCommitMessage(includeNumber bool) (string, error) {
+	if len(e.TargetIDs) == 0 {
+		return "", ErrInvalidMultiReferenceEntry
+	}
+
+	lines := []string{
+		MultiReferenceEntryHeader,
+		"",
+	}
+	for _, refName := range e.GetRefNames() {
+		if strings.HasPrefix(refName, gittufNamespacePrefix) {
+			return "", ErrInvalidMultiReferenceEntry
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", RefKey, refName))
+		lines = append(lines, fmt.Sprintf("%s: %s", TargetIDKey, e.TargetIDs[refName].String()))
+	}
+	if includeNumber && e.Number > 0 {
+		lines = append(lines, fmt.Sprintf("%s: %d", NumberKey, e.Number))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// GetReferenceUpdaterEntries returns the reference updates recorded in the
+// specified entry. A reference updater entry is returned as is, while a
+// multi-reference entry is expanded into a ReferenceEntry for each of its
+// references. Other entries record no reference updates.
+func GetReferenceUpdaterEntries(entry Entry) []ReferenceUpdaterEntry {
+	switch entry := entry.(type) {
+	case ReferenceUpdaterEntry:
+		return []ReferenceUpdaterEntry{entry}
+	case *MultiReferenceEntry:
+		referenceEntries := entry.GetReferenceEntries()
+		entries := make([]ReferenceUpdaterEntry, 0, len(referenceEntries))
+		for _, referenceEntry := range referenceEntries {
+			entries = append(entries, referenceEntry)
+		}
+		return entries
+	default:
+		return nil
+	}
 }
 
 // GetEntry returns the entry corresponding to entryID.
@@ -623,13 +1143,15 @@ func GetNonGittufParentReferenceUpdaterEntryForEntry(repo *gitinterface.Reposito
 
 	var targetEntry ReferenceUpdaterEntry
 	for {
-		switch iterator := it.(type) {
-		case ReferenceUpdaterEntry:
-			if !strings.HasPrefix(iterator.GetRefName(), gittufNamespacePrefix) {
-				targetEntry = iterator
+		if annotation, isAnnotation := it.(*AnnotationEntry); isAnnotation {
+			allAnnotations = append(allAnnotations, annotation)
+		}
+
+		for _, entry := range GetReferenceUpdaterEntries(it) {
+			if !strings.HasPrefix(entry.GetRefName(), gittufNamespacePrefix) {
+				targetEntry = entry
+				break
 			}
-		case *AnnotationEntry:
-			allAnnotations = append(allAnnotations, iterator)
 		}
 
 		if targetEntry != nil {
@@ -709,6 +1231,21 @@ func GetLatestReferenceUpdaterEntry(repo *gitinterface.Repository, opts ...GetLa
 		return nil, nil, ErrInvalidUntilEntryNumberCondition
 	}
 
+	// Use the index when searching for a specific reference as we can then
+	// only consider the entries for the reference
+	if options.Reference != "" && options.UntilEntryID.IsZero() && iteratorT.GetNumber() != 0 {
+		var (
+			targetEntry ReferenceUpdaterEntry
+			annotations []*AnnotationEntry
+			found       bool
+		)
+		if withIndex(repo, func(index *Index) {
+			targetEntry, annotations, found, err = getLatestReferenceUpdaterEntryFromIndex(repo, index, iteratorT, &options)
+		}) && found {
+			return targetEntry, annotations, err
+		}
+	}
+
 	// Do initial walk if either before condition is set
 	if !options.BeforeEntryID.IsZero() || options.BeforeEntryNumber != 0 {
 		slog.Debug("Scanning RSL for search start point using before condition...")
@@ -746,48 +1283,20 @@ func GetLatestReferenceUpdaterEntry(repo *gitinterface.Repository, opts ...GetLa
 
 	var targetEntry ReferenceUpdaterEntry
 	for {
-		switch iterator := iteratorT.(type) {
-		case ReferenceUpdaterEntry:
-			matchesConditions := true
-
-			if options.Reference != "" && iterator.GetRefName() != options.Reference {
-				matchesConditions = false
-			}
-
-			if matchesConditions && options.IsReferenceEntry {
-				if _, isReferenceEntry := iterator.(*ReferenceEntry); !isReferenceEntry {
-					matchesConditions = false
-				}
-			}
-
-			// Only reference entry can be skipped
-			referenceEntry, isReferenceEntry := iterator.(*ReferenceEntry)
-			if isReferenceEntry {
-				if matchesConditions && options.Unskipped && referenceEntry.SkippedBy(allAnnotations) {
-					// SkippedBy ensures only the applicable
-					// annotations that refer to the entry
-					// are used
-					matchesConditions = false
-				}
-			}
-
-			if matchesConditions && options.IsPropagationEntryForRepository != "" {
-				propagationEntry, isPropagationEntry := iterator.(*PropagationEntry)
-				if !isPropagationEntry || propagationEntry.UpstreamRepository != options.IsPropagationEntryForRepository {
-					matchesConditions = false
-				}
-			}
+		if annotation, isAnnotation := iteratorT.(*AnnotationEntry); isAnnotation {
+			allAnnotations = append(allAnnotations, annotation)
+		}
 
-			if matchesConditions && options.NonGittuf && strings.HasPrefix(iterator.GetRefName(), gittufNamespacePrefix) {
-				matchesConditions = false
+		// A multi-reference entry is considered for each of its references
+		for _, iterator := range GetReferenceUpdaterEntries(iteratorT) {
+			matches, err := options.matches(iterator, allAnnotations)
+			if err != nil {
+				return nil, nil, err
 			}
-
-			if matchesConditions {
+			if matches {
 				targetEntry = iterator
+				break
 			}
-
-		case *AnnotationEntry:
-			allAnnotations = append(allAnnotations, iterator)
 		}
 
 		if targetEntry != nil {
@@ -830,17 +1339,44 @@ func GetFirstReferenceUpdaterEntryForRef(repo *gitinterface.Repository, targetRe
 		return nil, nil, err
 	}
 
+	if targetRef != "" && iteratorT.GetNumber() != 0 {
+		var (
+			firstEntry  ReferenceUpdaterEntry
+			annotations []*AnnotationEntry
+		)
+		if withIndex(repo, func(index *Index) {
+			numbers := index.getRefEntries(targetRef)
+			if len(numbers) == 0 {
+				err = ErrRSLEntryNotFound
+				return
+			}
+
+			firstEntry, err = index.getEntryForRef(repo, numbers[0], targetRef)
+			if err != nil {
+				return
+			}
+			annotations, err = index.getAnnotations(repo, numbers[0])
+		}) {
+			if err != nil {
+				return nil, nil, err
+			}
+			return firstEntry, reverseAnnotations(annotations), nil
+		}
+	}
+
 	allAnnotations := []*AnnotationEntry{}
 	var firstEntry ReferenceUpdaterEntry
 
 	for {
-		switch entry := iteratorT.(type) {
-		case ReferenceUpdaterEntry:
+		if annotation, isAnnotation := iteratorT.(*AnnotationEntry); isAnnotation {
+			allAnnotations = append(allAnnotations, annotation)
+		}
+
+		for _, entry := range GetReferenceUpdaterEntries(iteratorT) {
 			if targetRef == "" || entry.GetRefName() == targetRef {
 				firstEntry = entry
+				break
 			}
-		case *AnnotationEntry:
-			allAnnotations = append(allAnnotations, entry)
 		}
 
 		parentT, err := GetParentForEntry(repo, iteratorT)
@@ -893,7 +1429,13 @@ func SkipAllInvalidReferenceEntriesForRef(repo *gitinterface.Repository, targetR
 	entriesToSkip := []gitinterface.Hash{}
 
 	for {
-		if entry, ok := iterator.(*ReferenceEntry); ok {
+		entry, ok := iterator.(*ReferenceEntry)
+		if multiReferenceEntry, isMultiReferenceEntry := iterator.(*MultiReferenceEntry); isMultiReferenceEntry {
+			// Skipping a multi-reference entry skips the updates to all of
+			// its references, as they were recorded as a unit
+			entry, ok = multiReferenceEntry.GetReferenceEntryForRef(targetRef)
+		}
+		if ok {
 			isAncestor, err := repo.KnowsCommit(latestEntry.GetTargetID(), entry.TargetID)
 			if err != nil {
 				return err
@@ -929,6 +1471,17 @@ func SkipAllInvalidReferenceEntriesForRef(repo *gitinterface.Repository, targetR
 // irrespective of the ref it was associated with, and we can infer things like
 // the active developers who could have signed the commit.
 func GetFirstReferenceUpdaterEntryForCommit(repo *gitinterface.Repository, commitID gitinterface.Hash) (ReferenceUpdaterEntry, []*AnnotationEntry, error) {
+	var (
+		indexedEntry       ReferenceUpdaterEntry
+		indexedAnnotations []*AnnotationEntry
+		indexErr           error
+	)
+	if withIndex(repo, func(index *Index) {
+		indexedEntry, indexedAnnotations, indexErr = getFirstReferenceUpdaterEntryForCommitFromIndex(repo, index, commitID)
+	}) {
+		return indexedEntry, indexedAnnotations, indexErr
+	}
+
 	// We check entries in pairs. In the initial case, we have the latest entry
 	// and its parent. At all times, the parent in the pair is being tested.
 	// If the latest entry is a descendant of the target commit, we start
@@ -988,6 +1541,20 @@ func GetReferenceUpdaterEntriesInRange(repo *gitinterface.Repository, firstID, l
 // of the reference entry, with the value being a list of annotations that apply
 // to that reference entry.
 func GetReferenceUpdaterEntriesInRangeForRef(repo *gitinterface.Repository, firstID, lastID gitinterface.Hash, refName string) ([]ReferenceUpdaterEntry, map[string][]*AnnotationEntry, error) {
+	if len(refName) != 0 {
+		var (
+			indexedEntries     []ReferenceUpdaterEntry
+			indexedAnnotations map[string][]*AnnotationEntry
+			found              bool
+			err                error
+		)
+		if withIndex(repo, func(index *Index) {
+			indexedEntries, indexedAnnotations, found, err = getReferenceUpdaterEntriesInRangeForRefFromIndex(repo, index, firstID, lastID, refName)
+		}) && found {
+			return indexedEntries, indexedAnnotations, err
+		}
+	}
+
 	// We have to iterate from latest to get the annotations that refer to the
 	// last requested entry
 	iterator, err := GetLatestEntry(repo)
@@ -1015,8 +1582,15 @@ func GetReferenceUpdaterEntriesInRangeForRef(repo *gitinterface.Repository, firs
 	for !iterator.GetID().Equal(firstID) {
 		// Here, all items are relevant until the one corresponding to first is
 		// found
-		switch it := iterator.(type) {
-		case ReferenceUpdaterEntry:
+		if annotation, isAnnotation := iterator.(*AnnotationEntry); isAnnotation {
+			allAnnotations = append(allAnnotations, annotation)
+		}
+
+		// A multi-reference entry is expanded in reverse so that its
+		// references are in order once entryStack is reversed
+		entries := GetReferenceUpdaterEntries(iterator)
+		for i := len(entries) - 1; i >= 0; i-- {
+			it := entries[i]
 			if len(refName) == 0 || it.GetRefName() == refName || isRelevantGittufRef(it.GetRefName()) {
 				// It's a relevant entry if:
 				// a) there's no refName set, or
@@ -1025,8 +1599,6 @@ func GetReferenceUpdaterEntriesInRangeForRef(repo *gitinterface.Repository, firs
 				entryStack = append(entryStack, it)
 				inRange[it.GetID().String()] = true
 			}
-		case *AnnotationEntry:
-			allAnnotations = append(allAnnotations, it)
 		}
 
 		parent, err := GetParentForEntry(repo, iterator)
@@ -1039,7 +1611,9 @@ func GetReferenceUpdaterEntriesInRangeForRef(repo *gitinterface.Repository, firs
 	// Handle the item corresponding to first explicitly
 	// If it's an annotation, ignore it as it refers to something before the
 	// range we care about
-	if entry, isEntry := iterator.(ReferenceUpdaterEntry); isEntry {
+	entries := GetReferenceUpdaterEntries(iterator)
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
 		if len(refName) == 0 || entry.GetRefName() == refName || isRelevantGittufRef(entry.GetRefName()) {
 			// It's a relevant entry if:
 			// a) there's no refName set, or
@@ -1079,15 +1653,61 @@ func GetReferenceUpdaterEntriesInRangeForRef(repo *gitinterface.Repository, firs
 	return allEntries, annotationMap, nil
 }
 
+// GetReferencesUpdatedSince returns the sorted names of all non-gittuf
+// references that have reference updater entries in the RSL after the entry
+// with the specified ID. If sinceEntryID is zero, every non-gittuf reference
+// recorded in the RSL is returned.
+func GetReferencesUpdatedSince(repo *gitinterface.Repository, sinceEntryID gitinterface.Hash) ([]string, error) {
+	if !sinceEntryID.IsZero() {
+		// Ensure the entry actually exists so that we don't silently walk the
+		// entire RSL for a typo
+		if _, err := GetEntry(repo, sinceEntryID); err != nil {
+			return nil, err
+		}
+	}
+
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	refNames := map[string]bool{}
+	for !iterator.GetID().Equal(sinceEntryID) {
+		for _, entry := range GetReferenceUpdaterEntries(iterator) {
+			if !strings.HasPrefix(entry.GetRefName(), gittufNamespacePrefix) {
+				refNames[entry.GetRefName()] = true
+			}
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	references := make([]string, 0, len(refNames))
+	for refName := range refNames {
+		references = append(references, refName)
+	}
+	slices.Sort(references)
+
+	return references, nil
+}
+
 // PropagateChangesFromUpstreamRepository executes gittuf's propagation workflow
 // to create a subtree of the contents of an upstream repository's reference
-// into the specified reference and path in the downstream repository.
-func PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo *gitinterface.Repository, details []tuf.PropagationDirective, sign bool) error {
+// into the specified reference and path in the downstream repository. Skip
+// annotations in the upstream repository's RSL are only honored if they are
+// permitted by upstreamSkipAuthorizer; a nil authorizer honors all of them.
+func PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo *gitinterface.Repository, details []tuf.PropagationDirective, upstreamSkipAuthorizer SkipAuthorizer, sign bool) error {
 	// FIXME: We assume here that downstreamRepo and upstreamRepo have their
 	// gittuf refs already synced.
 
 	for _, detail := range details {
-		latestUpstreamEntry, _, err := GetLatestReferenceUpdaterEntry(upstreamRepo, ForReference(detail.GetUpstreamReference()), IsUnskipped())
+		latestUpstreamEntry, _, err := GetLatestReferenceUpdaterEntry(upstreamRepo, ForReference(detail.GetUpstreamReference()), IsUnskipped(), WithSkipAuthorizer(upstreamSkipAuthorizer))
 		if err != nil {
 			if !errors.Is(err, ErrRSLEntryNotFound) {
 				return err
@@ -1166,6 +1786,24 @@ func parseRSLEntryText(id gitinterface.Hash, text string) (Entry, error) {
 			return nil, err
 		}
 		return entry, nil
+	case strings.HasPrefix(text, HeartbeatEntryHeader):
+		entry, err := parseHeartbeatEntryText(id, text)
+		if err != nil {
+			return nil, err
+		}
+		return entry, nil
+	case strings.HasPrefix(text, CheckpointEntryHeader):
+		entry, err := parseCheckpointEntryText(id, text)
+		if err != nil {
+			return nil, err
+		}
+		return entry, nil
+	case strings.HasPrefix(text, MultiReferenceEntryHeader):
+		entry, err := parseMultiReferenceEntryText(id, text)
+		if err != nil {
+			return nil, err
+		}
+		return entry, nil
 	default:
 		return nil, ErrInvalidRSLEntry
 	}
@@ -1260,6 +1898,7 @@ func parseAnnotationEntryText(id gitinterface.Hash, text string) (*AnnotationEnt
 	const (
 		expectEntryID = iota // one or more entryIDs, then skip
 		expectNumber         // entryIDs and skip seen; optional number
+		expectData           // optional data
 		done
 	)
 
@@ -1308,6 +1947,19 @@ func parseAnnotationEntryText(id gitinterface.Hash, text string) (*AnnotationEnt
 			if err := setNumber(&annotation.Number, value); err != nil {
 				return nil, err
 			}
+			state = expectData
+
+		case DataKey:
+			if state != expectNumber && state != expectData {
+				return nil, ErrInvalidRSLEntry
+			}
+			data := map[string]json.RawMessage{}
+			if err := json.Unmarshal([]byte(value), &data); err != nil {
+				return nil, errors.Join(ErrInvalidRSLEntry, err)
+			}
+			if len(data) != 0 {
+				annotation.Data = data
+			}
 			state = done
 		}
 	}
@@ -1399,6 +2051,242 @@ func parsePropagationEntryText(id gitinterface.Hash, text string) (*PropagationE
 	return entry, nil
 }
 
+// parseHeartbeatEntryText parses a heartbeat entry as a state machine. The
+// fields must appear in the order timestamp, number, each at most once; number
+// is optional and trailing.
+func parseHeartbeatEntryText(id gitinterface.Hash, text string) (*HeartbeatEntry, error) {
+	body, err := entryBody(text, HeartbeatEntryHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		expectTimestamp = iota
+		expectNumber
+		done
+	)
+
+	entry := &HeartbeatEntry{ID: id}
+	state := expectTimestamp
+	for _, line := range body {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			return nil, ErrInvalidRSLEntry
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case TimestampKey:
+			if state != expectTimestamp {
+				return nil, ErrInvalidRSLEntry
+			}
+			// The timestamp also contains ':', so value retains everything
+			// after the first separator.
+			timestamp, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, errors.Join(ErrInvalidRSLEntry, err)
+			}
+			entry.Timestamp = timestamp
+			state = expectNumber
+
+		case NumberKey:
+			if state != expectNumber {
+				return nil, ErrInvalidRSLEntry
+			}
+			if err := setNumber(&entry.Number, value); err != nil {
+				return nil, err
+			}
+			state = done
+		}
+	}
+
+	if state < expectNumber {
+		// timestamp was not seen.
+		return nil, ErrInvalidRSLEntry
+	}
+	return entry, nil
+}
+
+// parseCheckpointEntryText parses a checkpoint entry as a state machine. The
+// fields must appear in the order policyEntryID, attestationsEntryID, zero or
+// more ref and entryID pairs, then an optional number, followed by an optional
+// PEM signatures block. The signatures are decoded separately, so the state
+// machine stops at their begin marker.
+func parseCheckpointEntryText(id gitinterface.Hash, text string) (*CheckpointEntry, error) {
+	body, err := entryBody(text, CheckpointEntryHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &CheckpointEntry{
+		ID:          id,
+		RefEntryIDs: map[string]gitinterface.Hash{},
+	}
+
+	if strings.Contains(text, BeginSignatures) {
+		_, signatures, _ := strings.Cut(text, "\n"+BeginSignatures)
+		signaturesBlock, _ := pem.Decode([]byte(BeginSignatures + signatures))
+		if signaturesBlock == nil {
+			return nil, ErrInvalidRSLEntry
+		}
+
+		envelope := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(signaturesBlock.Bytes, envelope); err != nil {
+			return nil, errors.Join(ErrInvalidRSLEntry, err)
+		}
+		entry.Signatures = envelope
+	}
+
+	const (
+		expectPolicyEntryID = iota
+		expectAttestationsEntryID
+		expectRef // zero or more ref and entryID pairs, then optional number
+		expectRefEntryID
+		done
+	)
+
+	state := expectPolicyEntryID
+	refName := ""
+	for _, line := range body {
+		line = strings.TrimSpace(line)
+		if line == BeginSignatures {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, ErrInvalidRSLEntry
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case PolicyEntryIDKey:
+			if state != expectPolicyEntryID {
+				return nil, ErrInvalidRSLEntry
+			}
+			if err := setHash(&entry.PolicyEntryID, value); err != nil {
+				return nil, err
+			}
+			state = expectAttestationsEntryID
+
+		case AttestationsEntryIDKey:
+			if state != expectAttestationsEntryID {
+				return nil, ErrInvalidRSLEntry
+			}
+			if err := setHash(&entry.AttestationsEntryID, value); err != nil {
+				return nil, err
+			}
+			state = expectRef
+
+		case RefKey:
+			if state != expectRef {
+				return nil, ErrInvalidRSLEntry
+			}
+			if _, has := entry.RefEntryIDs[value]; has {
+				return nil, ErrInvalidRSLEntry
+			}
+			refName = value
+			state = expectRefEntryID
+
+		case EntryIDKey:
+			if state != expectRefEntryID {
+				return nil, ErrInvalidRSLEntry
+			}
+			var entryID gitinterface.Hash
+			if err := setHash(&entryID, value); err != nil {
+				return nil, err
+			}
+			entry.RefEntryIDs[refName] = entryID
+			state = expectRef
+
+		case NumberKey:
+			if state != expectRef {
+				return nil, ErrInvalidRSLEntry
+			}
+			if err := setNumber(&entry.Number, value); err != nil {
+				return nil, err
+			}
+			state = done
+		}
+	}
+
+	if state != expectRef && state != done {
+		// A required field was not seen or a ref is missing its entryID.
+		return nil, ErrInvalidRSLEntry
+	}
+	return entry, nil
+}
+
+// parseMultiReferenceEntryText parses a multi-reference entry as a state
+// machine. One or more ref and targetID pairs come first, followed by an
+// optional number. A reference may appear at most once and may not be in the
+// gittuf namespace.
+func parseMultiReferenceEntryText(id gitinterface.Hash, text string) (*MultiReferenceEntry, error) {
+	body, err := entryBody(text, MultiReferenceEntryHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		expectRef = iota
+		expectTargetID
+		expectRefOrNumber
+		done
+	)
+
+	entry := &MultiReferenceEntry{
+		ID:        id,
+		TargetIDs: map[string]gitinterface.Hash{},
+	}
+	state := expectRef
+	refName := ""
+	for _, line := range body {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			return nil, ErrInvalidRSLEntry
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case RefKey:
+			if state != expectRef && state != expectRefOrNumber {
+				return nil, ErrInvalidRSLEntry
+			}
+			if _, has := entry.TargetIDs[value]; has || strings.HasPrefix(value, gittufNamespacePrefix) {
+				return nil, ErrInvalidRSLEntry
+			}
+			refName = value
+			state = expectTargetID
+
+		case TargetIDKey:
+			if state != expectTargetID {
+				return nil, ErrInvalidRSLEntry
+			}
+			var targetID gitinterface.Hash
+			if err := setHash(&targetID, value); err != nil {
+				return nil, err
+			}
+			entry.TargetIDs[refName] = targetID
+			state = expectRefOrNumber
+
+		case NumberKey:
+			if state != expectRefOrNumber {
+				return nil, ErrInvalidRSLEntry
+			}
+			if err := setNumber(&entry.Number, value); err != nil {
+				return nil, err
+			}
+			state = done
+		}
+	}
+
+	if state < expectRefOrNumber {
+		// No ref was seen or a ref is missing its targetID.
+		return nil, ErrInvalidRSLEntry
+	}
+	return entry, nil
+}
+
 // entryBody validates the entry's header line and the mandatory blank line that
 // follows it, returning the remaining body lines for the state machine.
 func entryBody(text, header string) ([]string, error) {
@@ -5,12 +5,15 @@ package rsl
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
 	"slices"
 	"testing"
+	"time"
 
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibdsse "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/dsse"
 	"github.com/gittuf/gittuf/internal/tuf"
 	tufv01 "github.com/gittuf/gittuf/internal/tuf/v01"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
@@ -105,6 +108,238 @@ func TestNewReferenceEntry(t *testing.T) {
 	assert.Contains(t, parentIDs, currentTip)
 }
 
+func TestReferenceEntryIsDeletion(t *testing.T) {
+	targetID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	require.NoError(t, err)
+
+	assert.False(t, NewReferenceEntry("refs/heads/main", targetID).IsDeletion())
+	assert.True(t, NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).IsDeletion())
+}
+
+func TestNewHeartbeatEntry(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	if err := NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	timestamp := time.Date(1995, time.October, 26, 9, 0, 0, 500, time.FixedZone("EDT", -4*60*60))
+	if err := NewHeartbeatEntry(timestamp).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitMessage, err := repo.GetCommitMessage(latestEntry.GetID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %d", HeartbeatEntryHeader, TimestampKey, "1995-10-26T13:00:00Z", NumberKey, 2)
+	assert.Equal(t, expectedMessage, commitMessage)
+
+	heartbeatEntry, isHeartbeatEntry := latestEntry.(*HeartbeatEntry)
+	require.True(t, isHeartbeatEntry)
+	assert.True(t, timestamp.Truncate(time.Second).Equal(heartbeatEntry.Timestamp))
+	assert.Equal(t, uint64(2), heartbeatEntry.GetNumber())
+}
+
+func TestNewCheckpointEntry(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	if err := NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	refEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := NewCheckpointEntry(gitinterface.ZeroHash, gitinterface.ZeroHash, map[string]gitinterface.Hash{"refs/heads/main": refEntry.GetID()})
+	checkpoint.Signatures = &sslibdsse.Envelope{
+		PayloadType: "application/vnd.gittuf+json",
+		Payload:     "e30=",
+		Signatures:  []sslibdsse.Signature{{KeyID: "test-key", Sig: "c2lnbmF0dXJl"}},
+	}
+	if err := checkpoint.Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpointEntry, isCheckpointEntry := latestEntry.(*CheckpointEntry)
+	require.True(t, isCheckpointEntry)
+	assert.Equal(t, gitinterface.ZeroHash, checkpointEntry.PolicyEntryID)
+	assert.Equal(t, gitinterface.ZeroHash, checkpointEntry.AttestationsEntryID)
+	assert.Equal(t, map[string]gitinterface.Hash{"refs/heads/main": refEntry.GetID()}, checkpointEntry.RefEntryIDs)
+	assert.Equal(t, checkpoint.Signatures, checkpointEntry.Signatures)
+	assert.Equal(t, uint64(2), checkpointEntry.GetNumber())
+
+	summary := checkpointEntry.GetSummary()
+	assert.Equal(t, &CheckpointSummary{
+		PolicyEntryID:       gitinterface.ZeroHash.String(),
+		AttestationsEntryID: gitinterface.ZeroHash.String(),
+		RefEntryIDs:         map[string]string{"refs/heads/main": refEntry.GetID().String()},
+	}, summary)
+}
+
+func TestNewMultiReferenceEntry(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	if err := NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/feature", "Test commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetIDs := map[string]gitinterface.Hash{
+		"refs/tags/v1":       commitID,
+		"refs/heads/feature": commitID,
+	}
+	if err := NewMultiReferenceEntry(targetIDs).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitMessage, err := repo.GetCommitMessage(latestEntry.GetID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedMessage := fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", MultiReferenceEntryHeader, RefKey, "refs/heads/feature", TargetIDKey, commitID.String(), RefKey, "refs/tags/v1", TargetIDKey, commitID.String(), NumberKey, 2)
+	assert.Equal(t, expectedMessage, commitMessage)
+
+	multiReferenceEntry, isMultiReferenceEntry := latestEntry.(*MultiReferenceEntry)
+	require.True(t, isMultiReferenceEntry)
+	assert.Equal(t, targetIDs, multiReferenceEntry.TargetIDs)
+	assert.Equal(t, uint64(2), multiReferenceEntry.GetNumber())
+	assert.Equal(t, []string{"refs/heads/feature", "refs/tags/v1"}, multiReferenceEntry.GetRefNames())
+
+	expectedEntries := []*ReferenceEntry{
+		{ID: latestEntry.GetID(), RefName: "refs/heads/feature", TargetID: commitID, Number: 2},
+		{ID: latestEntry.GetID(), RefName: "refs/tags/v1", TargetID: commitID, Number: 2},
+	}
+	assert.Equal(t, expectedEntries, multiReferenceEntry.GetReferenceEntries())
+
+	_, has := multiReferenceEntry.GetReferenceEntryForRef("refs/heads/main")
+	assert.False(t, has)
+
+	t.Run("no references", func(t *testing.T) {
+		err := NewMultiReferenceEntry(nil).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrInvalidMultiReferenceEntry)
+	})
+
+	t.Run("gittuf reference", func(t *testing.T) {
+		err := NewMultiReferenceEntry(map[string]gitinterface.Hash{
+			"refs/heads/main": commitID,
+			Ref:               commitID,
+		}).Commit(repo, false)
+		assert.ErrorIs(t, err, ErrInvalidMultiReferenceEntry)
+	})
+}
+
+func TestGetReferenceUpdaterEntries(t *testing.T) {
+	entryID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	require.NoError(t, err)
+
+	referenceEntry := &ReferenceEntry{ID: entryID, RefName: "refs/heads/main", TargetID: gitinterface.ZeroHash, Number: 1}
+	assert.Equal(t, []ReferenceUpdaterEntry{referenceEntry}, GetReferenceUpdaterEntries(referenceEntry))
+
+	multiReferenceEntry := &MultiReferenceEntry{
+		ID: entryID,
+		TargetIDs: map[string]gitinterface.Hash{
+			"refs/tags/v1":    entryID,
+			"refs/heads/main": gitinterface.ZeroHash,
+		},
+		Number: 2,
+	}
+	assert.Equal(t, []ReferenceUpdaterEntry{
+		&ReferenceEntry{ID: entryID, RefName: "refs/heads/main", TargetID: gitinterface.ZeroHash, Number: 2},
+		&ReferenceEntry{ID: entryID, RefName: "refs/tags/v1", TargetID: entryID, Number: 2},
+	}, GetReferenceUpdaterEntries(multiReferenceEntry))
+
+	assert.Nil(t, GetReferenceUpdaterEntries(&AnnotationEntry{ID: entryID}))
+}
+
+func TestMultiReferenceEntryWalks(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	if err := NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewMultiReferenceEntry(map[string]gitinterface.Hash{
+		"refs/heads/main":    gitinterface.ZeroHash,
+		"refs/heads/feature": gitinterface.ZeroHash,
+	}).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	multiEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/other", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	lastEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	featureEntry := &ReferenceEntry{ID: multiEntry.GetID(), RefName: "refs/heads/feature", TargetID: gitinterface.ZeroHash, Number: 2}
+	mainEntry := &ReferenceEntry{ID: multiEntry.GetID(), RefName: "refs/heads/main", TargetID: gitinterface.ZeroHash, Number: 2}
+
+	entry, _, err := GetLatestReferenceUpdaterEntry(repo, ForReference("refs/heads/main"))
+	assert.Nil(t, err)
+	assert.Equal(t, mainEntry, entry)
+
+	entry, _, err = GetLatestReferenceUpdaterEntry(repo, ForReference("refs/heads/main"), BeforeEntryID(multiEntry.GetID()))
+	assert.Nil(t, err)
+	assert.Equal(t, firstEntry, entry)
+
+	entry, _, err = GetFirstReferenceUpdaterEntryForRef(repo, "refs/heads/feature")
+	assert.Nil(t, err)
+	assert.Equal(t, featureEntry, entry)
+
+	entries, _, err := GetReferenceUpdaterEntriesInRange(repo, firstEntry.GetID(), lastEntry.GetID())
+	assert.Nil(t, err)
+	assert.Equal(t, []ReferenceUpdaterEntry{firstEntry.(*ReferenceEntry), featureEntry, mainEntry, lastEntry.(*ReferenceEntry)}, entries)
+
+	entries, _, err = GetReferenceUpdaterEntriesInRangeForRef(repo, firstEntry.GetID(), lastEntry.GetID(), "refs/heads/feature")
+	assert.Nil(t, err)
+	assert.Equal(t, []ReferenceUpdaterEntry{featureEntry}, entries)
+
+	refNames, err := GetReferencesUpdatedSince(repo, firstEntry.GetID())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main", "refs/heads/other"}, refNames)
+}
+
 func TestCommitUsingSpecificKey(t *testing.T) {
 	for _, objectFormat := range []gitinterface.ObjectFormat{gitinterface.ObjectFormatSHA1, gitinterface.ObjectFormatSHA256} {
 		t.Run(string(objectFormat), func(t *testing.T) {
@@ -776,6 +1011,66 @@ func TestGetLatestReferenceUpdaterEntry(t *testing.T) {
 		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
 	})
 
+	t.Run("with ref name, unskipped, and skip authorizer", func(t *testing.T) {
+		refName := "refs/heads/main"
+
+		tempDir := t.TempDir()
+		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+		entryIDs := []gitinterface.Hash{}
+		for i := 0; i < 2; i++ {
+			if err := NewReferenceEntry(refName, gitinterface.ZeroHash).Commit(repo, false); err != nil {
+				t.Fatal(err)
+			}
+
+			e, err := GetLatestEntry(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entryIDs = append(entryIDs, e.GetID())
+		}
+
+		// Skip the second one
+		if err := NewAnnotationEntry([]gitinterface.Hash{entryIDs[1]}, true, "revoke").Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// The authorizer does not honor the skip, so the latest entry is
+		// returned
+		authorizerCalled := false
+		rejectSkips := func(entry *ReferenceEntry, annotations []*AnnotationEntry) (bool, error) {
+			authorizerCalled = true
+			assert.Equal(t, entryIDs[1], entry.GetID())
+			assert.True(t, entry.SkippedBy(annotations))
+			return false, nil
+		}
+		entry, annotations, err := GetLatestReferenceUpdaterEntry(repo, ForReference(refName), IsUnskipped(), WithSkipAuthorizer(rejectSkips))
+		assert.Nil(t, err)
+		assert.True(t, authorizerCalled)
+		assert.Equal(t, entryIDs[1], entry.GetID())
+		assert.Len(t, annotations, 1)
+
+		// The authorizer honors the skip
+		acceptSkips := func(_ *ReferenceEntry, _ []*AnnotationEntry) (bool, error) {
+			return true, nil
+		}
+		entry, _, err = GetLatestReferenceUpdaterEntry(repo, ForReference(refName), IsUnskipped(), WithSkipAuthorizer(acceptSkips))
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[0], entry.GetID())
+
+		// Errors from the authorizer are returned
+		failSkips := func(_ *ReferenceEntry, _ []*AnnotationEntry) (bool, error) {
+			return false, ErrInvalidRSLEntry
+		}
+		_, _, err = GetLatestReferenceUpdaterEntry(repo, ForReference(refName), IsUnskipped(), WithSkipAuthorizer(failSkips))
+		assert.ErrorIs(t, err, ErrInvalidRSLEntry)
+
+		// The authorizer is only used for entries that are skipped
+		entry, _, err = GetLatestReferenceUpdaterEntry(repo, ForReference(refName), BeforeEntryID(entryIDs[1]), IsUnskipped(), WithSkipAuthorizer(failSkips))
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[0], entry.GetID())
+	})
+
 	t.Run("with unskipped and non gittuf option", func(t *testing.T) {
 		tempDir := t.TempDir()
 		repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
@@ -1855,6 +2150,58 @@ func TestGetReferenceUpdaterEntriesInRangeForRef(t *testing.T) {
 	assert.Equal(t, expectedAnnotationMap, annotationMap)
 }
 
+func TestGetReferencesUpdatedSince(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := gitinterface.CreateTestGitRepository(t, tempDir, false)
+
+	_, err := GetReferencesUpdatedSince(repo, gitinterface.ZeroHash)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+	if err := NewReferenceEntry("refs/heads/main", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReferenceEntry("refs/gittuf/policy", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	sinceEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewReferenceEntry("refs/heads/feature", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReferenceEntry("refs/tags/v1", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReferenceEntry("refs/heads/feature", gitinterface.ZeroHash).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := GetReferencesUpdatedSince(repo, gitinterface.ZeroHash)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main", "refs/tags/v1"}, refs)
+
+	refs, err = GetReferencesUpdatedSince(repo, sinceEntry.GetID())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"refs/heads/feature", "refs/tags/v1"}, refs)
+
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs, err = GetReferencesUpdatedSince(repo, latestEntry.GetID())
+	assert.Nil(t, err)
+	assert.Empty(t, refs)
+
+	unknownEntryID, err := gitinterface.NewHash("abcdef0123456789abcdef0123456789abcdef01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = GetReferencesUpdatedSince(repo, unknownEntryID)
+	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+}
+
 func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 	// Create upstreamRepo
 	upstreamRepoLocation := t.TempDir()
@@ -1870,7 +2217,7 @@ func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 		DownstreamPath:      "upstream",
 	}
 
-	err := PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, false)
+	err := PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, nil, false)
 	assert.Nil(t, err) // propagation has nothing to do because no RSL exists in upstream
 
 	// Add things to upstreamRepo
@@ -1904,7 +2251,7 @@ func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, false)
+	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, nil, false)
 	// TODO: should propagation result in a new local ref?
 	assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
 
@@ -1935,7 +2282,7 @@ func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, false)
+	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, nil, false)
 	assert.Nil(t, err)
 
 	latestEntry, err := GetLatestEntry(downstreamRepo)
@@ -1974,7 +2321,42 @@ func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 	assert.Equal(t, expectedRootTreeID, downstreamRootTreeID)
 
 	// Nothing to propagate, check that a new entry has not been added in the downstreamRepo
-	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, false)
+	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, nil, false)
+	assert.Nil(t, err)
+
+	latestEntry, err = GetLatestEntry(downstreamRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, propagationEntry.GetID(), latestEntry.GetID())
+
+	// Skip a new upstream entry, the skip is only honored if authorized
+	blobCID, err := upstreamRepo.WriteBlob([]byte("c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstreamSkippedTreeID, err := upstreamTreeBuilder.WriteTreeFromEntries([]gitinterface.TreeEntry{
+		gitinterface.NewEntryBlob("c", blobCID),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstreamCommitID, err = upstreamRepo.Commit(upstreamSkippedTreeID, "refs/heads/main", "Skipped commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewReferenceEntry("refs/heads/main", upstreamCommitID).Commit(upstreamRepo, false); err != nil {
+		t.Fatal(err)
+	}
+	upstreamSkippedEntry, err := GetLatestEntry(upstreamRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewAnnotationEntry([]gitinterface.Hash{upstreamSkippedEntry.GetID()}, true, "skip").Commit(upstreamRepo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, nil, false)
 	assert.Nil(t, err)
 
 	latestEntry, err = GetLatestEntry(downstreamRepo)
@@ -1982,6 +2364,22 @@ func TestPropagateChangesFromUpstreamRepository(t *testing.T) {
 		t.Fatal(err)
 	}
 	assert.Equal(t, propagationEntry.GetID(), latestEntry.GetID())
+
+	rejectSkips := func(_ *ReferenceEntry, _ []*AnnotationEntry) (bool, error) {
+		return false, nil
+	}
+	err = PropagateChangesFromUpstreamRepository(downstreamRepo, upstreamRepo, []tuf.PropagationDirective{propagationDetails}, rejectSkips, false)
+	assert.Nil(t, err)
+
+	latestEntry, err = GetLatestEntry(downstreamRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	propagationEntry, isPropagationEntry = latestEntry.(*PropagationEntry)
+	if !isPropagationEntry {
+		t.Fatal("unexpected entry type in downstream repo")
+	}
+	assert.Equal(t, upstreamSkippedEntry.GetID(), propagationEntry.UpstreamEntryID)
 }
 
 func TestAnnotationEntryRefersTo(t *testing.T) {
@@ -2033,6 +2431,34 @@ func TestAnnotationEntryRefersTo(t *testing.T) {
 	}
 }
 
+func TestAnnotationEntryGetData(t *testing.T) {
+	annotation := NewAnnotationEntry([]gitinterface.Hash{gitinterface.ZeroHash}, false, annotationMessage)
+	annotation.Data = map[string]json.RawMessage{
+		"incident": json.RawMessage(`"INC-1234"`),
+		"severity": json.RawMessage(`2`),
+	}
+
+	var incident string
+	has, err := annotation.GetData("incident", &incident)
+	assert.Nil(t, err)
+	assert.True(t, has)
+	assert.Equal(t, "INC-1234", incident)
+
+	var severity int
+	has, err = annotation.GetData("severity", &severity)
+	assert.Nil(t, err)
+	assert.True(t, has)
+	assert.Equal(t, 2, severity)
+
+	has, err = annotation.GetData("unknown", &incident)
+	assert.Nil(t, err)
+	assert.False(t, has)
+
+	has, err = annotation.GetData("incident", &severity)
+	assert.NotNil(t, err)
+	assert.True(t, has)
+}
+
 func TestReferenceEntryCreateCommitMessage(t *testing.T) {
 	nonZeroHash, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
 	if err != nil {
@@ -2148,6 +2574,19 @@ func TestAnnotationEntryCreateCommitMessage(t *testing.T) {
 			},
 			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", AnnotationEntryHeader, EntryIDKey, gitinterface.ZeroHash.String(), SkipKey, "true", NumberKey, uint64(math.MaxUint64)),
 		},
+		"annotation, with data and message": {
+			entry: &AnnotationEntry{
+				RSLEntryIDs: []gitinterface.Hash{gitinterface.ZeroHash},
+				Skip:        false,
+				Message:     "message",
+				Data: map[string]json.RawMessage{
+					"incident": json.RawMessage(`"INC-1234"`),
+					"approved": json.RawMessage(`true`),
+				},
+				Number: 1,
+			},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, gitinterface.ZeroHash.String(), SkipKey, "false", NumberKey, 1, DataKey, `{"approved":true,"incident":"INC-1234"}`, BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
 	}
 
 	for name, test := range tests {
@@ -2225,6 +2664,107 @@ func TestPropagationEntryCreateCommitMessage(t *testing.T) {
 	}
 }
 
+func TestHeartbeatEntryCreateCommitMessage(t *testing.T) {
+	timestamp := time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		entry           *HeartbeatEntry
+		expectedMessage string
+	}{
+		"entry, no number": {
+			entry:           &HeartbeatEntry{Timestamp: timestamp},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s", HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z"),
+		},
+		"entry, non-UTC timestamp": {
+			entry:           &HeartbeatEntry{Timestamp: timestamp.In(time.FixedZone("IST", 5*60*60+30*60))},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s", HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z"),
+		},
+		"entry, with number": {
+			entry:           &HeartbeatEntry{Timestamp: timestamp, Number: 1},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %d", HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z", NumberKey, 1),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, _ := test.entry.createCommitMessage(true)
+			if !assert.Equal(t, test.expectedMessage, message) {
+				t.Errorf("expected\n%s\n\ngot\n%s", test.expectedMessage, message)
+			}
+		})
+	}
+}
+
+func TestCheckpointEntryCreateCommitMessage(t *testing.T) {
+	policyEntryID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	require.NoError(t, err)
+	zero := gitinterface.ZeroHash.String()
+
+	tests := map[string]struct {
+		entry           *CheckpointEntry
+		expectedMessage string
+	}{
+		"entry, no refs": {
+			entry:           NewCheckpointEntry(policyEntryID, gitinterface.ZeroHash, nil),
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s", CheckpointEntryHeader, PolicyEntryIDKey, policyEntryID.String(), AttestationsEntryIDKey, zero),
+		},
+		"entry, refs are sorted": {
+			entry: NewCheckpointEntry(policyEntryID, gitinterface.ZeroHash, map[string]gitinterface.Hash{
+				"refs/tags/v1":    gitinterface.ZeroHash,
+				"refs/heads/main": policyEntryID,
+			}),
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s", CheckpointEntryHeader, PolicyEntryIDKey, policyEntryID.String(), AttestationsEntryIDKey, zero, RefKey, "refs/heads/main", EntryIDKey, policyEntryID.String(), RefKey, "refs/tags/v1", EntryIDKey, zero),
+		},
+		"entry, with number": {
+			entry:           &CheckpointEntry{PolicyEntryID: policyEntryID, AttestationsEntryID: gitinterface.ZeroHash, Number: 3},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", CheckpointEntryHeader, PolicyEntryIDKey, policyEntryID.String(), AttestationsEntryIDKey, zero, NumberKey, 3),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, err := test.entry.createCommitMessage(true)
+			require.NoError(t, err)
+			if !assert.Equal(t, test.expectedMessage, message) {
+				t.Errorf("expected\n%s\n\ngot\n%s", test.expectedMessage, message)
+			}
+		})
+	}
+}
+
+func TestMultiReferenceEntryCreateCommitMessage(t *testing.T) {
+	targetID, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	require.NoError(t, err)
+	zero := gitinterface.ZeroHash.String()
+
+	tests := map[string]struct {
+		entry           *MultiReferenceEntry
+		expectedMessage string
+	}{
+		"entry, refs are sorted": {
+			entry: NewMultiReferenceEntry(map[string]gitinterface.Hash{
+				"refs/tags/v1":    gitinterface.ZeroHash,
+				"refs/heads/main": targetID,
+			}),
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s", MultiReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, targetID.String(), RefKey, "refs/tags/v1", TargetIDKey, zero),
+		},
+		"entry, with number": {
+			entry:           &MultiReferenceEntry{TargetIDs: map[string]gitinterface.Hash{"refs/heads/main": targetID}, Number: 3},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", MultiReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, targetID.String(), NumberKey, 3),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, err := test.entry.createCommitMessage(true)
+			require.NoError(t, err)
+			if !assert.Equal(t, test.expectedMessage, message) {
+				t.Errorf("expected\n%s\n\ngot\n%s", test.expectedMessage, message)
+			}
+		})
+	}
+}
+
 func TestParseRSLEntryText(t *testing.T) {
 	nonZeroHash, err := gitinterface.NewHash("abcdef12345678900987654321fedcbaabcdef12")
 	if err != nil {
@@ -2357,6 +2897,20 @@ func TestParseRSLEntryText(t *testing.T) {
 			},
 			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d", AnnotationEntryHeader, EntryIDKey, gitinterface.ZeroHash.String(), SkipKey, "true", NumberKey, 7),
 		},
+		"annotation, with data": {
+			expectedEntry: &AnnotationEntry{
+				ID:          gitinterface.ZeroHash,
+				RSLEntryIDs: []gitinterface.Hash{gitinterface.ZeroHash},
+				Skip:        false,
+				Message:     "message",
+				Data: map[string]json.RawMessage{
+					"incident": json.RawMessage(`"INC-1234"`),
+					"details":  json.RawMessage(`{"severity":2}`),
+				},
+				Number: 7,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %d\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, gitinterface.ZeroHash.String(), SkipKey, "false", NumberKey, 7, DataKey, `{"details":{"severity":2},"incident":"INC-1234"}`, BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
 		"propagation entry, with number": {
 			expectedEntry: &PropagationEntry{
 				ID:                 gitinterface.ZeroHash,
@@ -2368,6 +2922,54 @@ func TestParseRSLEntryText(t *testing.T) {
 			},
 			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", PropagationEntryHeader, RefKey, "refs/heads/main", TargetIDKey, gitinterface.ZeroHash.String(), UpstreamRepositoryKey, upstreamRepository, UpstreamEntryIDKey, gitinterface.ZeroHash.String(), NumberKey, 3),
 		},
+		"heartbeat entry": {
+			expectedEntry: &HeartbeatEntry{
+				ID:        gitinterface.ZeroHash,
+				Timestamp: time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC),
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s", HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z"),
+		},
+		"heartbeat entry, with number": {
+			expectedEntry: &HeartbeatEntry{
+				ID:        gitinterface.ZeroHash,
+				Timestamp: time.Date(1995, time.October, 26, 9, 0, 0, 0, time.UTC),
+				Number:    5,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %d", HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z", NumberKey, 5),
+		},
+		"heartbeat entry, missing information": {
+			expectedError: ErrInvalidRSLEntry,
+			message:       fmt.Sprintf("%s\n\n%s: %d", HeartbeatEntryHeader, NumberKey, 5),
+		},
+		"checkpoint entry": {
+			expectedEntry: &CheckpointEntry{
+				ID:                  gitinterface.ZeroHash,
+				PolicyEntryID:       nonZeroHash,
+				AttestationsEntryID: gitinterface.ZeroHash,
+				RefEntryIDs:         map[string]gitinterface.Hash{"refs/heads/main": nonZeroHash},
+				Number:              7,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", CheckpointEntryHeader, PolicyEntryIDKey, nonZeroHash.String(), AttestationsEntryIDKey, gitinterface.ZeroHash.String(), RefKey, "refs/heads/main", EntryIDKey, nonZeroHash.String(), NumberKey, 7),
+		},
+		"checkpoint entry, missing information": {
+			expectedError: ErrInvalidRSLEntry,
+			message:       fmt.Sprintf("%s\n\n%s: %s", CheckpointEntryHeader, PolicyEntryIDKey, nonZeroHash.String()),
+		},
+		"multi-reference entry": {
+			expectedEntry: &MultiReferenceEntry{
+				ID: gitinterface.ZeroHash,
+				TargetIDs: map[string]gitinterface.Hash{
+					"refs/heads/main": nonZeroHash,
+					"refs/tags/v1":    gitinterface.ZeroHash,
+				},
+				Number: 4,
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %d", MultiReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, nonZeroHash.String(), RefKey, "refs/tags/v1", TargetIDKey, gitinterface.ZeroHash.String(), NumberKey, 4),
+		},
+		"multi-reference entry, missing information": {
+			expectedError: ErrInvalidRSLEntry,
+			message:       fmt.Sprintf("%s\n\n%s: %d", MultiReferenceEntryHeader, NumberKey, 4),
+		},
 	}
 
 	for name, test := range tests {
@@ -2425,12 +3027,40 @@ func TestParseRSLEntryTextRejectsMalformed(t *testing.T) {
 			AnnotationEntryHeader, SkipKey, "true"),
 		"annotation, invalid skip value": fmt.Sprintf("%s\n\n%s: %s\n%s: %s",
 			AnnotationEntryHeader, EntryIDKey, zero, SkipKey, "maybe"),
+		"annotation, data before skip": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s",
+			AnnotationEntryHeader, EntryIDKey, zero, DataKey, `{"key":"value"}`, SkipKey, "true"),
+		"annotation, number after data": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %d",
+			AnnotationEntryHeader, EntryIDKey, zero, SkipKey, "true", DataKey, `{"key":"value"}`, NumberKey, 1),
+		"annotation, data not an object": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s",
+			AnnotationEntryHeader, EntryIDKey, zero, SkipKey, "true", DataKey, `["value"]`),
 		"propagation, duplicate upstreamRepository": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
 			PropagationEntryHeader, RefKey, "refs/heads/main", TargetIDKey, zero, UpstreamRepositoryKey, upstream, UpstreamRepositoryKey, upstream, UpstreamEntryIDKey, zero),
 		"propagation, upstreamEntryID before upstreamRepository": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
 			PropagationEntryHeader, RefKey, "refs/heads/main", TargetIDKey, zero, UpstreamEntryIDKey, zero, UpstreamRepositoryKey, upstream),
 		"propagation, missing upstreamEntryID": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s",
 			PropagationEntryHeader, RefKey, "refs/heads/main", TargetIDKey, zero, UpstreamRepositoryKey, upstream),
+		"heartbeat, duplicate timestamp": fmt.Sprintf("%s\n\n%s: %s\n%s: %s",
+			HeartbeatEntryHeader, TimestampKey, "1995-10-26T09:00:00Z", TimestampKey, "1995-10-26T10:00:00Z"),
+		"heartbeat, invalid timestamp": fmt.Sprintf("%s\n\n%s: %s",
+			HeartbeatEntryHeader, TimestampKey, "yesterday"),
+		"heartbeat, number before timestamp": fmt.Sprintf("%s\n\n%s: %d\n%s: %s",
+			HeartbeatEntryHeader, NumberKey, 1, TimestampKey, "1995-10-26T09:00:00Z"),
+		"checkpoint, attestationsEntryID before policyEntryID": fmt.Sprintf("%s\n\n%s: %s\n%s: %s",
+			CheckpointEntryHeader, AttestationsEntryIDKey, zero, PolicyEntryIDKey, zero),
+		"checkpoint, ref missing entryID": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s",
+			CheckpointEntryHeader, PolicyEntryIDKey, zero, AttestationsEntryIDKey, zero, RefKey, "refs/heads/main"),
+		"checkpoint, duplicate ref": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
+			CheckpointEntryHeader, PolicyEntryIDKey, zero, AttestationsEntryIDKey, zero, RefKey, "refs/heads/main", EntryIDKey, zero, RefKey, "refs/heads/main", EntryIDKey, zero),
+		"multi-reference, ref missing targetID": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s",
+			MultiReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, zero, RefKey, "refs/tags/v1"),
+		"multi-reference, duplicate ref": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
+			MultiReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, zero, RefKey, "refs/heads/main", TargetIDKey, zero),
+		"multi-reference, targetID before ref": fmt.Sprintf("%s\n\n%s: %s\n%s: %s",
+			MultiReferenceEntryHeader, TargetIDKey, zero, RefKey, "refs/heads/main"),
+		"multi-reference, gittuf ref": fmt.Sprintf("%s\n\n%s: %s\n%s: %s",
+			MultiReferenceEntryHeader, RefKey, "refs/gittuf/policy", TargetIDKey, zero),
+		"checkpoint, invalid signatures": fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s\nbm90IGpzb24=\n-----END SIGNATURES-----",
+			CheckpointEntryHeader, PolicyEntryIDKey, zero, AttestationsEntryIDKey, zero, BeginSignatures),
 	}
 
 	for name, message := range tests {
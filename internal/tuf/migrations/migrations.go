@@ -82,6 +82,15 @@ func MigrateRootMetadataV01ToV02(rootMetadata *tufv01.RootMetadata) *tufv02.Root
 	// Set hooks
 	newRootMetadata.Hooks = rootMetadata.Hooks
 
+	// Set heartbeat requirement
+	newRootMetadata.Heartbeat = rootMetadata.Heartbeat
+
+	// Set skip authorizations
+	newRootMetadata.SkipAuthorizations = rootMetadata.SkipAuthorizations
+
+	// Set forge
+	newRootMetadata.Forge = rootMetadata.Forge
+
 	return newRootMetadata
 }
 
@@ -6,6 +6,7 @@ package tuf
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/pkg/gitinterface"
@@ -75,6 +76,13 @@ var (
 	ErrInvalidHookEnvironment                          = errors.New("invalid environment for hook")
 	ErrHookNotFound                                    = errors.New("cannot find hook entry")
 	ErrNoHooksDefined                                  = errors.New("no hooks defined")
+	ErrInvalidHeartbeatMaxStaleness                    = errors.New("maximum staleness for heartbeats must be at least one second")
+	ErrHeartbeatPrincipalsNotSpecified                 = errors.New("at least one principal must be trusted to create heartbeats")
+	ErrSkipAuthorizationAlreadyExists                  = errors.New("skip authorization already exists")
+	ErrSkipAuthorizationNotFound                       = errors.New("skip authorization not found")
+	ErrSkipAuthorizationReferencesNotSpecified         = errors.New("at least one reference pattern must be specified for skip authorization")
+	ErrForgePrincipalsNotSpecified                     = errors.New("at least one principal must be trusted as the forge")
+	ErrForgeReferencesNotSpecified                     = errors.New("at least one reference pattern must be delegated to the forge")
 )
 
 // Principal represents an entity that is granted trust by gittuf metadata. In
@@ -230,6 +238,39 @@ type RootMetadata interface {
 	RemoveHook(stages []HookStage, hookName string) error
 	// GetHooks returns all hooks in the metadata for the specified Git stage.
 	GetHooks(stage HookStage) ([]Hook, error)
+
+	// SetHeartbeat adds the principals to the root metadata and trusts them
+	// to create RSL heartbeat entries. The maximum age of the latest heartbeat
+	// before the RSL is considered stale is also set.
+	SetHeartbeat(principals []Principal, maxStaleness time.Duration) error
+	// RemoveHeartbeat removes the RSL heartbeat requirement from the root
+	// metadata.
+	RemoveHeartbeat()
+	// GetHeartbeat returns the RSL heartbeat requirement declared in the
+	// metadata. It returns nil if heartbeats are not required.
+	GetHeartbeat() Heartbeat
+
+	// AddSkipAuthorization adds the principals to the root metadata and
+	// trusts them to skip RSL entries for references matching the patterns.
+	// A threshold of the principals must create skip annotations for an
+	// entry to be skipped.
+	AddSkipAuthorization(name string, refPatterns []string, principals []Principal, threshold int) error
+	// RemoveSkipAuthorization removes the skip authorization identified by
+	// name from the root metadata.
+	RemoveSkipAuthorization(name string) error
+	// GetSkipAuthorizations returns the skip authorizations declared in the
+	// metadata.
+	GetSkipAuthorizations() []SkipAuthorization
+
+	// SetForge adds the principals to the root metadata and trusts them as
+	// the forge, which may create RSL entries on receipt of pushes for
+	// references matching the patterns.
+	SetForge(principals []Principal, refPatterns []string) error
+	// RemoveForge removes the forge declaration from the root metadata.
+	RemoveForge()
+	// GetForge returns the forge declared in the metadata. It returns nil if
+	// no forge is trusted.
+	GetForge() Forge
 }
 
 // TargetsMetadata represents gittuf's rule files. Its name is inspired by TUF.
@@ -554,6 +595,57 @@ type Hook interface {
 	GetTimeout() int
 }
 
+// Heartbeat represents the RSL heartbeat requirement declared in the gittuf
+// root of trust ('RootMetadata').
+type Heartbeat interface {
+	// GetPrincipalIDs returns the identifiers of the principals trusted to
+	// create heartbeat entries.
+	GetPrincipalIDs() []string
+
+	// GetMaxStaleness returns the maximum age of the latest heartbeat entry.
+	GetMaxStaleness() time.Duration
+}
+
+// SkipAuthorization restricts which principals may skip RSL entries for a set
+// of Git references using annotations. It is declared in the gittuf root of
+// trust ('RootMetadata').
+type SkipAuthorization interface {
+	// GetName returns the name of the skip authorization.
+	GetName() string
+
+	// GetProtectedNamespaces returns the reference patterns the skip
+	// authorization applies to.
+	GetProtectedNamespaces() []string
+
+	// GetPrincipalIDs returns the identifiers of the principals trusted to
+	// skip entries for the matching references.
+	GetPrincipalIDs() []string
+
+	// GetThreshold returns the number of trusted principals who must skip an
+	// entry.
+	GetThreshold() int
+
+	// Matches returns true if the skip authorization applies to the
+	// reference.
+	Matches(refName string) bool
+}
+
+// Forge represents a trusted forge that creates RSL entries on receipt of
+// pushes for the Git references delegated to it. It is declared in the gittuf
+// root of trust ('RootMetadata').
+type Forge interface {
+	// GetPrincipalIDs returns the identifiers of the principals trusted to
+	// create RSL entries as the forge.
+	GetPrincipalIDs() []string
+
+	// GetProtectedNamespaces returns the reference patterns delegated to the
+	// forge.
+	GetProtectedNamespaces() []string
+
+	// Matches returns true if the reference is delegated to the forge.
+	Matches(refName string) bool
+}
+
 type GitHubApp interface {
 	GetPrincipalIDs() []string
 	GetThreshold() int
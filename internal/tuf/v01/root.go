@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/danwakefield/fnmatch"
 	"github.com/gittuf/gittuf/internal/common/set"
@@ -31,6 +32,9 @@ type RootMetadata struct {
 	Propagations       []tuf.PropagationDirective `json:"propagations,omitempty"`
 	MultiRepository    *MultiRepository           `json:"multiRepository,omitempty"`
 	Hooks              map[tuf.HookStage][]*Hook  `json:"hooks,omitempty"`
+	Heartbeat          *Heartbeat                 `json:"heartbeat,omitempty"`
+	SkipAuthorizations []*SkipAuthorization       `json:"skipAuthorizations,omitempty"`
+	Forge              *Forge                     `json:"forge,omitempty"`
 }
 
 // NewRootMetadata returns a new instance of RootMetadata.
@@ -718,6 +722,9 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 		Propagations       []json.RawMessage         `json:"propagations,omitempty"`
 		MultiRepository    *MultiRepository          `json:"multiRepository,omitempty"`
 		Hooks              map[tuf.HookStage][]*Hook `json:"hooks,omitempty"`
+		Heartbeat          *Heartbeat                `json:"heartbeat,omitempty"`
+		SkipAuthorizations []*SkipAuthorization      `json:"skipAuthorizations,omitempty"`
+		Forge              *Forge                    `json:"forge,omitempty"`
 	}
 
 	temp := &tempType{}
@@ -775,6 +782,12 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 
 	r.Hooks = temp.Hooks
 
+	r.Heartbeat = temp.Heartbeat
+
+	r.SkipAuthorizations = temp.SkipAuthorizations
+
+	r.Forge = temp.Forge
+
 	return nil
 }
 
@@ -1111,6 +1124,244 @@ func (h *Hook) GetTimeout() int {
 	return h.Timeout
 }
 
+// SetHeartbeat adds the principals as trusted to create RSL heartbeat entries
+// and sets the maximum age of the latest heartbeat. Any principals previously
+// trusted for heartbeats are replaced.
+func (r *RootMetadata) SetHeartbeat(principals []tuf.Principal, maxStaleness time.Duration) error {
+	if len(principals) == 0 {
+		return tuf.ErrHeartbeatPrincipalsNotSpecified
+	}
+
+	if maxStaleness < time.Second {
+		return tuf.ErrInvalidHeartbeatMaxStaleness
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addKey(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	r.Heartbeat = &Heartbeat{
+		PrincipalIDs: principalIDs,
+		MaxStaleness: int(maxStaleness / time.Second),
+	}
+	return nil
+}
+
+// RemoveHeartbeat removes the RSL heartbeat requirement.
+func (r *RootMetadata) RemoveHeartbeat() {
+	r.Heartbeat = nil
+}
+
+// GetHeartbeat returns the RSL heartbeat requirement, if set.
+func (r *RootMetadata) GetHeartbeat() tuf.Heartbeat {
+	if r.Heartbeat == nil {
+		return nil
+	}
+
+	return r.Heartbeat
+}
+
+// Heartbeat defines the schema for the RSL heartbeat requirement.
+type Heartbeat struct {
+	PrincipalIDs *set.Set[string] `json:"principalIDs"`
+	MaxStaleness int              `json:"maxStaleness"` // in seconds
+}
+
+// GetPrincipalIDs returns the principals trusted to create heartbeat entries.
+func (h *Heartbeat) GetPrincipalIDs() []string {
+	return h.PrincipalIDs.Contents()
+}
+
+// GetMaxStaleness returns the maximum age of the latest heartbeat entry.
+func (h *Heartbeat) GetMaxStaleness() time.Duration {
+	return time.Duration(h.MaxStaleness) * time.Second
+}
+
+// AddSkipAuthorization adds the principals as trusted to skip RSL entries for
+// references matching the patterns. A threshold of the principals must skip an
+// entry for the skip to be honored.
+func (r *RootMetadata) AddSkipAuthorization(name string, refPatterns []string, principals []tuf.Principal, threshold int) error {
+	for _, skipAuthorization := range r.SkipAuthorizations {
+		if skipAuthorization.Name == name {
+			return tuf.ErrSkipAuthorizationAlreadyExists
+		}
+	}
+
+	if len(refPatterns) == 0 {
+		return tuf.ErrSkipAuthorizationReferencesNotSpecified
+	}
+
+	if threshold < 1 {
+		return tuf.ErrInvalidThreshold
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addKey(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	if principalIDs.Len() < threshold {
+		return tuf.ErrCannotMeetThreshold
+	}
+
+	r.SkipAuthorizations = append(r.SkipAuthorizations, &SkipAuthorization{
+		Name:         name,
+		References:   refPatterns,
+		PrincipalIDs: principalIDs,
+		Threshold:    threshold,
+	})
+	return nil
+}
+
+// RemoveSkipAuthorization removes the skip authorization identified by name.
+func (r *RootMetadata) RemoveSkipAuthorization(name string) error {
+	for index, skipAuthorization := range r.SkipAuthorizations {
+		if skipAuthorization.Name == name {
+			r.SkipAuthorizations = append(r.SkipAuthorizations[:index], r.SkipAuthorizations[index+1:]...)
+			return nil
+		}
+	}
+
+	return tuf.ErrSkipAuthorizationNotFound
+}
+
+// GetSkipAuthorizations returns the skip authorizations in the metadata.
+func (r *RootMetadata) GetSkipAuthorizations() []tuf.SkipAuthorization {
+	skipAuthorizations := make([]tuf.SkipAuthorization, 0, len(r.SkipAuthorizations))
+	for _, skipAuthorization := range r.SkipAuthorizations {
+		skipAuthorizations = append(skipAuthorizations, skipAuthorization)
+	}
+
+	return skipAuthorizations
+}
+
+// SkipAuthorization defines the schema for authorizing principals to skip RSL
+// entries.
+type SkipAuthorization struct {
+	Name         string           `json:"name"`
+	References   []string         `json:"references"`
+	PrincipalIDs *set.Set[string] `json:"principalIDs"`
+	Threshold    int              `json:"threshold"`
+}
+
+// GetName returns the name of the skip authorization.
+func (s *SkipAuthorization) GetName() string {
+	return s.Name
+}
+
+// GetProtectedNamespaces returns the reference patterns the skip authorization
+// applies to.
+func (s *SkipAuthorization) GetProtectedNamespaces() []string {
+	return s.References
+}
+
+// GetPrincipalIDs returns the principals trusted to skip entries.
+func (s *SkipAuthorization) GetPrincipalIDs() []string {
+	return s.PrincipalIDs.Contents()
+}
+
+// GetThreshold returns the number of principals who must skip an entry.
+func (s *SkipAuthorization) GetThreshold() int {
+	return s.Threshold
+}
+
+// Matches returns true if the reference matches any of the patterns.
+func (s *SkipAuthorization) Matches(refName string) bool {
+	for _, pattern := range s.References {
+		if matches := fnmatch.Match(pattern, refName, 0); matches {
+			return true
+		}
+	}
+	return false
+}
+
+// SetForge adds the principals as trusted to create RSL entries as the forge
+// for references matching the patterns. Any forge previously trusted is
+// replaced.
+func (r *RootMetadata) SetForge(principals []tuf.Principal, refPatterns []string) error {
+	if len(principals) == 0 {
+		return tuf.ErrForgePrincipalsNotSpecified
+	}
+
+	if len(refPatterns) == 0 {
+		return tuf.ErrForgeReferencesNotSpecified
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addKey(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	r.Forge = &Forge{
+		References:   refPatterns,
+		PrincipalIDs: principalIDs,
+	}
+	return nil
+}
+
+// RemoveForge removes the forge declaration.
+func (r *RootMetadata) RemoveForge() {
+	r.Forge = nil
+}
+
+// GetForge returns the trusted forge, if set.
+func (r *RootMetadata) GetForge() tuf.Forge {
+	if r.Forge == nil {
+		return nil
+	}
+
+	return r.Forge
+}
+
+// Forge defines the schema for trusting a forge to create RSL entries.
+type Forge struct {
+	References   []string         `json:"references"`
+	PrincipalIDs *set.Set[string] `json:"principalIDs"`
+}
+
+// GetPrincipalIDs returns the principals trusted as the forge.
+func (f *Forge) GetPrincipalIDs() []string {
+	return f.PrincipalIDs.Contents()
+}
+
+// GetProtectedNamespaces returns the reference patterns delegated to the
+// forge.
+func (f *Forge) GetProtectedNamespaces() []string {
+	return f.References
+}
+
+// Matches returns true if the reference matches any of the patterns.
+func (f *Forge) Matches(refName string) bool {
+	for _, pattern := range f.References {
+		if matches := fnmatch.Match(pattern, refName, 0); matches {
+			return true
+		}
+	}
+	return false
+}
+
 type GitHubApp struct {
 	Trusted      bool             `json:"trusted"`
 	PrincipalIDs *set.Set[string] `json:"principalIDs"`
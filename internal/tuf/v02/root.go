@@ -6,6 +6,7 @@ package v02
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/tuf"
@@ -30,6 +31,9 @@ type RootMetadata struct {
 	Propagations       []tuf.PropagationDirective `json:"propagations,omitempty"`
 	MultiRepository    *MultiRepository           `json:"multiRepository,omitempty"`
 	Hooks              map[tuf.HookStage][]*Hook  `json:"hooks,omitempty"`
+	Heartbeat          *Heartbeat                 `json:"heartbeat,omitempty"`
+	SkipAuthorizations []*SkipAuthorization       `json:"skipAuthorizations,omitempty"`
+	Forge              *Forge                     `json:"forge,omitempty"`
 }
 
 // NewRootMetadata returns a new instance of RootMetadata.
@@ -375,6 +379,9 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 		Propagations       []json.RawMessage          `json:"propagations,omitempty"`
 		MultiRepository    *MultiRepository           `json:"multiRepository,omitempty"`
 		Hooks              map[tuf.HookStage][]*Hook  `json:"hooks,omitempty"`
+		Heartbeat          *Heartbeat                 `json:"heartbeat,omitempty"`
+		SkipAuthorizations []*SkipAuthorization       `json:"skipAuthorizations,omitempty"`
+		Forge              *Forge                     `json:"forge,omitempty"`
 	}
 
 	temp := &tempType{}
@@ -466,6 +473,12 @@ func (r *RootMetadata) UnmarshalJSON(data []byte) error {
 
 	r.Hooks = temp.Hooks
 
+	r.Heartbeat = temp.Heartbeat
+
+	r.SkipAuthorizations = temp.SkipAuthorizations
+
+	r.Forge = temp.Forge
+
 	return nil
 }
 
@@ -1067,4 +1080,165 @@ func (r *RootMetadata) GetHooks(stage tuf.HookStage) ([]tuf.Hook, error) {
 	return hooks, nil
 }
 
+// SetHeartbeat adds the principals as trusted to create RSL heartbeat entries
+// and sets the maximum age of the latest heartbeat. Any principals previously
+// trusted for heartbeats are replaced.
+func (r *RootMetadata) SetHeartbeat(principals []tuf.Principal, maxStaleness time.Duration) error {
+	if len(principals) == 0 {
+		return tuf.ErrHeartbeatPrincipalsNotSpecified
+	}
+
+	if maxStaleness < time.Second {
+		return tuf.ErrInvalidHeartbeatMaxStaleness
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addPrincipal(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	r.Heartbeat = &Heartbeat{
+		PrincipalIDs: principalIDs,
+		MaxStaleness: int(maxStaleness / time.Second),
+	}
+	return nil
+}
+
+// RemoveHeartbeat removes the RSL heartbeat requirement.
+func (r *RootMetadata) RemoveHeartbeat() {
+	r.Heartbeat = nil
+}
+
+// GetHeartbeat returns the RSL heartbeat requirement, if set.
+func (r *RootMetadata) GetHeartbeat() tuf.Heartbeat {
+	if r.Heartbeat == nil {
+		return nil
+	}
+
+	return r.Heartbeat
+}
+
+// AddSkipAuthorization adds the principals as trusted to skip RSL entries for
+// references matching the patterns. A threshold of the principals must skip an
+// entry for the skip to be honored.
+func (r *RootMetadata) AddSkipAuthorization(name string, refPatterns []string, principals []tuf.Principal, threshold int) error {
+	for _, skipAuthorization := range r.SkipAuthorizations {
+		if skipAuthorization.Name == name {
+			return tuf.ErrSkipAuthorizationAlreadyExists
+		}
+	}
+
+	if len(refPatterns) == 0 {
+		return tuf.ErrSkipAuthorizationReferencesNotSpecified
+	}
+
+	if threshold < 1 {
+		return tuf.ErrInvalidThreshold
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addPrincipal(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	if principalIDs.Len() < threshold {
+		return tuf.ErrCannotMeetThreshold
+	}
+
+	r.SkipAuthorizations = append(r.SkipAuthorizations, &SkipAuthorization{
+		Name:         name,
+		References:   refPatterns,
+		PrincipalIDs: principalIDs,
+		Threshold:    threshold,
+	})
+	return nil
+}
+
+// RemoveSkipAuthorization removes the skip authorization identified by name.
+func (r *RootMetadata) RemoveSkipAuthorization(name string) error {
+	for index, skipAuthorization := range r.SkipAuthorizations {
+		if skipAuthorization.Name == name {
+			r.SkipAuthorizations = append(r.SkipAuthorizations[:index], r.SkipAuthorizations[index+1:]...)
+			return nil
+		}
+	}
+
+	return tuf.ErrSkipAuthorizationNotFound
+}
+
+// GetSkipAuthorizations returns the skip authorizations in the metadata.
+func (r *RootMetadata) GetSkipAuthorizations() []tuf.SkipAuthorization {
+	skipAuthorizations := make([]tuf.SkipAuthorization, 0, len(r.SkipAuthorizations))
+	for _, skipAuthorization := range r.SkipAuthorizations {
+		skipAuthorizations = append(skipAuthorizations, skipAuthorization)
+	}
+
+	return skipAuthorizations
+}
+
+// SetForge adds the principals as trusted to create RSL entries as the forge
+// for references matching the patterns. Any forge previously trusted is
+// replaced.
+func (r *RootMetadata) SetForge(principals []tuf.Principal, refPatterns []string) error {
+	if len(principals) == 0 {
+		return tuf.ErrForgePrincipalsNotSpecified
+	}
+
+	if len(refPatterns) == 0 {
+		return tuf.ErrForgeReferencesNotSpecified
+	}
+
+	principalIDs := set.NewSet[string]()
+	for _, principal := range principals {
+		if principal == nil {
+			return tuf.ErrInvalidPrincipalType
+		}
+
+		if err := r.addPrincipal(principal); err != nil {
+			return err
+		}
+		principalIDs.Add(principal.ID())
+	}
+
+	r.Forge = &Forge{
+		References:   refPatterns,
+		PrincipalIDs: principalIDs,
+	}
+	return nil
+}
+
+// RemoveForge removes the forge declaration.
+func (r *RootMetadata) RemoveForge() {
+	r.Forge = nil
+}
+
+// GetForge returns the trusted forge, if set.
+func (r *RootMetadata) GetForge() tuf.Forge {
+	if r.Forge == nil {
+		return nil
+	}
+
+	return r.Forge
+}
+
+type Heartbeat = tufv01.Heartbeat
+
+type SkipAuthorization = tufv01.SkipAuthorization
+
+type Forge = tufv01.Forge
+
 type GitHubApp = tufv01.GitHubApp
@@ -1088,3 +1088,118 @@ func TestRemoveHook(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(rootMetadata.Hooks[tuf.HookStagePrePush]))
 }
+
+func TestHeartbeat(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	assert.Nil(t, rootMetadata.GetHeartbeat())
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	err := rootMetadata.SetHeartbeat(nil, time.Hour)
+	assert.ErrorIs(t, err, tuf.ErrHeartbeatPrincipalsNotSpecified)
+
+	err = rootMetadata.SetHeartbeat([]tuf.Principal{key}, time.Millisecond)
+	assert.ErrorIs(t, err, tuf.ErrInvalidHeartbeatMaxStaleness)
+
+	err = rootMetadata.SetHeartbeat([]tuf.Principal{key}, time.Hour)
+	require.Nil(t, err)
+	assert.Contains(t, rootMetadata.GetPrincipals(), key.KeyID)
+
+	heartbeat := rootMetadata.GetHeartbeat()
+	require.NotNil(t, heartbeat)
+	assert.Equal(t, []string{key.KeyID}, heartbeat.GetPrincipalIDs())
+	assert.Equal(t, time.Hour, heartbeat.GetMaxStaleness())
+
+	rootMetadataBytes, err := json.Marshal(rootMetadata)
+	require.Nil(t, err)
+
+	unmarshalledRootMetadata := &RootMetadata{}
+	err = json.Unmarshal(rootMetadataBytes, unmarshalledRootMetadata)
+	require.Nil(t, err)
+	assert.Equal(t, rootMetadata.Heartbeat, unmarshalledRootMetadata.Heartbeat)
+
+	rootMetadata.RemoveHeartbeat()
+	assert.Nil(t, rootMetadata.GetHeartbeat())
+}
+
+func TestSkipAuthorizations(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	assert.Empty(t, rootMetadata.GetSkipAuthorizations())
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	err := rootMetadata.AddSkipAuthorization("protect-main", nil, []tuf.Principal{key}, 1)
+	assert.ErrorIs(t, err, tuf.ErrSkipAuthorizationReferencesNotSpecified)
+
+	err = rootMetadata.AddSkipAuthorization("protect-main", []string{"refs/heads/main"}, []tuf.Principal{key}, 0)
+	assert.ErrorIs(t, err, tuf.ErrInvalidThreshold)
+
+	err = rootMetadata.AddSkipAuthorization("protect-main", []string{"refs/heads/main"}, []tuf.Principal{key}, 2)
+	assert.ErrorIs(t, err, tuf.ErrCannotMeetThreshold)
+
+	err = rootMetadata.AddSkipAuthorization("protect-main", []string{"refs/heads/main"}, []tuf.Principal{key}, 1)
+	require.Nil(t, err)
+	assert.Contains(t, rootMetadata.GetPrincipals(), key.KeyID)
+
+	err = rootMetadata.AddSkipAuthorization("protect-main", []string{"refs/heads/main"}, []tuf.Principal{key}, 1)
+	assert.ErrorIs(t, err, tuf.ErrSkipAuthorizationAlreadyExists)
+
+	skipAuthorizations := rootMetadata.GetSkipAuthorizations()
+	require.Len(t, skipAuthorizations, 1)
+	assert.Equal(t, "protect-main", skipAuthorizations[0].GetName())
+	assert.Equal(t, []string{"refs/heads/main"}, skipAuthorizations[0].GetProtectedNamespaces())
+	assert.Equal(t, []string{key.KeyID}, skipAuthorizations[0].GetPrincipalIDs())
+	assert.Equal(t, 1, skipAuthorizations[0].GetThreshold())
+	assert.True(t, skipAuthorizations[0].Matches("refs/heads/main"))
+	assert.False(t, skipAuthorizations[0].Matches("refs/heads/feature"))
+
+	rootMetadataBytes, err := json.Marshal(rootMetadata)
+	require.Nil(t, err)
+
+	unmarshalledRootMetadata := &RootMetadata{}
+	err = json.Unmarshal(rootMetadataBytes, unmarshalledRootMetadata)
+	require.Nil(t, err)
+	assert.Equal(t, rootMetadata.SkipAuthorizations, unmarshalledRootMetadata.SkipAuthorizations)
+
+	err = rootMetadata.RemoveSkipAuthorization("protect-feature")
+	assert.ErrorIs(t, err, tuf.ErrSkipAuthorizationNotFound)
+
+	err = rootMetadata.RemoveSkipAuthorization("protect-main")
+	assert.Nil(t, err)
+	assert.Empty(t, rootMetadata.GetSkipAuthorizations())
+}
+
+func TestForge(t *testing.T) {
+	rootMetadata := initialTestRootMetadata(t)
+	assert.Nil(t, rootMetadata.GetForge())
+
+	key := NewKeyFromSSLibKey(ssh.NewKeyFromBytes(t, targets1PubKeyBytes))
+
+	err := rootMetadata.SetForge(nil, []string{"refs/heads/*"})
+	assert.ErrorIs(t, err, tuf.ErrForgePrincipalsNotSpecified)
+
+	err = rootMetadata.SetForge([]tuf.Principal{key}, nil)
+	assert.ErrorIs(t, err, tuf.ErrForgeReferencesNotSpecified)
+
+	err = rootMetadata.SetForge([]tuf.Principal{key}, []string{"refs/heads/*"})
+	require.Nil(t, err)
+	assert.Contains(t, rootMetadata.GetPrincipals(), key.KeyID)
+
+	forge := rootMetadata.GetForge()
+	require.NotNil(t, forge)
+	assert.Equal(t, []string{key.KeyID}, forge.GetPrincipalIDs())
+	assert.Equal(t, []string{"refs/heads/*"}, forge.GetProtectedNamespaces())
+	assert.True(t, forge.Matches("refs/heads/main"))
+	assert.False(t, forge.Matches("refs/tags/v1"))
+
+	rootMetadataBytes, err := json.Marshal(rootMetadata)
+	require.Nil(t, err)
+
+	unmarshalledRootMetadata := &RootMetadata{}
+	err = json.Unmarshal(rootMetadataBytes, unmarshalledRootMetadata)
+	require.Nil(t, err)
+	assert.Equal(t, rootMetadata.Forge, unmarshalledRootMetadata.Forge)
+
+	rootMetadata.RemoveForge()
+	assert.Nil(t, rootMetadata.GetForge())
+}
@@ -4,17 +4,17 @@
 package gitinterface
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os/exec"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
-	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
-	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/go-git/go-git/v6/storage/memory"
@@ -167,14 +167,9 @@ func (r *Repository) commitWithParents(t *testing.T, treeID Hash, parentIDs []Ha
 // verifyCommitSignature verifies a signature for the specified commit using
 // the provided public key.
 func (r *Repository) verifyCommitSignature(ctx context.Context, commitID Hash, key *signerverifier.SSLibKey) error {
-	goGitRepo, err := r.GetGoGitRepository()
-	if err != nil {
-		return fmt.Errorf("error opening repository: %w", err)
-	}
-
-	commit, err := goGitRepo.CommitObject(plumbing.NewHash(commitID.String()))
+	commit, err := r.loadGoGitCommit(commitID)
 	if err != nil {
-		return fmt.Errorf("unable to load commit object: %w", err)
+		return err
 	}
 
 	commitContents, err := getCommitBytesWithoutSignature(commit)
@@ -184,36 +179,58 @@ func (r *Repository) verifyCommitSignature(ctx context.Context, commitID Hash, k
 
 	commitSignature := signatureForObjectID(commitID, commit.Signature, commit.SignatureSHA256)
 
-	if signatureBlockCount(commitSignature) > 1 {
-		return errors.Join(ErrIncorrectVerificationKey, ErrMultipleSignatures)
+	return r.verifyContentsSignature(ctx, commitContents, commitSignature, key)
+}
+
+// IsCommitSigned returns true if the commit has a signature. The signature is
+// not verified.
+func (r *Repository) IsCommitSigned(commitID Hash) (bool, error) {
+	commit, err := r.loadGoGitCommit(commitID)
+	if err != nil {
+		return false, err
 	}
 
-	switch key.KeyType {
-	case gpg.KeyType:
-		verifier, err := gpg.NewVerifierFromKey(key)
-		if err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
-		if err := verifier.Verify(ctx, commitContents, []byte(commitSignature)); err != nil {
-			return ErrIncorrectVerificationKey
-		}
+	return signatureForObjectID(commitID, commit.Signature, commit.SignatureSHA256) != "", nil
+}
 
-		return nil
-	case ssh.KeyType:
-		if err := verifySSHKeySignature(ctx, key, commitContents, []byte(commitSignature)); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
+// ReadCommitObject returns the raw contents of the commit object referenced by
+// commitID, including its signature, if any.
+func (r *Repository) ReadCommitObject(commitID Hash) ([]byte, error) {
+	if err := r.ensureIsCommit(commitID); err != nil {
+		return nil, err
+	}
 
-		return nil
-	case sigstore.KeyType:
-		if err := verifyGitsignSignature(ctx, r, key, commitContents, []byte(commitSignature)); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
+	obj, err := r.readGoGitObject(commitID, plumbing.CommitObject)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil
+	reader, err := obj.Reader()
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close() //nolint:errcheck
 
-	return ErrUnknownSigningMethod
+	return io.ReadAll(reader)
+}
+
+// WriteCommitObject creates a commit object with the specified raw contents,
+// such as those returned by ReadCommitObject for another repository, and
+// returns the ID of the resultant commit. The commit's parents and tree need
+// not exist in the repository.
+func (r *Repository) WriteCommitObject(contents []byte) (Hash, error) {
+	stdInBuf := bytes.NewBuffer(contents)
+	objID, err := r.executor("hash-object", "-t", "commit", "-w", "--stdin").withStdIn(stdInBuf).executeString()
+	if err != nil {
+		return ZeroHash, fmt.Errorf("unable to write commit: %w", err)
+	}
+
+	hash, err := NewHash(objID)
+	if err != nil {
+		return ZeroHash, fmt.Errorf("invalid Git ID for commit: %w", err)
+	}
+
+	return hash, nil
 }
 
 // GetCommitMessage returns the commit's message.
@@ -222,12 +239,33 @@ func (r *Repository) GetCommitMessage(commitID Hash) (string, error) {
 		return "", err
 	}
 
-	commitMessage, err := r.executor("show", "-s", "--format=%B", commitID.String()).executeString()
+	// The commit is read directly so that its message can be identified
+	// even if its parents are not in the repository
+	commit, err := r.loadGoGitCommit(commitID)
 	if err != nil {
 		return "", fmt.Errorf("unable to identify message for commit '%s': %w", commitID.String(), err)
 	}
 
-	return commitMessage, nil
+	return strings.TrimSpace(commit.Message), nil
+}
+
+// GetCommitTime returns the commit's committer timestamp.
+func (r *Repository) GetCommitTime(commitID Hash) (time.Time, error) {
+	if err := r.ensureIsCommit(commitID); err != nil {
+		return time.Time{}, err
+	}
+
+	stdOut, err := r.executor("show", "-s", "--format=%ct", commitID.String()).executeString()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to identify time for commit '%s': %w", commitID.String(), err)
+	}
+
+	timestamp, err := strconv.ParseInt(stdOut, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time for commit '%s': %w", commitID.String(), err)
+	}
+
+	return time.Unix(timestamp, 0).UTC(), nil
 }
 
 // GetCommitTreeID returns the commit's Git tree ID.
@@ -300,8 +338,11 @@ func (r *Repository) KnowsCommit(testCommitID, ancestorCommitID Hash) (bool, err
 	return err == nil, nil
 }
 
+// ErrNoCommonAncestor is returned when the supplied commits share no history.
+var ErrNoCommonAncestor = errors.New("commits do not share a common ancestor")
+
 // GetCommonAncestor finds the common ancestor commit for the two supplied
-// commits.
+// commits. If the commits share no history, ErrNoCommonAncestor is returned.
 func (r *Repository) GetCommonAncestor(commitAID, commitBID Hash) (Hash, error) {
 	if err := r.ensureIsCommit(commitAID); err != nil {
 		return nil, err
@@ -312,6 +353,12 @@ func (r *Repository) GetCommonAncestor(commitAID, commitBID Hash) (Hash, error)
 
 	mergeBase, err := r.executor("merge-base", commitAID.String(), commitBID.String()).executeString()
 	if err != nil {
+		// git merge-base exits with status 1 and no output when there's no
+		// merge base, other failures exit with a different status
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, ErrNoCommonAncestor
+		}
 		return nil, err
 	}
 
@@ -335,6 +382,22 @@ func (r *Repository) ensureIsCommit(commitID Hash) error {
 	return nil
 }
 
+// loadGoGitCommit returns the go-git representation of the specified commit,
+// read using Git.
+func (r *Repository) loadGoGitCommit(commitID Hash) (*object.Commit, error) {
+	obj, err := r.readGoGitObject(commitID, plumbing.CommitObject)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load commit object: %w", err)
+	}
+
+	commit := &object.Commit{}
+	if err := commit.Decode(obj); err != nil {
+		return nil, fmt.Errorf("unable to load commit object: %w", err)
+	}
+
+	return commit, nil
+}
+
 func getCommitBytesWithoutSignature(commit *object.Commit) ([]byte, error) {
 	commitEncoded := memory.NewStorage().NewEncodedObject()
 	if err := commit.EncodeWithoutSignature(commitEncoded); err != nil {
@@ -680,6 +680,73 @@ oYBpMWLgg6AUzpxx9mITZ2EKr4c=
 	return commitHash
 }
 
+func TestIsCommitSigned(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	emptyTreeID, err := repo.EmptyTree()
+	require.Nil(t, err)
+
+	unsignedCommitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Unsigned commit\n", false)
+	require.Nil(t, err)
+
+	signed, err := repo.IsCommitSigned(unsignedCommitID)
+	assert.Nil(t, err)
+	assert.False(t, signed)
+
+	signedCommitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Signed commit\n", true)
+	require.Nil(t, err)
+
+	signed, err = repo.IsCommitSigned(signedCommitID)
+	assert.Nil(t, err)
+	assert.True(t, signed)
+
+	_, err = repo.IsCommitSigned(emptyTreeID)
+	assert.NotNil(t, err)
+}
+
+func TestReadAndWriteCommitObject(t *testing.T) {
+	for _, objectFormat := range []ObjectFormat{ObjectFormatSHA1, ObjectFormatSHA256} {
+		t.Run(string(objectFormat), func(t *testing.T) {
+			repo := CreateTestGitRepository(t, t.TempDir(), false, WithObjectFormat(objectFormat))
+
+			emptyTreeID, err := repo.EmptyTree()
+			require.Nil(t, err)
+
+			firstCommitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+			require.Nil(t, err)
+			signedCommitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Signed commit\n", true)
+			require.Nil(t, err)
+
+			contents, err := repo.ReadCommitObject(signedCommitID)
+			require.Nil(t, err)
+			assert.Contains(t, string(contents), firstCommitID.String())
+
+			// The commit can be written to a repository that doesn't have its
+			// parent
+			otherRepo := CreateTestGitRepository(t, t.TempDir(), false, WithObjectFormat(objectFormat))
+			commitID, err := otherRepo.WriteCommitObject(contents)
+			require.Nil(t, err)
+			assert.Equal(t, signedCommitID, commitID)
+			assert.False(t, otherRepo.HasObject(firstCommitID))
+
+			message, err := otherRepo.GetCommitMessage(commitID)
+			require.Nil(t, err)
+			assert.Equal(t, "Signed commit", message)
+
+			signed, err := otherRepo.IsCommitSigned(commitID)
+			require.Nil(t, err)
+			assert.True(t, signed)
+
+			_, err = repo.ReadCommitObject(emptyTreeID)
+			assert.ErrorContains(t, err, "is not a commit object")
+
+			_, err = otherRepo.WriteCommitObject([]byte("invalid"))
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestRepositoryGetCommitMessage(t *testing.T) {
 	tempDir := t.TempDir()
 	repo := CreateTestGitRepository(t, tempDir, false)
@@ -712,6 +779,28 @@ func TestRepositoryGetCommitMessage(t *testing.T) {
 	})
 }
 
+func TestGetCommitTime(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	emptyTreeID, err := repo.EmptyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitTime, err := repo.GetCommitTime(commitID)
+	assert.Nil(t, err)
+	assert.True(t, testClock.Now().Equal(commitTime))
+
+	_, err = repo.GetCommitTime(emptyTreeID)
+	assert.NotNil(t, err)
+}
+
 func TestGetCommitTreeID(t *testing.T) {
 	tempDir := t.TempDir()
 	repo := CreateTestGitRepository(t, tempDir, false)
@@ -847,7 +936,7 @@ func TestGetCommonAncestor(t *testing.T) {
 	commitDisconnected := repo.commitWithParents(t, emptyTreeID, nil, "Disconnected initial commit\n", false)
 
 	_, err = repo.GetCommonAncestor(commitDisconnected, commitA)
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrNoCommonAncestor)
 
 	t.Run("non-commit object as first arg", func(t *testing.T) {
 		blobID, err := repo.WriteBlob([]byte("test"))
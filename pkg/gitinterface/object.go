@@ -6,7 +6,11 @@ package gitinterface
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+
+	"github.com/go-git/go-git/v6/plumbing"
+	formatcfg "github.com/go-git/go-git/v6/plumbing/format/config"
 )
 
 type ObjectType uint
@@ -60,3 +64,26 @@ func (r *Repository) GetObjectSize(objectID Hash) (uint64, error) {
 	}
 	return objSize, nil
 }
+
+// readGoGitObject returns the go-git representation of the object with the
+// specified Git ID and type. The object is read using Git rather than go-git's
+// storage so that objects that are only available to Git, such as those in the
+// quarantine environment of a pre-receive hook, can also be read.
+func (r *Repository) readGoGitObject(objectID Hash, objType plumbing.ObjectType) (plumbing.EncodedObject, error) {
+	stdOut, stdErr, err := r.executor("cat-file", objType.String(), objectID.String()).execute()
+	if err != nil {
+		stdErrContents, newErr := io.ReadAll(stdErr)
+		if newErr != nil {
+			return nil, fmt.Errorf("unable to read stderr contents: %w; original err: %w", newErr, err)
+		}
+		return nil, fmt.Errorf("unable to read %s object: %w: %s", objType.String(), err, string(stdErrContents))
+	}
+
+	obj := plumbing.NewMemoryObject(plumbing.FromObjectFormat(formatcfg.ObjectFormat(r.objectFormat)))
+	obj.SetType(objType)
+	if _, err := io.Copy(obj, stdOut); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// quarantinePathKey is set by Git for hooks such as pre-receive that run
+// before the objects received in a push are moved into the repository. Git
+// refuses to update references while it is set.
+const quarantinePathKey = "GIT_QUARANTINE_PATH"
+
+// CreateOverlayRepository creates a bare repository at dir that uses the
+// objects of r and has the specified references. As the references of the
+// overlay repository are independent of r's, the overlay can be used to
+// inspect r as it would be with some references updated, without updating
+// them. For example, the overlay can be used to verify the references received
+// in a push before Git updates them, including from a pre-receive hook where
+// the received objects are only available in the quarantine environment. Note
+// that objects written to the overlay are not available in r.
+func (r *Repository) CreateOverlayRepository(dir string, refs map[string]Hash) (*Repository, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	commonDir, err := r.executor("rev-parse", "--path-format=absolute", "--git-common-dir").executeString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify objects directory for repository: %w", err)
+	}
+
+	overlay := &Repository{objectFormat: r.objectFormat, clock: r.clock}
+	if _, err := overlay.executor("init", "--bare", "--quiet", "--object-format", string(r.objectFormat), dir).withoutGitDir().executeString(); err != nil {
+		return nil, fmt.Errorf("unable to create overlay repository: %w", err)
+	}
+	overlay.gitDirPath = dir
+
+	// Git doesn't create the objects directory when initializing the overlay
+	// if the object directory is set in the environment, as is the case in
+	// the quarantine environment
+	alternatesPath := filepath.Join(dir, "objects", "info", "alternates")
+	if err := os.MkdirAll(filepath.Dir(alternatesPath), 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create overlay repository: %w", err)
+	}
+	if err := os.WriteFile(alternatesPath, []byte(filepath.Join(commonDir, "objects")+"\n"), 0o600); err != nil {
+		return nil, fmt.Errorf("unable to create overlay repository: %w", err)
+	}
+
+	if len(refs) == 0 {
+		return overlay, nil
+	}
+
+	refNames := make([]string, 0, len(refs))
+	for refName := range refs {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	updates := new(bytes.Buffer)
+	for _, refName := range refNames {
+		fmt.Fprintf(updates, "create %s %s\n", refName, refs[refName].String())
+	}
+
+	// The overlay's references are its own, so they can be created even when
+	// r is in the quarantine environment
+	if _, err := overlay.executor("update-ref", "--stdin").withoutEnv(quarantinePathKey).withStdIn(updates).executeString(); err != nil {
+		return nil, fmt.Errorf("unable to set references in overlay repository: %w", err)
+	}
+
+	return overlay, nil
+}
@@ -0,0 +1,81 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOverlayRepository(t *testing.T) {
+	for _, objectFormat := range []ObjectFormat{ObjectFormatSHA1, ObjectFormatSHA256} {
+		t.Run(string(objectFormat), func(t *testing.T) {
+			tempDir := t.TempDir()
+			repo := CreateTestGitRepository(t, tempDir, true, WithObjectFormat(objectFormat))
+
+			treeBuilder := NewTreeBuilder(repo)
+			emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+			require.Nil(t, err)
+
+			firstCommitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+			require.Nil(t, err)
+			secondCommitID, err := repo.Commit(emptyTreeID, "refs/heads/feature", "Feature commit\n", false)
+			require.Nil(t, err)
+
+			t.Run("references are independent", func(t *testing.T) {
+				overlay, err := repo.CreateOverlayRepository(filepath.Join(t.TempDir(), "overlay"), map[string]Hash{"refs/heads/main": secondCommitID})
+				require.Nil(t, err)
+				assert.Equal(t, objectFormat, overlay.GetObjectFormat())
+
+				overlayTip, err := overlay.GetReference("refs/heads/main")
+				require.Nil(t, err)
+				assert.Equal(t, secondCommitID, overlayTip)
+
+				_, err = overlay.GetReference("refs/heads/feature")
+				assert.ErrorIs(t, err, ErrReferenceNotFound)
+
+				message, err := overlay.GetCommitMessage(firstCommitID)
+				require.Nil(t, err)
+				assert.Equal(t, "Initial commit", message)
+
+				// Updating the overlay does not affect the repository
+				require.Nil(t, overlay.SetReference("refs/heads/main", firstCommitID))
+				blobID, err := overlay.WriteBlob([]byte("overlay"))
+				require.Nil(t, err)
+
+				repoTip, err := repo.GetReference("refs/heads/main")
+				require.Nil(t, err)
+				assert.Equal(t, firstCommitID, repoTip)
+				repoTip, err = repo.GetReference("refs/heads/feature")
+				require.Nil(t, err)
+				assert.Equal(t, secondCommitID, repoTip)
+				assert.False(t, repo.HasObject(blobID))
+			})
+
+			t.Run("in quarantine environment", func(t *testing.T) {
+				t.Setenv(quarantinePathKey, t.TempDir())
+
+				overlay, err := repo.CreateOverlayRepository(filepath.Join(t.TempDir(), "overlay"), map[string]Hash{"refs/heads/main": secondCommitID})
+				require.Nil(t, err)
+
+				overlayTip, err := overlay.GetReference("refs/heads/main")
+				require.Nil(t, err)
+				assert.Equal(t, secondCommitID, overlayTip)
+			})
+
+			t.Run("no references", func(t *testing.T) {
+				overlay, err := repo.CreateOverlayRepository(filepath.Join(t.TempDir(), "overlay"), nil)
+				require.Nil(t, err)
+
+				refNames, err := overlay.ListReferences("")
+				require.Nil(t, err)
+				assert.Empty(t, refNames)
+				assert.True(t, overlay.HasObject(firstCommitID))
+			})
+		})
+	}
+}
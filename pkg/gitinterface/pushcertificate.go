@@ -0,0 +1,210 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+)
+
+const (
+	pushCertificateVersionHeader = "certificate version 0.1"
+	pushCertificatePusherHeader  = "pusher "
+	pushCertificatePusheeHeader  = "pushee "
+	pushCertificateNonceHeader   = "nonce "
+	pushCertificateOptionHeader  = "push-option "
+)
+
+var ErrInvalidPushCertificate = errors.New("invalid push certificate")
+
+// pushCertificateSignatureHeaders are the headers that indicate the start of
+// the signature in a push certificate, one for each signing method supported
+// by Git.
+var pushCertificateSignatureHeaders = []string{
+	"-----BEGIN PGP SIGNATURE-----",
+	"-----BEGIN PGP MESSAGE-----",
+	"-----BEGIN SSH SIGNATURE-----",
+	"-----BEGIN SIGNED MESSAGE-----",
+}
+
+// PushCertificate is a push certificate sent by Git when pushing with
+// `git push --signed`. It is a signed statement of the reference updates the
+// pusher intended to make. Git makes the certificate available to the
+// receiving repository's hooks as a blob identified by the GIT_PUSH_CERT
+// environment variable.
+type PushCertificate struct {
+	// Pusher is the Git identity of the pusher, including a timestamp.
+	Pusher string
+
+	// Pushee is the URL of the repository the push was sent to. It may be
+	// empty.
+	Pushee string
+
+	// Nonce is the nonce issued by the receiving repository, if any.
+	Nonce string
+
+	// PushOptions are the push options sent with the push.
+	PushOptions []string
+
+	// Updates are the reference updates made in the push.
+	Updates []*PushCertificateUpdate
+
+	payload   []byte
+	signature string
+}
+
+// PushCertificateUpdate is a reference update recorded in a push
+// certificate.
+type PushCertificateUpdate struct {
+	RefName string
+	OldID   Hash
+	NewID   Hash
+}
+
+// ParsePushCertificate parses the contents of a push certificate. The
+// certificate's signature is not verified.
+func ParsePushCertificate(contents []byte) (*PushCertificate, error) {
+	signatureIndex := -1
+	for _, header := range pushCertificateSignatureHeaders {
+		index := bytes.Index(contents, []byte("\n"+header))
+		if index == -1 {
+			continue
+		}
+		if signatureIndex == -1 || index+1 < signatureIndex {
+			signatureIndex = index + 1
+		}
+	}
+	if signatureIndex == -1 {
+		return nil, fmt.Errorf("%w: signature not found", ErrInvalidPushCertificate)
+	}
+
+	certificate := &PushCertificate{
+		payload:   contents[:signatureIndex],
+		signature: string(contents[signatureIndex:]),
+	}
+
+	header, commands, found := strings.Cut(string(certificate.payload), "\n\n")
+	if !found {
+		return nil, fmt.Errorf("%w: commands not found", ErrInvalidPushCertificate)
+	}
+
+	headerLines := strings.Split(header, "\n")
+	if headerLines[0] != pushCertificateVersionHeader {
+		return nil, fmt.Errorf("%w: unsupported version '%s'", ErrInvalidPushCertificate, headerLines[0])
+	}
+	for _, line := range headerLines[1:] {
+		switch {
+		case strings.HasPrefix(line, pushCertificatePusherHeader):
+			certificate.Pusher = strings.TrimPrefix(line, pushCertificatePusherHeader)
+		case strings.HasPrefix(line, pushCertificatePusheeHeader):
+			certificate.Pushee = strings.TrimPrefix(line, pushCertificatePusheeHeader)
+		case strings.HasPrefix(line, pushCertificateNonceHeader):
+			certificate.Nonce = strings.TrimPrefix(line, pushCertificateNonceHeader)
+		case strings.HasPrefix(line, pushCertificateOptionHeader):
+			certificate.PushOptions = append(certificate.PushOptions, strings.TrimPrefix(line, pushCertificateOptionHeader))
+		}
+	}
+	if certificate.Pusher == "" {
+		return nil, fmt.Errorf("%w: pusher not found", ErrInvalidPushCertificate)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(commands, "\n"), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: invalid command '%s'", ErrInvalidPushCertificate, line)
+		}
+
+		oldID, err := NewHash(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid command '%s': %w", ErrInvalidPushCertificate, line, err)
+		}
+		newID, err := NewHash(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid command '%s': %w", ErrInvalidPushCertificate, line, err)
+		}
+
+		certificate.Updates = append(certificate.Updates, &PushCertificateUpdate{RefName: fields[2], OldID: oldID, NewID: newID})
+	}
+
+	return certificate, nil
+}
+
+// HasUpdate returns true if the push certificate records the update of
+// refName from oldID to newID.
+func (c *PushCertificate) HasUpdate(refName string, oldID, newID Hash) bool {
+	for _, update := range c.Updates {
+		if update.RefName == refName && update.OldID.Equal(oldID) && update.NewID.Equal(newID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsForRepository returns true if the push certificate was issued for a push
+// to the repository at location, i.e., the certificate's pushee matches
+// location. Trailing slashes and the `.git` suffix are ignored when comparing
+// the two. A certificate without a pushee is not bound to any repository.
+func (c *PushCertificate) IsForRepository(location string) bool {
+	if c.Pushee == "" || location == "" {
+		return false
+	}
+
+	return normalizeRepositoryLocation(c.Pushee) == normalizeRepositoryLocation(location)
+}
+
+func normalizeRepositoryLocation(location string) string {
+	return strings.TrimSuffix(strings.TrimRight(location, "/"), ".git")
+}
+
+// VerifyPushCertificate verifies the signature of the push certificate using
+// the provided public key.
+func (r *Repository) VerifyPushCertificate(ctx context.Context, certificate *PushCertificate, key *signerverifier.SSLibKey) error {
+	return r.verifyContentsSignature(ctx, certificate.payload, certificate.signature, key)
+}
+
+// TestPushCertificatePushee is the pushee of push certificates created using
+// CreateTestPushCertificate.
+const TestPushCertificatePushee = "https://example.com/repository"
+
+// CreateTestPushCertificate creates a push certificate for the updates, signed
+// using the specified PEM encoded SSH or GPG key. The certificate's pushee is
+// TestPushCertificatePushee. This is meant to be used by tests across gittuf
+// packages.
+func CreateTestPushCertificate(t *testing.T, updates []*PushCertificateUpdate, signingKeyPEMBytes []byte) []byte {
+	t.Helper()
+
+	return CreateTestPushCertificateForPushee(t, TestPushCertificatePushee, updates, signingKeyPEMBytes)
+}
+
+// CreateTestPushCertificateForPushee creates a push certificate for the
+// updates made in a push to the repository at pushee, signed using the
+// specified PEM encoded SSH or GPG key. This is meant to be used by tests
+// across gittuf packages.
+func CreateTestPushCertificateForPushee(t *testing.T, pushee string, updates []*PushCertificateUpdate, signingKeyPEMBytes []byte) []byte {
+	t.Helper()
+
+	payload := new(strings.Builder)
+	payload.WriteString(pushCertificateVersionHeader + "\n")
+	fmt.Fprintf(payload, "%s%s <%s> %d +0000\n", pushCertificatePusherHeader, testName, testEmail, testClock.Now().Unix())
+	payload.WriteString(pushCertificatePusheeHeader + pushee + "\n")
+	payload.WriteString(pushCertificateNonceHeader + "1234567890-abcdef\n")
+	payload.WriteString("\n")
+	for _, update := range updates {
+		fmt.Fprintf(payload, "%s %s %s\n", update.OldID.String(), update.NewID.String(), update.RefName)
+	}
+
+	signature, err := signGitObjectUsingKey([]byte(payload.String()), signingKeyPEMBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []byte(payload.String() + signature)
+}
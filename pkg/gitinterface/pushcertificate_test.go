@@ -0,0 +1,125 @@
+// Copyright The gittuf Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/secure-systems-lab/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitPushCertificate was created by Git using `git push --signed` with the
+// test ECDSA SSH key.
+const gitPushCertificate = `certificate version 0.1
+pusher SHA256:oNYBImx035m3rl1Sn/+j5DPrlS9+zXn7k3mjNrC5eto  1792349982 +0000
+pushee file:///tmp/pc/r.git
+nonce 1792349982-e5a21754ec82a0a336354677f7da10a2fc5f1846
+push-option opt1
+
+0000000000000000000000000000000000000000 62c8ede89b2d33e9fa7f19db109d324a5809e146 refs/heads/main
+-----BEGIN SSH SIGNATURE-----
+U1NIU0lHAAAAAQAAAGgAAAATZWNkc2Etc2hhMi1uaXN0cDI1NgAAAAhuaXN0cDI1NgAAAE
+EENtHKw//GUZAA+UIzgjYbhwUCy9th9jHHpJ0hroydETWDBpHcFU5rVv4CAtMWp2lKaKQS
+cIQTaYLgtTWZJm//jQAAAANnaXQAAAAAAAAABnNoYTUxMgAAAGQAAAATZWNkc2Etc2hhMi
+1uaXN0cDI1NgAAAEkAAAAhAL4RPfJGipxEmd9GlwrpyW11YTe+GMLSAwQXbg2rslARAAAA
+ICPWVLwTM63EPGDj7LEPc4lZynEZSW4mw4n8kZVMi7sS
+-----END SSH SIGNATURE-----
+`
+
+func TestParsePushCertificate(t *testing.T) {
+	t.Run("push certificate created by Git", func(t *testing.T) {
+		certificate, err := ParsePushCertificate([]byte(gitPushCertificate))
+		require.Nil(t, err)
+
+		assert.Equal(t, "SHA256:oNYBImx035m3rl1Sn/+j5DPrlS9+zXn7k3mjNrC5eto  1792349982 +0000", certificate.Pusher)
+		assert.Equal(t, "file:///tmp/pc/r.git", certificate.Pushee)
+		assert.Equal(t, "1792349982-e5a21754ec82a0a336354677f7da10a2fc5f1846", certificate.Nonce)
+		assert.Equal(t, []string{"opt1"}, certificate.PushOptions)
+
+		newID, err := NewHash("62c8ede89b2d33e9fa7f19db109d324a5809e146")
+		require.Nil(t, err)
+		assert.Equal(t, []*PushCertificateUpdate{{RefName: "refs/heads/main", OldID: ZeroHash, NewID: newID}}, certificate.Updates)
+		assert.True(t, certificate.HasUpdate("refs/heads/main", ZeroHash, newID))
+		assert.False(t, certificate.HasUpdate("refs/heads/feature", ZeroHash, newID))
+		assert.False(t, certificate.HasUpdate("refs/heads/main", newID, ZeroHash))
+		assert.True(t, certificate.IsForRepository("file:///tmp/pc/r.git"))
+		assert.True(t, certificate.IsForRepository("file:///tmp/pc/r/"))
+		assert.False(t, certificate.IsForRepository("file:///tmp/pc/fork.git"))
+		assert.False(t, certificate.IsForRepository(""))
+	})
+
+	t.Run("unsigned push certificate", func(t *testing.T) {
+		_, err := ParsePushCertificate([]byte("certificate version 0.1\npusher Jane Doe <jane.doe@example.com> 1 +0000\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidPushCertificate)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ParsePushCertificate([]byte("certificate version 0.2\npusher Jane Doe <jane.doe@example.com> 1 +0000\n\n0000000000000000000000000000000000000000 62c8ede89b2d33e9fa7f19db109d324a5809e146 refs/heads/main\n-----BEGIN SSH SIGNATURE-----\n-----END SSH SIGNATURE-----\n"))
+		assert.ErrorIs(t, err, ErrInvalidPushCertificate)
+	})
+
+	t.Run("invalid command", func(t *testing.T) {
+		_, err := ParsePushCertificate([]byte("certificate version 0.1\npusher Jane Doe <jane.doe@example.com> 1 +0000\n\ninvalid refs/heads/main\n-----BEGIN SSH SIGNATURE-----\n-----END SSH SIGNATURE-----\n"))
+		assert.ErrorIs(t, err, ErrInvalidPushCertificate)
+	})
+}
+
+func TestVerifyPushCertificate(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tmpDir, true)
+
+	sshKey := func(t *testing.T, publicKeyBytes []byte) *signerverifier.SSLibKey {
+		t.Helper()
+
+		keyPath := filepath.Join(t.TempDir(), "ssh-key.pub")
+		require.Nil(t, os.WriteFile(keyPath, publicKeyBytes, 0o600))
+		key, err := ssh.NewKeyFromFile(keyPath)
+		require.Nil(t, err)
+		return key
+	}
+
+	t.Run("push certificate created by Git", func(t *testing.T) {
+		certificate, err := ParsePushCertificate([]byte(gitPushCertificate))
+		require.Nil(t, err)
+
+		err = repo.VerifyPushCertificate(context.Background(), certificate, sshKey(t, artifacts.SSHECDSAPublicSSH))
+		assert.Nil(t, err)
+
+		err = repo.VerifyPushCertificate(context.Background(), certificate, sshKey(t, artifacts.SSHED25519PublicSSH))
+		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+	})
+
+	updates := []*PushCertificateUpdate{{RefName: "refs/heads/main", OldID: ZeroHash, NewID: ZeroHash}}
+
+	t.Run("ssh", func(t *testing.T) {
+		certificate, err := ParsePushCertificate(CreateTestPushCertificate(t, updates, artifacts.SSHED25519Private))
+		require.Nil(t, err)
+
+		err = repo.VerifyPushCertificate(context.Background(), certificate, sshKey(t, artifacts.SSHED25519PublicSSH))
+		assert.Nil(t, err)
+	})
+
+	t.Run("gpg", func(t *testing.T) {
+		certificate, err := ParsePushCertificate(CreateTestPushCertificate(t, updates, artifacts.GPGKey1Private))
+		require.Nil(t, err)
+
+		key, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey1Public)
+		require.Nil(t, err)
+		err = repo.VerifyPushCertificate(context.Background(), certificate, key)
+		assert.Nil(t, err)
+
+		key, err = gpg.LoadGPGKeyFromBytes(artifacts.GPGKey2Public)
+		require.Nil(t, err)
+		err = repo.VerifyPushCertificate(context.Background(), certificate, key)
+		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+	})
+}
@@ -59,6 +59,27 @@ func (r *Repository) DeleteReference(refName string) error {
 	return nil
 }
 
+// ListReferences returns the names of all Git references in the repository
+// that begin with the specified prefix. If the prefix is empty, all references
+// are returned.
+func (r *Repository) ListReferences(prefix string) ([]string, error) {
+	args := []string{"for-each-ref", "--format=%(refname)"}
+	if prefix != "" {
+		args = append(args, prefix)
+	}
+
+	output, err := r.executor(args...).executeString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Git references: %w", err)
+	}
+
+	if output == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(output, "\n"), nil
+}
+
 // CheckAndSetReference sets the specified reference to the provided Git ID if
 // the reference is currently set to `oldGitID`.
 func (r *Repository) CheckAndSetReference(refName string, newGitID, oldGitID Hash) error {
@@ -114,6 +114,42 @@ func TestCheckAndSetReference(t *testing.T) {
 	})
 }
 
+func TestListReferences(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := CreateTestGitRepository(t, tempDir, false)
+
+	treeBuilder := NewTreeBuilder(repo)
+
+	// Write empty tree
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("no references", func(t *testing.T) {
+		refs, err := repo.ListReferences("")
+		assert.Nil(t, err)
+		assert.Empty(t, refs)
+	})
+
+	commitID, err := repo.Commit(emptyTreeID, "refs/heads/main", "Initial commit\n", false)
+	require.Nil(t, err)
+	require.Nil(t, repo.SetReference("refs/heads/feature", commitID))
+	require.Nil(t, repo.SetReference("refs/tags/v1", commitID))
+
+	t.Run("all references", func(t *testing.T) {
+		refs, err := repo.ListReferences("")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main", "refs/tags/v1"}, refs)
+	})
+
+	t.Run("references with prefix", func(t *testing.T) {
+		refs, err := repo.ListReferences(BranchRefPrefix)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"refs/heads/feature", "refs/heads/main"}, refs)
+	})
+}
+
 func TestGetSymbolicReferenceTarget(t *testing.T) {
 	tempDir := t.TempDir()
 	repo := CreateTestGitRepository(t, tempDir, false)
@@ -3,6 +3,11 @@
 
 package gitinterface
 
+import (
+	"fmt"
+	"strings"
+)
+
 // AddRemote adds a remote with the specified name and URL.
 func (r *Repository) AddRemote(remoteName, url string) error {
 	_, err := r.executor("remote", "add", remoteName, url).executeString()
@@ -19,3 +24,38 @@ func (r *Repository) RemoveRemote(remoteName string) error {
 func (r *Repository) GetRemoteURL(remoteName string) (string, error) {
 	return r.executor("remote", "get-url", remoteName).executeString()
 }
+
+// ListRemoteReferences returns the references advertised by the remote with
+// the specified name and their tips. Only references with the prefix are
+// returned, all references are returned if the prefix is empty. Peeled tags
+// and symbolic references such as HEAD are not included.
+func (r *Repository) ListRemoteReferences(remoteName, prefix string) (map[string]Hash, error) {
+	output, err := r.executor("ls-remote", remoteName).executeString()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list remote references: %w", err)
+	}
+
+	refs := map[string]Hash{}
+	if output == "" {
+		return refs, nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		tip, refName, found := strings.Cut(line, "\t")
+		if !found {
+			return nil, fmt.Errorf("unable to list remote references: unexpected line '%s'", line)
+		}
+
+		if !strings.HasPrefix(refName, "refs/") || strings.HasSuffix(refName, "^{}") || !strings.HasPrefix(refName, prefix) {
+			continue
+		}
+
+		tipHash, err := NewHash(tip)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list remote references: %w", err)
+		}
+		refs[refName] = tipHash
+	}
+
+	return refs, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,3 +54,53 @@ func TestRemote(t *testing.T) {
 	}
 	assert.Equal(t, "", output) // no output because there are no remotes
 }
+
+func TestListRemoteReferences(t *testing.T) {
+	remoteName := "origin"
+
+	localTmpDir := t.TempDir()
+	remoteTmpDir := t.TempDir()
+
+	localRepo := CreateTestGitRepository(t, localTmpDir, false)
+	remoteRepo := CreateTestGitRepository(t, remoteTmpDir, true)
+
+	if err := localRepo.AddRemote(remoteName, remoteTmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := localRepo.ListRemoteReferences(remoteName, "")
+	assert.Nil(t, err)
+	assert.Empty(t, refs)
+
+	treeBuilder := NewTreeBuilder(remoteRepo)
+	emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := remoteRepo.Commit(emptyTreeID, "refs/heads/main", "Test commit\n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remoteRepo.SetReference("refs/gittuf/reference-state-log", commitID); err != nil {
+		t.Fatal(err)
+	}
+	tagID, err := remoteRepo.TagUsingSpecificKey(commitID, "v1", "v1\n", artifacts.SSHED25519Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err = localRepo.ListRemoteReferences(remoteName, "")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]Hash{
+		"refs/heads/main":                 commitID,
+		"refs/gittuf/reference-state-log": commitID,
+		"refs/tags/v1":                    tagID,
+	}, refs)
+
+	refs, err = localRepo.ListRemoteReferences(remoteName, "refs/gittuf/")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]Hash{"refs/gittuf/reference-state-log": commitID}, refs)
+
+	_, err = localRepo.ListRemoteReferences("does-not-exist", "")
+	assert.ErrorContains(t, err, "unable to list remote references")
+}
@@ -253,6 +253,18 @@ func (e *executor) withEnv(env ...string) *executor {
 	return e
 }
 
+// withoutEnv removes the specified environment variable.
+func (e *executor) withoutEnv(key string) *executor {
+	env := make([]string, 0, len(e.env))
+	for _, keyValue := range e.env {
+		if !strings.HasPrefix(keyValue, key+"=") {
+			env = append(env, keyValue)
+		}
+	}
+	e.env = env
+	return e
+}
+
 // withoutGitDir ensures the executor doesn't auto-set the --git-dir flag to the
 // executed command.
 func (e *executor) withoutGitDir() *executor {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
 	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
 	sslibsvssh "github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/hiddeco/sshsig"
@@ -86,6 +87,42 @@ func (r *Repository) VerifySignature(ctx context.Context, objectID Hash, key *si
 	return ErrNotCommitOrTag
 }
 
+// verifyContentsSignature verifies the signature for the contents of a Git
+// object or similar signed payload, such as a push certificate, using the
+// provided public key.
+func (r *Repository) verifyContentsSignature(ctx context.Context, contents []byte, signature string, key *signerverifier.SSLibKey) error {
+	if signatureBlockCount(signature) > 1 {
+		return errors.Join(ErrIncorrectVerificationKey, ErrMultipleSignatures)
+	}
+
+	switch key.KeyType {
+	case gpg.KeyType:
+		verifier, err := gpg.NewVerifierFromKey(key)
+		if err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+		if err := verifier.Verify(ctx, contents, []byte(signature)); err != nil {
+			return ErrIncorrectVerificationKey
+		}
+
+		return nil
+	case sslibsvssh.KeyType:
+		if err := verifySSHKeySignature(ctx, key, contents, []byte(signature)); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	case sigstore.KeyType:
+		if err := verifyGitsignSignature(ctx, r, key, contents, []byte(signature)); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	}
+
+	return ErrUnknownSigningMethod
+}
+
 func signGitObjectUsingKey(contents, pemKeyBytes []byte) (string, error) {
 	block, _ := pem.Decode(pemKeyBytes)
 	if block == nil {
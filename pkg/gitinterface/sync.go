@@ -25,8 +25,52 @@ func WithFetchDepth(depth int) FetchOption {
 	}
 }
 
-func (r *Repository) PushRefSpec(remoteName string, refSpecs []string) error {
-	args := []string{"push", remoteName}
+type PushOptions struct {
+	Atomic             bool
+	ExpectedRemoteTips map[string]Hash
+}
+
+type PushOption func(*PushOptions)
+
+// WithAtomicPush requests that the remote applies all of the pushed reference
+// updates or none of them.
+func WithAtomicPush() PushOption {
+	return func(o *PushOptions) {
+		o.Atomic = true
+	}
+}
+
+// WithExpectedRemoteTips sets the tips the pushed references are expected to
+// have on the remote. The push is rejected if a reference has a different tip
+// on the remote, while an update that is not a fast-forward is allowed. The
+// zero hash indicates the reference must not exist on the remote.
+func WithExpectedRemoteTips(expectedRemoteTips map[string]Hash) PushOption {
+	return func(o *PushOptions) {
+		o.ExpectedRemoteTips = expectedRemoteTips
+	}
+}
+
+func (r *Repository) PushRefSpec(remoteName string, refSpecs []string, opts ...PushOption) error {
+	options := &PushOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	args := []string{"push"}
+
+	if options.Atomic {
+		args = append(args, "--atomic")
+	}
+
+	for refName, expectedTip := range options.ExpectedRemoteTips {
+		if expectedTip.IsZero() {
+			args = append(args, fmt.Sprintf("--force-with-lease=%s:", refName))
+			continue
+		}
+		args = append(args, fmt.Sprintf("--force-with-lease=%s:%s", refName, expectedTip.String()))
+	}
+
+	args = append(args, remoteName)
 	args = append(args, refSpecs...)
 
 	_, err := r.executor(args...).executeString()
@@ -162,6 +162,61 @@ func TestPushRefSpecRepository(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("atomic push with expected remote tips", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+		remoteTmpDir := t.TempDir()
+
+		localRepo := CreateTestGitRepository(t, localTmpDir, false)
+		remoteRepo := CreateTestGitRepository(t, remoteTmpDir, true)
+
+		if err := localRepo.CreateRemote(remoteName, remoteTmpDir); err != nil {
+			t.Fatal(err)
+		}
+
+		treeBuilder := NewTreeBuilder(localRepo)
+		emptyTreeID, err := treeBuilder.WriteTreeFromEntries(nil)
+		require.Nil(t, err)
+		firstCommitID, err := localRepo.Commit(emptyTreeID, refName, "Test commit\n", false)
+		require.Nil(t, err)
+
+		err = localRepo.PushRefSpec(remoteName, []string{refSpecs}, WithAtomicPush(), WithExpectedRemoteTips(map[string]Hash{refName: ZeroHash}))
+		assert.Nil(t, err)
+
+		remoteRef, err := remoteRepo.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, firstCommitID, remoteRef)
+
+		// Rewrite the local reference, which is not a fast-forward update
+		require.Nil(t, localRepo.DeleteReference(refName))
+		secondCommitID, err := localRepo.Commit(emptyTreeID, refName, "Another test commit\n", false)
+		require.Nil(t, err)
+
+		featureRefName := "refs/heads/feature"
+		featureRefSpecs := fmt.Sprintf("%s:%s", refName, featureRefName)
+
+		// The remote tip does not match the expected tip, so no reference
+		// is updated
+		err = localRepo.PushRefSpec(remoteName, []string{refSpecs, featureRefSpecs}, WithAtomicPush(), WithExpectedRemoteTips(map[string]Hash{refName: secondCommitID, featureRefName: ZeroHash}))
+		assert.ErrorContains(t, err, "unable to push")
+
+		remoteRef, err = remoteRepo.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, firstCommitID, remoteRef)
+		_, err = remoteRepo.GetReference(featureRefName)
+		assert.ErrorIs(t, err, ErrReferenceNotFound)
+
+		// The remote tip matches the expected tip
+		err = localRepo.PushRefSpec(remoteName, []string{refSpecs, featureRefSpecs}, WithAtomicPush(), WithExpectedRemoteTips(map[string]Hash{refName: firstCommitID, featureRefName: ZeroHash}))
+		assert.Nil(t, err)
+
+		remoteRef, err = remoteRepo.GetReference(refName)
+		require.Nil(t, err)
+		assert.Equal(t, secondCommitID, remoteRef)
+		remoteRef, err = remoteRepo.GetReference(featureRefName)
+		require.Nil(t, err)
+		assert.Equal(t, secondCommitID, remoteRef)
+	})
+
 	t.Run("push to non-existent remote", func(t *testing.T) {
 		localTmpDir := t.TempDir()
 		localRepo := CreateTestGitRepository(t, localTmpDir, false)
@@ -10,9 +10,6 @@ import (
 	"io"
 	"strings"
 
-	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
-	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
-	"github.com/gittuf/gittuf/internal/signerverifier/ssh"
 	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
 	"github.com/go-git/go-git/v6/storage/memory"
@@ -105,13 +102,13 @@ func (r *Repository) GetTagTarget(tagID Hash) (Hash, error) {
 // verifyTagSignature verifies a signature for the specified tag using the
 // provided public key.
 func (r *Repository) verifyTagSignature(ctx context.Context, tagID Hash, key *signerverifier.SSLibKey) error {
-	goGitRepo, err := r.GetGoGitRepository()
+	obj, err := r.readGoGitObject(tagID, plumbing.TagObject)
 	if err != nil {
-		return fmt.Errorf("error opening repository: %w", err)
+		return fmt.Errorf("unable to load tag object: %w", err)
 	}
 
-	tag, err := goGitRepo.TagObject(plumbing.NewHash(tagID.String()))
-	if err != nil {
+	tag := &object.Tag{}
+	if err := tag.Decode(obj); err != nil {
 		return fmt.Errorf("unable to load tag object: %w", err)
 	}
 
@@ -125,36 +122,7 @@ func (r *Repository) verifyTagSignature(ctx context.Context, tagID Hash, key *si
 	// commits, where the header depends on the hash algorithm).
 	tagSignature := tag.Signature
 
-	if signatureBlockCount(tagSignature) > 1 {
-		return errors.Join(ErrIncorrectVerificationKey, ErrMultipleSignatures)
-	}
-
-	switch key.KeyType {
-	case gpg.KeyType:
-		verifier, err := gpg.NewVerifierFromKey(key)
-		if err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
-		if err := verifier.Verify(ctx, tagContents, []byte(tagSignature)); err != nil {
-			return ErrIncorrectVerificationKey
-		}
-
-		return nil
-	case ssh.KeyType:
-		if err := verifySSHKeySignature(ctx, key, tagContents, []byte(tagSignature)); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
-
-		return nil
-	case sigstore.KeyType:
-		if err := verifyGitsignSignature(ctx, r, key, tagContents, []byte(tagSignature)); err != nil {
-			return errors.Join(ErrIncorrectVerificationKey, err)
-		}
-
-		return nil
-	}
-
-	return ErrUnknownSigningMethod
+	return r.verifyContentsSignature(ctx, tagContents, tagSignature, key)
 }
 
 func (r *Repository) ensureIsTag(tagID Hash) error {